@@ -0,0 +1,250 @@
+// Package app wires the repositories, services and controllers of the
+// application together and serves them over HTTP.
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/config"
+	"github.com/muzammil-cyber/golang-gin/controller"
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/graph"
+	"github.com/muzammil-cyber/golang-gin/mailer"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+// App is the application: its configuration, its database and the HTTP
+// handler serving it
+type App struct {
+	Config config.Config
+	DB     database.Database
+	Router *gin.Engine
+
+	trashService service.TrashService
+	draining     atomic.Bool
+	hooks        []shutdownHook
+}
+
+// shutdownHook releases a resource when the application stops
+type shutdownHook struct {
+	name  string
+	close func(ctx context.Context) error
+}
+
+// New wires the application on db, whose schema must be migrated. It seeds
+// the built-in roles and the admin account of cfg.
+func New(cfg config.Config, db database.Database) (*App, error) {
+	videoRepository := repository.NewVideoRepository(db)
+	userRepository := repository.NewUserRepository(db)
+	roleRepository := repository.NewRoleRepository(db)
+	actionTokenRepository := repository.NewActionTokenRepository(db)
+	refreshTokenRepository := repository.NewRefreshTokenRepository(db)
+	authorRepository := repository.NewAuthorRepository(db)
+	revisionRepository := repository.NewRevisionRepository(db)
+
+	jwtService, err := service.NewJWTService(service.JWTConfig{
+		SigningMethod:        cfg.JWT.SigningMethod,
+		Secret:               cfg.JWT.SecretKey,
+		PrivateKeyFile:       cfg.JWT.PrivateKeyFile,
+		KeyID:                cfg.JWT.KeyID,
+		VerificationKeyFiles: cfg.JWT.VerificationKeyFiles,
+		Issuer:               cfg.JWT.Issuer,
+		Expiry:               time.Duration(cfg.JWT.ExpiryMinutes) * time.Minute,
+		DevMode:              cfg.IsDevelopment(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure JWT signing: %w", err)
+	}
+	oidcService, err := service.NewOIDCService(service.OIDCConfig{
+		IssuerURL:    cfg.OIDC.IssuerURL,
+		ClientID:     cfg.OIDC.ClientID,
+		ClientSecret: cfg.OIDC.ClientSecret,
+		RedirectURL:  cfg.OIDCRedirectURL(),
+		Scopes:       cfg.OIDC.Scopes,
+		RolesClaim:   cfg.OIDC.RolesClaim,
+		RoleMapping:  roleMapping(cfg.OIDC.RoleMapping),
+		DefaultRole:  cfg.OIDC.DefaultRole,
+	}, userRepository, roleRepository, repository.NewIdentityRepository(db), actionTokenRepository, jwtService)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure OIDC login: %w", err)
+	}
+	videoService := service.New(videoRepository, authorRepository, revisionRepository)
+	trashService := service.NewTrashService(service.TrashConfig{
+		Retention:     time.Duration(cfg.Trash.RetentionDays) * 24 * time.Hour,
+		PurgeInterval: time.Duration(cfg.Trash.PurgeIntervalMinutes) * time.Minute,
+	}, repository.NewTrashRepository(db))
+	authorService := service.NewAuthorService(authorRepository)
+	playlistService := service.NewPlaylistService(repository.NewPlaylistRepository(db), videoRepository)
+	searchService := service.NewSearchService(repository.NewVideoSearcher(db))
+	apiKeyService := service.NewAPIKeyService(repository.NewAPIKeyRepository(db), userRepository)
+	userService := service.NewUserService(userRepository, roleRepository)
+	loginThrottle := service.NewLoginThrottle(service.LoginThrottleConfig{
+		MaxAttempts:      cfg.Throttle.MaxAttempts,
+		MaxAttemptsPerIP: cfg.Throttle.MaxAttemptsPerIP,
+		BaseLockout:      time.Duration(cfg.Throttle.LockoutSeconds) * time.Second,
+		MaxLockout:       time.Duration(cfg.Throttle.MaxLockoutMinutes) * time.Minute,
+		Window:           time.Duration(cfg.Throttle.WindowMinutes) * time.Minute,
+	}, repository.NewLoginAttemptStore(cfg.Throttle.Store, db))
+	tokenService := service.NewTokenService(service.TokenConfig{
+		RefreshExpiry: time.Duration(cfg.Tokens.RefreshExpiryHours) * time.Hour,
+	}, jwtService, userRepository, refreshTokenRepository, repository.NewRevokedTokenRepository(db))
+	mfaService := service.NewMFAService(service.MFAConfig{
+		Issuer:        cfg.MFA.Issuer,
+		RequiredRoles: cfg.MFA.RequiredRoles,
+	}, userRepository, actionTokenRepository, repository.NewRecoveryCodeRepository(db), jwtService)
+	accountService := service.NewAccountService(service.AccountConfig{
+		BaseURL: cfg.Server.BaseURL,
+	}, userRepository, roleRepository, actionTokenRepository, refreshTokenRepository, jwtService, mailer.New(mailer.Config{
+		Driver:   cfg.Mailer.Driver,
+		Dir:      cfg.Mailer.Dir,
+		SMTPAddr: cfg.Mailer.SMTPAddr,
+		From:     cfg.Mailer.From,
+		Username: cfg.Mailer.SMTPUsername,
+		Password: cfg.Mailer.SMTPPassword,
+	}))
+
+	if err := repository.SeedRoles(db); err != nil {
+		return nil, err
+	}
+	if cfg.Admin.Username != "" && cfg.Admin.Password != "" {
+		if err := userService.EnsureUser(cfg.Admin.Username, cfg.Admin.Password, entity.RoleAdmin); err != nil {
+			return nil, fmt.Errorf("failed to seed admin user: %w", err)
+		}
+	}
+
+	application := &App{
+		Config:       cfg,
+		DB:           db,
+		trashService: trashService,
+	}
+	application.Router = NewRouter(RouterDeps{
+		JWTService:         jwtService,
+		TokenService:       tokenService,
+		APIKeyService:      apiKeyService,
+		VideoController:    controller.New(videoService),
+		RevisionController: controller.NewRevisionController(service.NewRevisionService(revisionRepository, videoRepository), videoService),
+		TrashController:    controller.NewTrashController(trashService),
+		AuthorController:   controller.NewAuthorController(authorService),
+		TagController:      controller.NewTagController(service.NewTagService(repository.NewTagRepository(db))),
+		PlaylistController: controller.NewPlaylistController(playlistService),
+		SearchController:   controller.NewSearchController(searchService),
+		APIKeyController:   controller.NewAPIKeyController(apiKeyService),
+		JWKSController:     controller.NewJWKSController(jwtService),
+		UserController:     controller.NewUserController(userService, loginThrottle),
+		MFAController:      controller.NewMFAController(mfaService, tokenService, loginThrottle),
+		LoginController:    controller.NewLoginController(service.NewLoginService(userRepository), tokenService, mfaService, loginThrottle),
+		OIDCController:     controller.NewOIDCController(oidcService, tokenService, mfaService),
+		AccountController:  controller.NewAccountController(accountService),
+		HealthController:   controller.NewHealthController(application.Ready),
+		DumpRequests:       cfg.IsDevelopment(),
+		Resolver: &graph.Resolver{
+			VideoService:    videoService,
+			SearchService:   searchService,
+			PlaylistService: playlistService,
+			AuthorService:   authorService,
+			JWTService:      jwtService,
+		},
+	})
+	return application, nil
+}
+
+// roleMapping turns validated group=role pairs into a map from group to role
+func roleMapping(pairs []string) map[string]string {
+	mapping := map[string]string{}
+	for _, pair := range pairs {
+		group, role, _ := strings.Cut(pair, "=")
+		mapping[strings.TrimSpace(group)] = strings.TrimSpace(role)
+	}
+	return mapping
+}
+
+// Ready reports whether the application accepts new requests: it stops
+// being ready as soon as it starts shutting down
+func (a *App) Ready() bool {
+	return !a.draining.Load()
+}
+
+// OnShutdown registers close to run when the application stops, after the
+// server has finished its requests and the background jobs have stopped.
+// Hooks run in the order they were registered, so resources should be
+// registered before the ones they log to.
+func (a *App) OnShutdown(name string, close func(ctx context.Context) error) {
+	a.hooks = append(a.hooks, shutdownHook{name: name, close: close})
+}
+
+// Run starts the background jobs and serves HTTP on the configured port
+// until ctx is done or the server fails. It then shuts down in order: it
+// fails readiness for the drain period, stops accepting connections and
+// waits for in-flight requests, stops the background jobs and runs the
+// shutdown hooks. Requests, jobs and hooks share the shutdown timeout.
+func (a *App) Run(ctx context.Context) error {
+	server := &http.Server{
+		Addr:         fmt.Sprintf(":%d", a.Config.Server.Port),
+		Handler:      a.Router,
+		ReadTimeout:  time.Duration(a.Config.Server.ReadTimeoutSeconds) * time.Second,
+		WriteTimeout: time.Duration(a.Config.Server.WriteTimeoutSeconds) * time.Second,
+		IdleTimeout:  time.Duration(a.Config.Server.IdleTimeoutSeconds) * time.Second,
+	}
+
+	jobs, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	var running sync.WaitGroup
+	running.Add(1)
+	go func() {
+		defer running.Done()
+		a.trashService.RunRetention(jobs)
+	}()
+
+	served := make(chan error, 1)
+	go func() {
+		served <- server.ListenAndServe()
+	}()
+	log.Printf("connect to http://localhost:%d/ for GraphQL playground", a.Config.Server.Port)
+
+	var errs []error
+	select {
+	case err := <-served:
+		errs = append(errs, fmt.Errorf("the server failed: %w", err))
+	case <-ctx.Done():
+		drain := time.Duration(a.Config.Server.DrainSeconds) * time.Second
+		log.Printf("shutting down: failing readiness for %s before closing connections", drain)
+		a.draining.Store(true)
+		time.Sleep(drain)
+	}
+
+	shutdown, cancel := context.WithTimeout(context.Background(), time.Duration(a.Config.Server.ShutdownTimeoutSeconds)*time.Second)
+	defer cancel()
+	if err := server.Shutdown(shutdown); err != nil {
+		errs = append(errs, fmt.Errorf("failed to finish in-flight requests: %w", err))
+	}
+
+	stopJobs()
+	stopped := make(chan struct{})
+	go func() {
+		running.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-shutdown.Done():
+		errs = append(errs, errors.New("the background jobs did not stop in time"))
+	}
+
+	for _, hook := range a.hooks {
+		if err := hook.close(shutdown); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close the %s: %w", hook.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
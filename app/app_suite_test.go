@@ -0,0 +1,34 @@
+package app_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/database/sqlite"
+	"github.com/muzammil-cyber/golang-gin/migrations"
+)
+
+// testDB is a fresh SQLite file the application is wired on
+var testDB database.Database
+
+func TestApp(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "App Suite")
+}
+
+var _ = BeforeSuite(func() {
+	dir, err := os.MkdirTemp("", "app-suite")
+	Expect(err).To(BeNil())
+	DeferCleanup(os.RemoveAll, dir)
+	testDB, err = sqlite.NewSQLiteDBAt(filepath.Join(dir, "sqlite.db"))
+	Expect(err).To(BeNil())
+	migrator, err := migrations.NewMigrator(testDB)
+	Expect(err).To(BeNil())
+	_, err = migrator.Up()
+	Expect(err).To(BeNil())
+})
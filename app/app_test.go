@@ -0,0 +1,224 @@
+package app_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/app"
+	"github.com/muzammil-cyber/golang-gin/config"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/oidctest"
+)
+
+var _ = Describe("App", func() {
+	var application *app.App
+
+	BeforeEach(func() {
+		gin.SetMode(gin.TestMode)
+		var err error
+		cfg := config.Default()
+		cfg.Env = config.EnvDevelopment
+		cfg.Admin = config.AdminConfig{Username: "routeradmin", Password: "Router-pass1"}
+		application, err = app.New(cfg, testDB)
+		Expect(err).To(BeNil())
+	})
+
+	serve := func(method, path, body, token string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		request.Header.Set("Content-Type", "application/json")
+		if token != "" {
+			request.Header.Set("Authorization", "Bearer "+token)
+		}
+		recorder := httptest.NewRecorder()
+		application.Router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	// login returns an access token of the seeded admin
+	login := func() string {
+		response := serve(http.MethodPost, "/auth/login", `{"username": "routeradmin", "password": "Router-pass1"}`, "")
+		Expect(response.Code).To(Equal(http.StatusOK))
+		var tokens dto.LoginResponse
+		Expect(json.Unmarshal(response.Body.Bytes(), &tokens)).To(Succeed())
+		Expect(tokens.Token).NotTo(BeEmpty())
+		return tokens.Token
+	}
+
+	It("should serve the API to the seeded admin", func() {
+		Expect(serve(http.MethodGet, "/api/videos", "", "").Code).To(Equal(http.StatusUnauthorized))
+
+		token := login()
+		Expect(serve(http.MethodGet, "/api/videos", "", token).Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodGet, "/api/users", "", token).Code).To(Equal(http.StatusOK))
+		Expect(serve(http.MethodGet, "/api/videos/123e4567-e89b-12d3-a456-426614174000", "", token).Code).To(Equal(http.StatusNotFound))
+	})
+
+	It("should change the ETag of a video when its author is edited", func() {
+		token := login()
+		email := fmt.Sprintf("etag-%d@example.com", time.Now().UnixNano())
+		response := serve(http.MethodPost, "/api/videos", `{"title": "ETag video", "url": "https://example.com/etag", "author": {"name": "ETag Author", "email": "`+email+`"}}`, token)
+		Expect(response.Code).To(Equal(http.StatusOK))
+		var video entity.Video
+		Expect(json.Unmarshal(response.Body.Bytes(), &video)).To(Succeed())
+
+		get := func(etag string) *httptest.ResponseRecorder {
+			request := httptest.NewRequest(http.MethodGet, "/api/videos/"+video.ID.String(), nil)
+			request.Header.Set("Authorization", "Bearer "+token)
+			request.Header.Set("If-None-Match", etag)
+			recorder := httptest.NewRecorder()
+			application.Router.ServeHTTP(recorder, request)
+			return recorder
+		}
+		etag := get("").Header().Get("ETag")
+		Expect(etag).NotTo(BeEmpty())
+		Expect(get(etag).Code).To(Equal(http.StatusNotModified))
+
+		response = serve(http.MethodPut, "/api/authors/"+video.Author.ID.String(), `{"name": "Renamed Author", "email": "`+email+`"}`, token)
+		Expect(response.Code).To(Equal(http.StatusOK))
+		response = get(etag)
+		Expect(response.Code).To(Equal(http.StatusOK))
+		Expect(response.Header().Get("ETag")).NotTo(Equal(etag))
+		Expect(response.Body.String()).To(ContainSubstring("Renamed Author"))
+
+		request := httptest.NewRequest(http.MethodDelete, "/api/videos/"+video.ID.String(), nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		request.Header.Set("If-Match", response.Header().Get("ETag"))
+		recorder := httptest.NewRecorder()
+		application.Router.ServeHTTP(recorder, request)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+	})
+
+	It("should count wrong MFA activation codes towards the login lockout", func() {
+		token := login()
+		Expect(serve(http.MethodPost, "/api/me/mfa/enroll", "", token).Code).To(Equal(http.StatusOK))
+		for i := 1; i < config.Default().Throttle.MaxAttempts; i++ {
+			Expect(serve(http.MethodPost, "/api/me/mfa/activate", `{"code": "000000"}`, token).Code).To(Equal(http.StatusUnauthorized))
+		}
+		response := serve(http.MethodPost, "/api/me/mfa/activate", `{"code": "000000"}`, token)
+		Expect(response.Code).To(Equal(http.StatusTooManyRequests))
+		Expect(response.Header().Get("Retry-After")).NotTo(BeEmpty())
+
+		Expect(serve(http.MethodDelete, "/api/me/mfa", `{"code": "000000"}`, token).Code).To(Equal(http.StatusTooManyRequests))
+		Expect(serve(http.MethodPost, "/auth/login", `{"username": "routeradmin", "password": "Router-pass1"}`, "").Code).To(Equal(http.StatusTooManyRequests))
+	})
+
+	It("should challenge single sign-on users who need MFA", func() {
+		provider, err := oidctest.NewProvider("video-api", "video-api-secret")
+		Expect(err).To(BeNil())
+		DeferCleanup(provider.Close)
+		provider.SetUser(oidctest.User{
+			Subject:           "sso-mfa",
+			Email:             "sso-mfa@example.com",
+			EmailVerified:     true,
+			PreferredUsername: "sso-mfa",
+			Claims:            map[string]interface{}{"groups": []string{"video-admins"}},
+		})
+		cfg := config.Default()
+		cfg.Env = config.EnvDevelopment
+		cfg.OIDC.IssuerURL = provider.URL
+		cfg.OIDC.ClientID = provider.ClientID
+		cfg.OIDC.ClientSecret = provider.ClientSecret
+		cfg.OIDC.RoleMapping = []string{"video-admins=" + entity.RoleAdmin}
+		cfg.MFA.RequiredRoles = []string{entity.RoleAdmin}
+		application, err = app.New(cfg, testDB)
+		Expect(err).To(BeNil())
+
+		response := serve(http.MethodGet, "/auth/oidc/login", "", "")
+		Expect(response.Code).To(Equal(http.StatusFound))
+		callbackURL, err := provider.Authorize(response.Header().Get("Location"))
+		Expect(err).To(BeNil())
+		request := httptest.NewRequest(http.MethodGet, callbackURL.RequestURI(), nil)
+		for _, cookie := range response.Result().Cookies() {
+			request.AddCookie(cookie)
+		}
+		recorder := httptest.NewRecorder()
+		application.Router.ServeHTTP(recorder, request)
+		Expect(recorder.Code).To(Equal(http.StatusOK))
+
+		var login dto.LoginResponse
+		Expect(json.Unmarshal(recorder.Body.Bytes(), &login)).To(Succeed())
+		Expect(login.Token).To(BeEmpty())
+		Expect(login.MFARequired).To(BeTrue())
+		Expect(login.MFAEnrollmentRequired).To(BeTrue())
+		Expect(login.MFAToken).NotTo(BeEmpty())
+	})
+
+	It("should require videos:read for GraphQL queries that REST protects", func() {
+		for _, query := range []string{
+			`{ videos { id } }`,
+			`{ authors { videos { id } } }`,
+			`{ videosConnection(first: 1) { totalCount } }`,
+			`{ searchVideos(query: "golang") { snippet } }`,
+			`{ authors { email } }`,
+			`{ author(id: "123e4567-e89b-12d3-a456-426614174000") { email } }`,
+		} {
+			body, err := json.Marshal(map[string]string{"query": query})
+			Expect(err).To(BeNil())
+			response := serve(http.MethodPost, "/query", string(body), "")
+			Expect(response.Code).To(Equal(http.StatusOK))
+			Expect(response.Body.String()).To(ContainSubstring("authentication required"), query)
+
+			response = serve(http.MethodPost, "/query", string(body), login())
+			Expect(response.Code).To(Equal(http.StatusOK))
+			Expect(response.Body.String()).NotTo(ContainSubstring(`"errors"`), query)
+		}
+
+		body, err := json.Marshal(map[string]string{"query": `{ video(id: "123e4567-e89b-12d3-a456-426614174000") { id } }`})
+		Expect(err).To(BeNil())
+		response := serve(http.MethodPost, "/query", string(body), "")
+		Expect(response.Body.String()).To(ContainSubstring("authentication required"))
+		response = serve(http.MethodPost, "/query", string(body), login())
+		Expect(response.Body.String()).NotTo(ContainSubstring("authentication required"))
+	})
+
+	It("should drain and run the shutdown hooks in order", func() {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		Expect(err).To(BeNil())
+		application.Config.Server.Port = listener.Addr().(*net.TCPAddr).Port
+		Expect(listener.Close()).To(Succeed())
+		application.Config.Server.DrainSeconds = 1
+		var closed []string
+		application.OnShutdown("first", func(context.Context) error {
+			closed = append(closed, "first")
+			return nil
+		})
+		application.OnShutdown("second", func(context.Context) error {
+			closed = append(closed, "second")
+			return nil
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+		stopped := make(chan error, 1)
+		go func() {
+			stopped <- application.Run(ctx)
+		}()
+		readyz := fmt.Sprintf("http://127.0.0.1:%d/readyz", application.Config.Server.Port)
+		status := func() int {
+			response, err := http.Get(readyz)
+			if err != nil {
+				return 0
+			}
+			_ = response.Body.Close()
+			return response.StatusCode
+		}
+		Eventually(status).Should(Equal(http.StatusOK))
+
+		cancel()
+		// Readiness fails while connections are still served
+		Eventually(status).Should(Equal(http.StatusServiceUnavailable))
+		Eventually(stopped, "5s").Should(Receive(BeNil()))
+		Expect(closed).To(Equal([]string{"first", "second"}))
+		Expect(status()).To(BeZero())
+	})
+})
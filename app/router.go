@@ -0,0 +1,233 @@
+package app
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/controller"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/graph"
+	"github.com/muzammil-cyber/golang-gin/middleware"
+	"github.com/muzammil-cyber/golang-gin/service"
+	gindump "github.com/tpkeeper/gin-dump"
+
+	_ "github.com/muzammil-cyber/golang-gin/docs"
+
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/99designs/gqlgen/graphql/playground"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// RouterDeps are the services and controllers the HTTP routes are served by
+type RouterDeps struct {
+	// Services the authentication middleware checks credentials with
+	JWTService    service.JWTService
+	TokenService  service.TokenService
+	APIKeyService service.APIKeyService
+
+	HealthController   controller.HealthController
+	VideoController    controller.VideoController
+	RevisionController controller.RevisionController
+	TrashController    controller.TrashController
+	AuthorController   controller.AuthorController
+	TagController      controller.TagController
+	PlaylistController controller.PlaylistController
+	SearchController   controller.SearchController
+	APIKeyController   controller.APIKeyController
+	JWKSController     controller.JWKSController
+	UserController     controller.UserController
+	MFAController      controller.MFAController
+	LoginController    controller.LoginController
+	OIDCController     controller.OIDCController
+	AccountController  controller.AccountController
+
+	// Resolver serves the GraphQL endpoint
+	Resolver *graph.Resolver
+
+	// DumpRequests prints every request and response, headers and bodies
+	// included. They carry passwords and tokens, so it is for development
+	// only.
+	DumpRequests bool
+}
+
+// NewRouter returns the HTTP handler of the REST API, the GraphQL endpoint
+// and the Swagger UI
+func NewRouter(deps RouterDeps) *gin.Engine {
+	server := gin.New()
+
+	server.Use(gin.Recovery(), middleware.Logger())
+	if deps.DumpRequests {
+		server.Use(gindump.Dump())
+	}
+
+	// server.Static("/static", "./templates/static")
+	// server.LoadHTMLGlob("templates/*.html")
+
+	// swagger
+	server.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+
+	// Probes for load balancers and orchestrators
+	server.GET("/healthz", deps.HealthController.Live)
+	server.GET("/readyz", deps.HealthController.Ready)
+
+	// Public API routes (no JWT required)
+	server.GET("/.well-known/jwks.json", deps.JWKSController.GetKeys)
+	server.POST("/auth/login", func(ctx *gin.Context) {
+		tokens := deps.LoginController.Login(ctx)
+		if tokens != nil {
+			ctx.JSON(200, tokens)
+		}
+	})
+	server.POST("/auth/refresh", deps.LoginController.Refresh)
+	server.POST("/auth/logout", middleware.JWTAuthMiddleware(deps.JWTService, deps.TokenService), deps.LoginController.Logout)
+	server.POST("/auth/register", deps.AccountController.Register)
+	server.GET("/auth/verify", deps.AccountController.VerifyEmail)
+	server.POST("/auth/forgot-password", deps.AccountController.ForgotPassword)
+	server.POST("/auth/reset-password", deps.AccountController.ResetPassword)
+	server.POST("/auth/mfa/verify", deps.MFAController.Verify)
+	server.POST("/auth/mfa/enroll", deps.MFAController.EnrollWithChallenge)
+	server.GET("/auth/oidc/login", deps.OIDCController.Login)
+	server.GET("/auth/oidc/callback", deps.OIDCController.Callback)
+
+	// Protected API routes (JWT or API key required)
+	apiRoutes := server.Group("/api", middleware.AuthMiddleware(deps.JWTService, deps.TokenService, deps.APIKeyService))
+	{
+		apiRoutes.POST("/videos", middleware.RequirePermission(auth.PermVideosWrite),
+			deps.VideoController.Save)
+
+		apiRoutes.GET("/videos", middleware.RequirePermission(auth.PermVideosRead), func(ctx *gin.Context) {
+			videos := deps.VideoController.GetAll(ctx)
+			if ctx.Writer.Written() {
+				return
+			}
+			ctx.JSON(200, videos)
+		})
+		apiRoutes.GET("/videos/search", middleware.RequirePermission(auth.PermVideosRead), deps.SearchController.SearchVideos)
+		apiRoutes.GET("/me/videos", middleware.RequirePermission(auth.PermVideosRead), func(ctx *gin.Context) {
+			videos := deps.VideoController.GetMine(ctx)
+			if ctx.Writer.Written() {
+				return
+			}
+			ctx.JSON(200, videos)
+		})
+		apiRoutes.GET("/videos/:id", middleware.RequirePermission(auth.PermVideosRead), func(ctx *gin.Context) {
+			video := deps.VideoController.GetByID(ctx)
+			if ctx.Writer.Written() {
+				return
+			}
+			ctx.JSON(200, video)
+		})
+		apiRoutes.PUT("/videos/:id", middleware.RequirePermission(auth.PermVideosWrite), func(ctx *gin.Context) {
+			updatedVideo := deps.VideoController.Update(ctx)
+			if ctx.Writer.Written() {
+				return
+			}
+			ctx.JSON(200, updatedVideo)
+		})
+		apiRoutes.PATCH("/videos/:id", middleware.RequirePermission(auth.PermVideosWrite), func(ctx *gin.Context) {
+			patchedVideo := deps.VideoController.Patch(ctx)
+			if ctx.Writer.Written() {
+				return
+			}
+			ctx.JSON(200, patchedVideo)
+		})
+		apiRoutes.DELETE("/videos/:id", middleware.RequirePermission(auth.PermVideosDelete), func(ctx *gin.Context) {
+			err := deps.VideoController.Delete(ctx)
+			if err != nil {
+				// The controller has already written the error response
+				return
+			}
+			ctx.JSON(200, dto.MessageResponse{
+				Message: "Video deleted successfully",
+			})
+		})
+		apiRoutes.GET("/videos/:id/revisions", middleware.RequirePermission(auth.PermVideosRead), deps.RevisionController.GetAll)
+		apiRoutes.GET("/videos/:id/revisions/diff", middleware.RequirePermission(auth.PermVideosRead), deps.RevisionController.Diff)
+		apiRoutes.GET("/videos/:id/revisions/:rev", middleware.RequirePermission(auth.PermVideosRead), deps.RevisionController.GetOne)
+		apiRoutes.POST("/videos/:id/revisions/:rev/restore", middleware.RequirePermission(auth.PermVideosWrite), deps.RevisionController.Restore)
+		apiRoutes.GET("/tags", middleware.RequirePermission(auth.PermVideosRead), deps.TagController.GetAll)
+
+		apiRoutes.GET("/trash", middleware.RequirePermission(auth.PermVideosRead), deps.TrashController.GetAll)
+		apiRoutes.POST("/trash/:id/restore", middleware.RequirePermission(auth.PermVideosDelete), deps.TrashController.Restore)
+		apiRoutes.DELETE("/trash/:id", middleware.RequirePermission(auth.PermVideosDelete), deps.TrashController.Purge)
+
+		apiRoutes.POST("/authors", middleware.RequirePermission(auth.PermVideosWrite), deps.AuthorController.Create)
+		apiRoutes.GET("/authors", middleware.RequirePermission(auth.PermVideosRead), deps.AuthorController.GetAll)
+		apiRoutes.GET("/authors/:id", middleware.RequirePermission(auth.PermVideosRead), deps.AuthorController.GetByID)
+		apiRoutes.PUT("/authors/:id", middleware.RequirePermission(auth.PermVideosWrite), deps.AuthorController.Update)
+		apiRoutes.DELETE("/authors/:id", middleware.RequirePermission(auth.PermVideosDelete), deps.AuthorController.Delete)
+
+		apiRoutes.POST("/playlists", middleware.RequirePermission(auth.PermVideosWrite), deps.PlaylistController.Create)
+		apiRoutes.GET("/playlists", middleware.RequirePermission(auth.PermVideosRead), deps.PlaylistController.GetAll)
+		apiRoutes.GET("/playlists/:id", middleware.RequirePermission(auth.PermVideosRead), deps.PlaylistController.GetByID)
+		apiRoutes.PATCH("/playlists/:id", middleware.RequirePermission(auth.PermVideosWrite), deps.PlaylistController.Update)
+		apiRoutes.DELETE("/playlists/:id", middleware.RequirePermission(auth.PermVideosWrite), deps.PlaylistController.Delete)
+		apiRoutes.POST("/playlists/:id/items", middleware.RequirePermission(auth.PermVideosWrite), deps.PlaylistController.AddItem)
+		apiRoutes.PATCH("/playlists/:id/items/:itemId", middleware.RequirePermission(auth.PermVideosWrite), deps.PlaylistController.MoveItem)
+		apiRoutes.DELETE("/playlists/:id/items/:itemId", middleware.RequirePermission(auth.PermVideosWrite), deps.PlaylistController.RemoveItem)
+	}
+
+	mfaRoutes := apiRoutes.Group("/me/mfa")
+	{
+		mfaRoutes.POST("/enroll", deps.MFAController.Enroll)
+		mfaRoutes.POST("/activate", deps.MFAController.Activate)
+		mfaRoutes.DELETE("", deps.MFAController.Disable)
+	}
+	apiRoutes.POST("/me/oidc/link", deps.OIDCController.Link)
+
+	keyRoutes := apiRoutes.Group("/keys")
+	{
+		keyRoutes.POST("", deps.APIKeyController.Create)
+		keyRoutes.GET("", deps.APIKeyController.GetAll)
+		keyRoutes.DELETE("/:id", deps.APIKeyController.Revoke)
+	}
+
+	adminRoutes := apiRoutes.Group("", middleware.RequirePermission(auth.PermUsersAdmin))
+	{
+		adminRoutes.GET("/users", deps.UserController.GetAll)
+		adminRoutes.PUT("/users/:id/roles", deps.UserController.SetRoles)
+		adminRoutes.POST("/users/:id/unlock", deps.UserController.Unlock)
+		adminRoutes.GET("/roles", deps.UserController.GetRoles)
+	}
+
+	viewRoutes := server.Group("/view")
+	{
+		viewRoutes.GET("/", deps.VideoController.ShowAll)
+	}
+
+	srv := handler.New(graph.NewExecutableSchema(graph.Config{Resolvers: deps.Resolver}))
+
+	srv.AddTransport(transport.Options{})
+	srv.AddTransport(transport.GET{})
+	srv.AddTransport(transport.POST{})
+
+	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+
+	srv.Use(extension.Introspection{})
+	srv.Use(extension.AutomaticPersistedQuery{
+		Cache: lru.New[string](100),
+	})
+
+	server.GET("/", gin.WrapH(playground.Handler("GraphQL playground", "/query")))
+
+	// GraphQL requests are authenticated when they carry a token; the
+	// resolvers decide which operations need permissions
+	graphqlAuth := middleware.OptionalJWTAuthMiddleware(deps.JWTService, deps.TokenService)
+
+	// GraphQL endpoint for mutations
+	server.POST("/query", graphqlAuth, func(ctx *gin.Context) {
+		srv.ServeHTTP(ctx.Writer, ctx.Request)
+	})
+
+	// GraphQL queries (GET) can be public or protected based on your needs
+	server.GET("/query", graphqlAuth, func(ctx *gin.Context) {
+		srv.ServeHTTP(ctx.Writer, ctx.Request)
+	})
+
+	return server
+}
@@ -0,0 +1,68 @@
+package auth
+
+import "context"
+
+// Permissions understood by the API
+const (
+	PermVideosRead   = "videos:read"
+	PermVideosWrite  = "videos:write"
+	PermVideosDelete = "videos:delete"
+	PermVideosAdmin  = "videos:admin" // edit and delete videos owned by others
+	PermUsersAdmin   = "users:admin"
+)
+
+// Principal is the authenticated caller of a request
+type Principal struct {
+	UserID      string
+	Username    string
+	Roles       []string
+	Permissions []string
+	// APIKeyID is set when the caller authenticated with an API key
+	APIKeyID string
+}
+
+// HasPermission reports whether the principal was granted every permission
+func (p *Principal) HasPermission(permissions ...string) bool {
+	if p == nil {
+		return false
+	}
+	for _, required := range permissions {
+		granted := false
+		for _, permission := range p.Permissions {
+			if permission == required {
+				granted = true
+				break
+			}
+		}
+		if !granted {
+			return false
+		}
+	}
+	return true
+}
+
+// HasRole reports whether the principal holds the role
+func (p *Principal) HasRole(role string) bool {
+	if p == nil {
+		return false
+	}
+	for _, r := range p.Roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the principal
+func NewContext(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, principal)
+}
+
+// FromContext returns the principal stored in ctx, or nil for anonymous requests
+func FromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(contextKey{}).(*Principal)
+	return principal
+}
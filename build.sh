@@ -8,5 +8,5 @@ go get github.com/go-playground/validator/v10
 go get github.com/tpkeeper/gin-dump
 
 # build the application
-GOOS=linux GOARCH=amd64 go build -o bin/application main.go
+GOOS=linux GOARCH=amd64 go build -tags sqlite_fts5 -o bin/application main.go
 
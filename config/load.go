@@ -0,0 +1,142 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+	"go.yaml.in/yaml/v3"
+)
+
+// Load builds the configuration from the defaults, the file named by the
+// --config flag or CONFIG_FILE, the environment and the flags at the start
+// of args. It returns the arguments that follow the flags. The
+// configuration is not validated.
+func Load(args []string) (*Config, []string, error) {
+	return load(args, os.Getenv)
+}
+
+func load(args []string, getenv func(string) string) (*Config, []string, error) {
+	config := Default()
+
+	// Flags are parsed first to find the file, and applied last
+	flags := flag.NewFlagSet("", flag.ContinueOnError)
+	file := flags.String("config", getenv("CONFIG_FILE"), "YAML or TOML configuration file")
+	type flagValue struct{ name, value string }
+	var flagValues []flagValue
+	walk(reflect.ValueOf(&config).Elem(), "", func(field reflect.StructField, value reflect.Value, key string) {
+		name := field.Tag.Get("flag")
+		usage := fmt.Sprintf("%s (%s)", field.Tag.Get("usage"), field.Tag.Get("env"))
+		record := func(raw string) error {
+			flagValues = append(flagValues, flagValue{name: name, value: raw})
+			return nil
+		}
+		if value.Kind() == reflect.Bool {
+			flags.BoolFunc(name, usage, record)
+		} else {
+			flags.Func(name, usage, record)
+		}
+	})
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	if *file != "" {
+		if err := loadFile(&config, *file); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var errs []error
+	walk(reflect.ValueOf(&config).Elem(), "", func(field reflect.StructField, value reflect.Value, key string) {
+		name := field.Tag.Get("env")
+		if raw := getenv(name); raw != "" {
+			if err := set(value, raw); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", name, err))
+			}
+		}
+	})
+	walk(reflect.ValueOf(&config).Elem(), "", func(field reflect.StructField, value reflect.Value, key string) {
+		for _, flagValue := range flagValues {
+			if flagValue.name != field.Tag.Get("flag") {
+				continue
+			}
+			if err := set(value, flagValue.value); err != nil {
+				errs = append(errs, fmt.Errorf("--%s: %w", flagValue.name, err))
+			}
+		}
+	})
+	if err := errors.Join(errs...); err != nil {
+		return nil, nil, err
+	}
+	return &config, flags.Args(), nil
+}
+
+// loadFile overrides config with the settings in a .yaml, .yml or .toml
+// file. Unknown keys are errors, so that typos are not ignored.
+func loadFile(config *Config, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read the configuration file: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(content))
+		decoder.KnownFields(true)
+		err = decoder.Decode(config)
+		if errors.Is(err, io.EOF) {
+			// The file is empty
+			err = nil
+		}
+	case ".toml":
+		decoder := toml.NewDecoder(bytes.NewReader(content))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(config)
+	default:
+		return fmt.Errorf("the configuration file %s is not .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid configuration file %s: %w", path, err)
+	}
+	return nil
+}
+
+// set parses raw into a setting: a string, a whole number, a boolean or a
+// comma separated list
+func set(value reflect.Value, raw string) error {
+	switch value.Kind() {
+	case reflect.String:
+		value.SetString(raw)
+	case reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("%q is not a whole number", raw)
+		}
+		value.SetInt(int64(n))
+	case reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("%q is not true or false", raw)
+		}
+		value.SetBool(b)
+	case reflect.Slice:
+		items := []string{}
+		for _, item := range strings.Split(raw, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		value.Set(reflect.ValueOf(items))
+	default:
+		return fmt.Errorf("unsupported setting type %s", value.Type())
+	}
+	return nil
+}
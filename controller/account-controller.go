@@ -0,0 +1,141 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/service"
+	"github.com/muzammil-cyber/golang-gin/utils"
+)
+
+type AccountController interface {
+	Register(ctx *gin.Context)
+	VerifyEmail(ctx *gin.Context)
+	ForgotPassword(ctx *gin.Context)
+	ResetPassword(ctx *gin.Context)
+}
+
+type accountController struct {
+	accountService service.AccountService
+}
+
+func NewAccountController(accountService service.AccountService) AccountController {
+	return &accountController{
+		accountService: accountService,
+	}
+}
+
+// Register godoc
+// @Summary Register an account
+// @Description Create a new account. A verification link is emailed to the given address and must be followed before the account can log in.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param account body dto.RegisterRequest true "Username, email and password"
+// @Success 201 {object} entity.User "Account created, verification email sent"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or validation errors"
+// @Failure 409 {object} dto.ErrorResponse "Username or email already registered"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while creating the account"
+// @Router /auth/register [post]
+func (c *accountController) Register(ctx *gin.Context) {
+	var request dto.RegisterRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	user, err := c.accountService.Register(request)
+	if errors.Is(err, service.ErrUserExists) || errors.Is(err, service.ErrEmailTaken) {
+		ctx.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, user)
+}
+
+// VerifyEmail godoc
+// @Summary Verify an email address
+// @Description Confirm the email address of an account with the single-use token from the verification email.
+// @Tags Authentication
+// @Produce json
+// @Param token query string true "Verification token"
+// @Success 200 {object} dto.MessageResponse "Email address verified"
+// @Failure 400 {object} dto.ValidationErrorResponse "Missing token"
+// @Failure 401 {object} dto.ErrorResponse "Invalid, expired or already used token"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while verifying the email"
+// @Router /auth/verify [get]
+func (c *accountController) VerifyEmail(ctx *gin.Context) {
+	var request dto.VerifyEmailRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	err := c.accountService.VerifyEmail(request.Token)
+	if errors.Is(err, service.ErrInvalidToken) {
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, dto.MessageResponse{Message: "Email address verified"})
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset
+// @Description Email a single-use password reset token. The response is the same whether or not the address is registered.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordRequest true "Account email address"
+// @Success 202 {object} dto.MessageResponse "Reset email sent if the address is registered"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or validation errors"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while sending the email"
+// @Router /auth/forgot-password [post]
+func (c *accountController) ForgotPassword(ctx *gin.Context) {
+	var request dto.ForgotPasswordRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	if err := c.accountService.RequestPasswordReset(request.Email); err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusAccepted, dto.MessageResponse{Message: "If the address is registered, a reset email has been sent"})
+}
+
+// ResetPassword godoc
+// @Summary Reset a password
+// @Description Set a new password with the single-use token from the password reset email.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequest true "Reset token and new password"
+// @Success 200 {object} dto.MessageResponse "Password updated"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or validation errors"
+// @Failure 401 {object} dto.ErrorResponse "Invalid, expired or already used token"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while updating the password"
+// @Router /auth/reset-password [post]
+func (c *accountController) ResetPassword(ctx *gin.Context) {
+	var request dto.ResetPasswordRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	err := c.accountService.ResetPassword(request.Token, request.Password)
+	if errors.Is(err, service.ErrInvalidToken) {
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, dto.MessageResponse{Message: "Password updated"})
+}
@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/service"
+	"github.com/muzammil-cyber/golang-gin/utils"
+)
+
+type APIKeyController interface {
+	Create(ctx *gin.Context)
+	GetAll(ctx *gin.Context)
+	Revoke(ctx *gin.Context)
+}
+
+type apiKeyController struct {
+	apiKeyService service.APIKeyService
+}
+
+func NewAPIKeyController(apiKeyService service.APIKeyService) APIKeyController {
+	return &apiKeyController{
+		apiKeyService: apiKeyService,
+	}
+}
+
+// Create godoc
+// @Summary Create an API key
+// @Description Create a long-lived API key for machine clients, sent in the X-API-Key header. The key is only shown in this response. Its scopes must be permissions the caller holds. Requires JWT authentication; API keys cannot manage API keys.
+// @Tags API Keys
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param request body dto.APIKeyCreateRequest true "Name, scopes and lifetime of the key"
+// @Success 201 {object} dto.APIKeyCreateResponse "Created key, including the secret"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or validation errors"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Scopes exceed the caller's permissions, or called with an API key"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while creating the key"
+// @Security BearerAuth
+// @Router /api/keys [post]
+func (c *apiKeyController) Create(ctx *gin.Context) {
+	principal, ok := sessionPrincipal(ctx)
+	if !ok {
+		return
+	}
+	var request dto.APIKeyCreateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	created, err := c.apiKeyService.Create(principal, request)
+	if errors.Is(err, service.ErrScopeNotHeld) {
+		ctx.JSON(http.StatusForbidden, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusCreated, created)
+}
+
+// GetAll godoc
+// @Summary List API keys
+// @Description List the caller's API keys, including revoked ones. Secrets are never returned. Requires JWT authentication.
+// @Tags API Keys
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {array} entity.APIKey "The caller's API keys"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Called with an API key"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching keys"
+// @Security BearerAuth
+// @Router /api/keys [get]
+func (c *apiKeyController) GetAll(ctx *gin.Context) {
+	principal, ok := sessionPrincipal(ctx)
+	if !ok {
+		return
+	}
+	keys, err := c.apiKeyService.GetAll(principal.UserID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, keys)
+}
+
+// Revoke godoc
+// @Summary Revoke an API key
+// @Description Revoke one of the caller's API keys. Requests using it are rejected from then on. Requires JWT authentication.
+// @Tags API Keys
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "API key UUID" format(uuid)
+// @Success 200 {object} dto.MessageResponse "Key revoked"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Called with an API key"
+// @Failure 404 {object} dto.ErrorResponse "No active key with this ID belongs to the caller"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while revoking the key"
+// @Security BearerAuth
+// @Router /api/keys/{id} [delete]
+func (c *apiKeyController) Revoke(ctx *gin.Context) {
+	principal, ok := sessionPrincipal(ctx)
+	if !ok {
+		return
+	}
+	err := c.apiKeyService.Revoke(principal.UserID, ctx.Param("id"))
+	if errors.Is(err, service.ErrAPIKeyNotFound) {
+		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, dto.MessageResponse{Message: "API key revoked"})
+}
+
+// sessionPrincipal returns the caller if they logged in interactively.
+// Keys cannot mint or revoke keys, so a leaked key cannot entrench itself.
+func sessionPrincipal(ctx *gin.Context) (*auth.Principal, bool) {
+	principal := auth.FromContext(ctx.Request.Context())
+	if principal == nil {
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "Authentication required"})
+		return nil, false
+	}
+	if principal.APIKeyID != "" {
+		ctx.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "API keys cannot be managed with an API key"})
+		return nil, false
+	}
+	return principal, true
+}
@@ -0,0 +1,169 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+	"github.com/muzammil-cyber/golang-gin/utils"
+)
+
+type AuthorController interface {
+	Create(ctx *gin.Context)
+	GetAll(ctx *gin.Context)
+	GetByID(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+}
+
+type authorController struct {
+	authorService service.AuthorService
+}
+
+func NewAuthorController(authorService service.AuthorService) AuthorController {
+	return &authorController{
+		authorService: authorService,
+	}
+}
+
+// Create godoc
+// @Summary Create an author
+// @Description Create an author that videos can refer to by author_id. Emails are unique and compared without case. Requires the videos:write permission.
+// @Tags Authors
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param request body dto.AuthorRequest true "Author to create"
+// @Success 201 {object} entity.Person "Created author"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or validation errors"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - videos:write permission required"
+// @Failure 409 {object} dto.ErrorResponse "Another author has this email"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while creating the author"
+// @Security BearerAuth
+// @Router /api/authors [post]
+func (c *authorController) Create(ctx *gin.Context) {
+	var request dto.AuthorRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	author, err := c.authorService.Create(request)
+	if err != nil {
+		writeAuthorError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, author)
+}
+
+// GetAll godoc
+// @Summary List authors
+// @Description List all authors ordered by name. Requires the videos:read permission.
+// @Tags Authors
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {array} entity.Person "Authors"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching authors"
+// @Security BearerAuth
+// @Router /api/authors [get]
+func (c *authorController) GetAll(ctx *gin.Context) {
+	authors, err := c.authorService.GetAll()
+	if err != nil {
+		writeAuthorError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, authors)
+}
+
+// GetByID godoc
+// @Summary Get an author
+// @Description Get an author by ID. Use GET /api/videos?author_id= for their videos. Requires the videos:read permission.
+// @Tags Authors
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Author UUID" format(uuid)
+// @Success 200 {object} entity.Person "Author"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 404 {object} dto.ErrorResponse "Author not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching the author"
+// @Security BearerAuth
+// @Router /api/authors/{id} [get]
+func (c *authorController) GetByID(ctx *gin.Context) {
+	author, err := c.authorService.GetByID(ctx.Param("id"))
+	if err != nil {
+		writeAuthorError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, author)
+}
+
+// Update godoc
+// @Summary Update an author
+// @Description Replace the name, age and email of an author. The change shows on all of the author's videos. Requires the videos:write permission.
+// @Tags Authors
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Author UUID" format(uuid)
+// @Param request body dto.AuthorRequest true "New author details"
+// @Success 200 {object} entity.Person "Updated author"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or validation errors"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - videos:write permission required"
+// @Failure 404 {object} dto.ErrorResponse "Author not found"
+// @Failure 409 {object} dto.ErrorResponse "Another author has this email, or the author was changed at the same time"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while updating the author"
+// @Security BearerAuth
+// @Router /api/authors/{id} [put]
+func (c *authorController) Update(ctx *gin.Context) {
+	var request dto.AuthorRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	author, err := c.authorService.Update(ctx.Param("id"), request)
+	if err != nil {
+		writeAuthorError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, author)
+}
+
+// Delete godoc
+// @Summary Delete an author
+// @Description Delete an author who has no videos left, counting videos in the trash. Requires the videos:delete permission.
+// @Tags Authors
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Author UUID" format(uuid)
+// @Success 200 {object} dto.MessageResponse "Author deleted"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - videos:delete permission required"
+// @Failure 404 {object} dto.ErrorResponse "Author not found"
+// @Failure 409 {object} dto.ErrorResponse "The author still has videos"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while deleting the author"
+// @Security BearerAuth
+// @Router /api/authors/{id} [delete]
+func (c *authorController) Delete(ctx *gin.Context) {
+	if err := c.authorService.Delete(ctx.Param("id")); err != nil {
+		writeAuthorError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, dto.MessageResponse{Message: "Author deleted"})
+}
+
+// writeAuthorError maps author service errors to HTTP responses
+func writeAuthorError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrAuthorNotFound):
+		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrAuthorEmailTaken), errors.Is(err, service.ErrAuthorHasVideos), errors.Is(err, repository.ErrVersionConflict):
+		ctx.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+	}
+}
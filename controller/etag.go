@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+// errPreconditionResponse is returned by handlers that wrote an If-Match
+// error response
+var errPreconditionResponse = errors.New("if-match precondition not met")
+
+// videoETag returns the entity tag of a video, such as "3.1". The video
+// embeds its author, so the tag holds the versions of both.
+func videoETag(video *entity.Video) string {
+	return fmt.Sprintf(`"%d.%d"`, video.Version, video.Author.Version)
+}
+
+// parseETags splits a list of entity tags. Weak tags are only accepted if
+// weak is set, and are then compared like strong ones.
+func parseETags(header string, weak bool) []string {
+	var tags []string
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if weak {
+			tag = strings.TrimPrefix(tag, "W/")
+		}
+		if tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
+
+// ifMatchVersion returns the video version that the If-Match header asks
+// for, or service.AnyVersion for "*". Only the video's part of the tag is
+// compared, since an update conflicts with changes to the video and not
+// with edits of its author. If the header is missing or names several
+// versions it writes the error response and returns false.
+func ifMatchVersion(ctx *gin.Context) (int64, bool) {
+	header := strings.TrimSpace(ctx.GetHeader("If-Match"))
+	if header == "" {
+		ctx.JSON(http.StatusPreconditionRequired, dto.ErrorResponse{Error: "the If-Match header with the ETag of the video is required; use * to skip the check"})
+		return 0, false
+	}
+	if header == "*" {
+		return service.AnyVersion, true
+	}
+	tags := parseETags(header, false)
+	if len(tags) != 1 {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "If-Match must hold a single ETag or *"})
+		return 0, false
+	}
+	videoVersion, _, _ := strings.Cut(strings.Trim(tags[0], `"`), ".")
+	version, err := strconv.ParseInt(videoVersion, 10, 64)
+	if err != nil || version <= 0 || !strings.HasPrefix(tags[0], `"`) {
+		// No version of the video has this tag
+		ctx.JSON(http.StatusPreconditionFailed, dto.ErrorResponse{Error: service.ErrVersionMismatch.Error()})
+		return 0, false
+	}
+	return version, true
+}
+
+// noneMatch reports whether the If-None-Match header allows sending the
+// representation with the given tag, that is whether it does not name it
+func noneMatch(ctx *gin.Context, etag string) bool {
+	header := strings.TrimSpace(ctx.GetHeader("If-None-Match"))
+	if header == "" {
+		return true
+	}
+	if header == "*" {
+		return false
+	}
+	for _, tag := range parseETags(header, true) {
+		if tag == etag {
+			return false
+		}
+	}
+	return true
+}
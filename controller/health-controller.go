@@ -0,0 +1,51 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/dto"
+)
+
+type HealthController interface {
+	Live(ctx *gin.Context)
+	Ready(ctx *gin.Context)
+}
+
+type healthController struct {
+	ready func() bool
+}
+
+// NewHealthController reports the server ready while ready returns true
+func NewHealthController(ready func() bool) HealthController {
+	return &healthController{
+		ready: ready,
+	}
+}
+
+// Live godoc
+// @Summary Liveness probe
+// @Description Succeeds while the server process is running, including while it shuts down.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} dto.HealthResponse "The server is running"
+// @Router /healthz [get]
+func (c *healthController) Live(ctx *gin.Context) {
+	ctx.JSON(http.StatusOK, dto.HealthResponse{Status: "ok"})
+}
+
+// Ready godoc
+// @Summary Readiness probe
+// @Description Succeeds while the server accepts new requests. It fails as soon as the server starts shutting down, so that load balancers stop sending requests before connections are closed.
+// @Tags Health
+// @Produce json
+// @Success 200 {object} dto.HealthResponse "The server accepts requests"
+// @Failure 503 {object} dto.HealthResponse "The server is shutting down"
+// @Router /readyz [get]
+func (c *healthController) Ready(ctx *gin.Context) {
+	if !c.ready() {
+		ctx.JSON(http.StatusServiceUnavailable, dto.HealthResponse{Status: "draining"})
+		return
+	}
+	ctx.JSON(http.StatusOK, dto.HealthResponse{Status: "ok"})
+}
@@ -0,0 +1,35 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+type JWKSController interface {
+	GetKeys(ctx *gin.Context)
+}
+
+type jwksController struct {
+	jwtService service.JWTService
+}
+
+func NewJWKSController(jwtService service.JWTService) JWKSController {
+	return &jwksController{
+		jwtService: jwtService,
+	}
+}
+
+// GetKeys godoc
+// @Summary JSON Web Key Set
+// @Description Public keys that verify the tokens issued by this API, for services that validate tokens without the signing key. Empty when tokens are signed with HS256.
+// @Tags Authentication
+// @Produce json
+// @Success 200 {object} dto.JSONWebKeySet "Active verification keys"
+// @Router /.well-known/jwks.json [get]
+func (c *jwksController) GetKeys(ctx *gin.Context) {
+	// Let verifiers cache the set for a while, but pick up rotations
+	ctx.Header("Cache-Control", "public, max-age=300")
+	ctx.JSON(http.StatusOK, c.jwtService.JWKS())
+}
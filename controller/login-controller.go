@@ -1,7 +1,12 @@
 package controller
 
 import (
+	"errors"
+	"log"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/muzammil-cyber/golang-gin/dto"
@@ -13,45 +18,165 @@ import (
 )
 
 type LoginController interface {
-	Login(ctx *gin.Context) string
+	Login(ctx *gin.Context) *dto.LoginResponse
+	Refresh(ctx *gin.Context)
+	Logout(ctx *gin.Context)
 }
 
 type loginController struct {
-	loginService service.LoginService
-	jwtService   service.JWTService
+	loginService  service.LoginService
+	tokenService  service.TokenService
+	mfaService    service.MFAService
+	loginThrottle service.LoginThrottle
 }
 
-func NewLoginController(loginService service.LoginService, jwtService service.JWTService) LoginController {
+func NewLoginController(loginService service.LoginService, tokenService service.TokenService, mfaService service.MFAService, loginThrottle service.LoginThrottle) LoginController {
 	return &loginController{
-		loginService: loginService,
-		jwtService:   jwtService,
+		loginService:  loginService,
+		tokenService:  tokenService,
+		mfaService:    mfaService,
+		loginThrottle: loginThrottle,
 	}
 }
 
 // Login godoc
 // @Summary User Login
-// @Description Authenticate user with username and password to receive a JWT token for accessing protected endpoints
+// @Description Authenticate user with username and password to receive a JWT access token for accessing protected endpoints and a refresh token for /auth/refresh. Accounts with two-factor authentication instead receive an MFA challenge token to exchange at /auth/mfa/verify.
 // @Tags Authentication
 // @Accept json
 // @Produce json
 // @Param credentials body entity.LoginCredentials true "User login credentials (username and password)"
-// @Success 200 {object} dto.LoginResponse "Successfully authenticated, returns JWT token"
+// @Success 200 {object} dto.LoginResponse "Successfully authenticated, returns JWT access and refresh tokens, or an MFA challenge"
 // @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or missing required fields"
 // @Failure 401 {object} dto.ErrorResponse "Authentication failed - invalid username or password"
+// @Failure 403 {object} dto.ErrorResponse "Email address has not been verified yet"
+// @Failure 429 {object} dto.ErrorResponse "Too many failed attempts for the username or from the client; see the Retry-After header"
+// @Header 429 {integer} Retry-After "Seconds until logins are accepted again"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while issuing tokens"
 // @Router /auth/login [post]
-func (c *loginController) Login(ctx *gin.Context) string {
+func (c *loginController) Login(ctx *gin.Context) *dto.LoginResponse {
 	var credentials entity.LoginCredentials
 	if err := ctx.ShouldBindJSON(&credentials); err != nil {
 		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
-		return ""
+		return nil
 	}
 
-	isAuthenticated := c.loginService.Login(credentials.Username, credentials.Password)
-	if !isAuthenticated {
+	ip := ctx.ClientIP()
+	retryAfter, err := c.loginThrottle.Check(credentials.Username, ip)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return nil
+	}
+	if retryAfter > 0 {
+		writeLoginLocked(ctx, retryAfter)
+		return nil
+	}
+
+	user, err := c.loginService.Login(credentials.Username, credentials.Password)
+	if errors.Is(err, service.ErrEmailNotVerified) {
+		ctx.JSON(http.StatusForbidden, dto.ErrorResponse{Error: "Email address has not been verified"})
+		return nil
+	}
+	if err != nil {
+		lockout, throttleErr := c.loginThrottle.Failure(credentials.Username, ip)
+		if throttleErr != nil {
+			log.Printf("failed to record failed login: %v", throttleErr)
+		}
+		if lockout > 0 {
+			writeLoginLocked(ctx, lockout)
+			return nil
+		}
 		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: "Invalid username or password"})
-		return ""
+		return nil
+	}
+
+	// Failures of users with a second factor are only forgotten once it is
+	// passed, so that codes cannot be guessed by logging in again
+	if c.mfaService.Required(user) {
+		challenge, err := c.mfaService.Challenge(user)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+			return nil
+		}
+		return &dto.LoginResponse{
+			MFARequired:           true,
+			MFAEnrollmentRequired: !user.MFAEnabled(),
+			MFAToken:              challenge,
+		}
+	}
+
+	if err := c.loginThrottle.Success(credentials.Username); err != nil {
+		log.Printf("failed to reset failed logins: %v", err)
+	}
+	tokens, err := c.tokenService.Issue(user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return nil
+	}
+	return &tokens
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Exchange a refresh token for a new access and refresh token pair. Each refresh token can be used once; reusing one revokes every token issued from the same login.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshRequest true "Refresh token"
+// @Success 200 {object} dto.LoginResponse "New access and refresh tokens"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or missing required fields"
+// @Failure 401 {object} dto.ErrorResponse "Invalid, expired, revoked or reused refresh token"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while issuing tokens"
+// @Router /auth/refresh [post]
+func (c *loginController) Refresh(ctx *gin.Context) {
+	var request dto.RefreshRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	tokens, err := c.tokenService.Refresh(request.RefreshToken)
+	if errors.Is(err, service.ErrInvalidToken) || errors.Is(err, service.ErrRefreshTokenReused) {
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, tokens)
+}
+
+// Logout godoc
+// @Summary Logout
+// @Description Revoke the access token used for this request and, if given, the refresh token issued with it. Requires JWT authentication.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param request body dto.LogoutRequest false "Refresh token to revoke"
+// @Success 200 {object} dto.MessageResponse "Logged out"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required, or refresh token does not belong to the user"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while revoking tokens"
+// @Security BearerAuth
+// @Router /auth/logout [post]
+func (c *loginController) Logout(ctx *gin.Context) {
+	var request dto.LogoutRequest
+	// The body is optional; without one only the access token is revoked
+	_ = ctx.ShouldBindJSON(&request)
+
+	err := c.tokenService.Logout(ctx.GetString("user_id"), ctx.GetString("jti"), ctx.GetTime("expires_at"), request.RefreshToken)
+	if errors.Is(err, service.ErrInvalidToken) {
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
 	}
+	ctx.JSON(http.StatusOK, dto.MessageResponse{Message: "Logged out"})
+}
 
-	token := c.jwtService.GenerateToken(credentials.Username, credentials.Username == "admin")
-	return token
+func writeLoginLocked(ctx *gin.Context, retryAfter time.Duration) {
+	ctx.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+	ctx.JSON(http.StatusTooManyRequests, dto.ErrorResponse{Error: service.ErrLoginLocked.Error()})
 }
@@ -0,0 +1,261 @@
+package controller
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/service"
+	"github.com/muzammil-cyber/golang-gin/utils"
+)
+
+type MFAController interface {
+	Verify(ctx *gin.Context)
+	EnrollWithChallenge(ctx *gin.Context)
+	Enroll(ctx *gin.Context)
+	Activate(ctx *gin.Context)
+	Disable(ctx *gin.Context)
+}
+
+type mfaController struct {
+	mfaService    service.MFAService
+	tokenService  service.TokenService
+	loginThrottle service.LoginThrottle
+}
+
+func NewMFAController(mfaService service.MFAService, tokenService service.TokenService, loginThrottle service.LoginThrottle) MFAController {
+	return &mfaController{
+		mfaService:    mfaService,
+		tokenService:  tokenService,
+		loginThrottle: loginThrottle,
+	}
+}
+
+// Verify godoc
+// @Summary Complete a two-step login
+// @Description Exchange the MFA challenge token from /auth/login and a TOTP or recovery code for JWT access and refresh tokens. For accounts that enrolled through /auth/mfa/enroll this also turns MFA on and returns the recovery codes. A challenge is void after 5 wrong codes, and wrong codes count towards the login lockout of the user and the client.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.MFAVerifyRequest true "Challenge token and code"
+// @Success 200 {object} dto.LoginResponse "Successfully authenticated, returns JWT access and refresh tokens"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or missing required fields"
+// @Failure 401 {object} dto.ErrorResponse "Invalid or expired challenge token, or wrong code"
+// @Failure 409 {object} dto.ErrorResponse "MFA enrollment has not been started"
+// @Failure 429 {object} dto.ErrorResponse "Too many failed attempts for the user or from the client; see the Retry-After header"
+// @Header 429 {integer} Retry-After "Seconds until codes are accepted again"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while issuing tokens"
+// @Router /auth/mfa/verify [post]
+func (c *mfaController) Verify(ctx *gin.Context) {
+	var request dto.MFAVerifyRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	challenged, err := c.mfaService.ChallengedUser(request.MFAToken)
+	if err != nil {
+		writeMFAError(ctx, err)
+		return
+	}
+	if c.throttled(ctx, challenged.Username) {
+		return
+	}
+
+	user, recoveryCodes, err := c.mfaService.Verify(request.MFAToken, request.Code)
+	if err != nil {
+		c.writeCodeError(ctx, challenged.Username, err)
+		return
+	}
+	c.resetThrottle(user.Username)
+	tokens, err := c.tokenService.Issue(user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	tokens.RecoveryCodes = recoveryCodes
+	ctx.JSON(http.StatusOK, tokens)
+}
+
+// EnrollWithChallenge godoc
+// @Summary Set up MFA during login
+// @Description For accounts that must use two-factor authentication but have not set it up. Takes the MFA challenge token from /auth/login and returns a TOTP secret; the login is completed with a code at /auth/mfa/verify.
+// @Tags Authentication
+// @Accept json
+// @Produce json
+// @Param request body dto.MFAChallengeRequest true "Challenge token"
+// @Success 200 {object} dto.MFAEnrollResponse "TOTP secret and provisioning URI"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or missing required fields"
+// @Failure 401 {object} dto.ErrorResponse "Invalid or expired challenge token"
+// @Failure 409 {object} dto.ErrorResponse "MFA is already enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while enrolling"
+// @Router /auth/mfa/enroll [post]
+func (c *mfaController) EnrollWithChallenge(ctx *gin.Context) {
+	var request dto.MFAChallengeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	enrollment, err := c.mfaService.EnrollWithChallenge(request.MFAToken)
+	if err != nil {
+		writeMFAError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, enrollment)
+}
+
+// Enroll godoc
+// @Summary Start MFA enrollment
+// @Description Generate a TOTP secret for the caller. Render the provisioning URI as a QR code for an authenticator app, then confirm with /api/me/mfa/activate. Requires JWT authentication.
+// @Tags MFA
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {object} dto.MFAEnrollResponse "TOTP secret and provisioning URI"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Called with an API key"
+// @Failure 409 {object} dto.ErrorResponse "MFA is already enabled"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while enrolling"
+// @Security BearerAuth
+// @Router /api/me/mfa/enroll [post]
+func (c *mfaController) Enroll(ctx *gin.Context) {
+	principal, ok := sessionPrincipal(ctx)
+	if !ok {
+		return
+	}
+	enrollment, err := c.mfaService.Enroll(principal.UserID)
+	if err != nil {
+		writeMFAError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, enrollment)
+}
+
+// Activate godoc
+// @Summary Turn MFA on
+// @Description Confirm enrollment with a code from the authenticator app. Returns recovery codes, which are only shown once. Wrong codes count towards the login lockout of the user and the client. Requires JWT authentication.
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param request body dto.MFACodeRequest true "TOTP code"
+// @Success 200 {object} dto.MFAActivateResponse "Recovery codes"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or missing required fields"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized, or wrong code"
+// @Failure 403 {object} dto.ErrorResponse "Called with an API key"
+// @Failure 409 {object} dto.ErrorResponse "MFA is already enabled or enrollment has not been started"
+// @Failure 429 {object} dto.ErrorResponse "Too many failed attempts for the user or from the client; see the Retry-After header"
+// @Header 429 {integer} Retry-After "Seconds until codes are accepted again"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while activating"
+// @Security BearerAuth
+// @Router /api/me/mfa/activate [post]
+func (c *mfaController) Activate(ctx *gin.Context) {
+	principal, ok := sessionPrincipal(ctx)
+	if !ok {
+		return
+	}
+	var request dto.MFACodeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	if c.throttled(ctx, principal.Username) {
+		return
+	}
+	recoveryCodes, err := c.mfaService.Activate(principal.UserID, request.Code)
+	if err != nil {
+		c.writeCodeError(ctx, principal.Username, err)
+		return
+	}
+	c.resetThrottle(principal.Username)
+	ctx.JSON(http.StatusOK, dto.MFAActivateResponse{RecoveryCodes: recoveryCodes})
+}
+
+// Disable godoc
+// @Summary Turn MFA off
+// @Description Turn two-factor authentication off after checking a TOTP or recovery code. Accounts whose role requires MFA have to enroll again at their next login. Wrong codes count towards the login lockout of the user and the client. Requires JWT authentication.
+// @Tags MFA
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param request body dto.MFACodeRequest true "TOTP or recovery code"
+// @Success 200 {object} dto.MessageResponse "MFA turned off"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or missing required fields"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized, or wrong code"
+// @Failure 403 {object} dto.ErrorResponse "Called with an API key"
+// @Failure 409 {object} dto.ErrorResponse "MFA is not enabled"
+// @Failure 429 {object} dto.ErrorResponse "Too many failed attempts for the user or from the client; see the Retry-After header"
+// @Header 429 {integer} Retry-After "Seconds until codes are accepted again"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while turning MFA off"
+// @Security BearerAuth
+// @Router /api/me/mfa [delete]
+func (c *mfaController) Disable(ctx *gin.Context) {
+	principal, ok := sessionPrincipal(ctx)
+	if !ok {
+		return
+	}
+	var request dto.MFACodeRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	if c.throttled(ctx, principal.Username) {
+		return
+	}
+	if err := c.mfaService.Disable(principal.UserID, request.Code); err != nil {
+		c.writeCodeError(ctx, principal.Username, err)
+		return
+	}
+	c.resetThrottle(principal.Username)
+	ctx.JSON(http.StatusOK, dto.MessageResponse{Message: "Two-factor authentication turned off"})
+}
+
+// throttled writes 429 and reports true while the user or the client is
+// locked out of entering codes.
+func (c *mfaController) throttled(ctx *gin.Context, username string) bool {
+	retryAfter, err := c.loginThrottle.Check(username, ctx.ClientIP())
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return true
+	}
+	if retryAfter > 0 {
+		writeLoginLocked(ctx, retryAfter)
+		return true
+	}
+	return false
+}
+
+// writeCodeError counts a wrong code towards the login lockout before
+// writing the error, answering 429 when it starts a lockout.
+func (c *mfaController) writeCodeError(ctx *gin.Context, username string, err error) {
+	if errors.Is(err, service.ErrInvalidMFACode) {
+		lockout, throttleErr := c.loginThrottle.Failure(username, ctx.ClientIP())
+		if throttleErr != nil {
+			log.Printf("failed to record failed MFA code: %v", throttleErr)
+		}
+		if lockout > 0 {
+			writeLoginLocked(ctx, lockout)
+			return
+		}
+	}
+	writeMFAError(ctx, err)
+}
+
+func (c *mfaController) resetThrottle(username string) {
+	if err := c.loginThrottle.Success(username); err != nil {
+		log.Printf("failed to reset failed logins: %v", err)
+	}
+}
+
+func writeMFAError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidToken), errors.Is(err, service.ErrInvalidMFACode):
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrMFAAlreadyEnabled), errors.Is(err, service.ErrMFANotEnrolled):
+		ctx.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+	}
+}
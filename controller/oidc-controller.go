@@ -0,0 +1,182 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+// Cookies that keep the state of a login while the user is at the provider
+const (
+	oidcStateCookie    = "oidc_state"
+	oidcNonceCookie    = "oidc_nonce"
+	oidcVerifierCookie = "oidc_verifier"
+	oidcLinkCookie     = "oidc_link"
+	oidcCookiePath     = "/auth/oidc"
+	oidcCookieMaxAge   = 10 * 60
+)
+
+type OIDCController interface {
+	Login(ctx *gin.Context)
+	Link(ctx *gin.Context)
+	Callback(ctx *gin.Context)
+}
+
+type oidcController struct {
+	oidcService  service.OIDCService
+	tokenService service.TokenService
+	mfaService   service.MFAService
+}
+
+func NewOIDCController(oidcService service.OIDCService, tokenService service.TokenService, mfaService service.MFAService) OIDCController {
+	return &oidcController{
+		oidcService:  oidcService,
+		tokenService: tokenService,
+		mfaService:   mfaService,
+	}
+}
+
+// Login godoc
+// @Summary Sign in with the identity provider
+// @Description Start an OpenID Connect authorization code login with PKCE. Redirects the browser to the configured identity provider, which returns to /auth/oidc/callback.
+// @Tags Authentication
+// @Success 302 "Redirect to the identity provider"
+// @Failure 404 {object} dto.ErrorResponse "OIDC login is not configured"
+// @Failure 502 {object} dto.ErrorResponse "The identity provider could not be reached"
+// @Router /auth/oidc/login [get]
+func (c *oidcController) Login(ctx *gin.Context) {
+	flow, err := c.oidcService.Begin()
+	if errors.Is(err, service.ErrOIDCDisabled) {
+		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusBadGateway, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	setFlowCookies(ctx, flow)
+	ctx.Redirect(http.StatusFound, flow.AuthURL)
+}
+
+// Link godoc
+// @Summary Link the identity provider to your account
+// @Description Start an OpenID Connect login that links the provider account to the caller instead of signing in. Open the returned URL in the browser that made this request; the provider returns to /auth/oidc/callback, which links the account and returns new tokens. Accounts with a password or MFA can only be linked this way. Requires JWT authentication.
+// @Tags Authentication
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {object} dto.OIDCLinkResponse "URL of the identity provider's login page"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Called with an API key"
+// @Failure 404 {object} dto.ErrorResponse "OIDC login is not configured"
+// @Failure 502 {object} dto.ErrorResponse "The identity provider could not be reached"
+// @Security BearerAuth
+// @Router /api/me/oidc/link [post]
+func (c *oidcController) Link(ctx *gin.Context) {
+	principal, ok := sessionPrincipal(ctx)
+	if !ok {
+		return
+	}
+	flow, err := c.oidcService.BeginLink(principal.UserID)
+	switch {
+	case errors.Is(err, service.ErrOIDCDisabled), errors.Is(err, service.ErrUserNotFound):
+		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+		return
+	case err != nil:
+		ctx.JSON(http.StatusBadGateway, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	setFlowCookies(ctx, flow)
+	ctx.JSON(http.StatusOK, dto.OIDCLinkResponse{AuthURL: flow.AuthURL})
+}
+
+// Callback godoc
+// @Summary Identity provider callback
+// @Description Complete an OpenID Connect login. The provider account is linked to a local user, which is created on first login, and the API's own access and refresh tokens are returned. Accounts with two-factor authentication instead receive an MFA challenge token to exchange at /auth/mfa/verify, as with /auth/login. An existing account with a password or MFA is only linked after its owner starts the login at /api/me/oidc/link.
+// @Tags Authentication
+// @Produce json
+// @Param code query string true "Authorization code"
+// @Param state query string true "State set by /auth/oidc/login"
+// @Success 200 {object} dto.LoginResponse "Successfully authenticated, returns JWT access and refresh tokens, or an MFA challenge"
+// @Failure 400 {object} dto.ErrorResponse "Login state is missing or does not match"
+// @Failure 401 {object} dto.ErrorResponse "The identity provider rejected the login, or the link request expired"
+// @Failure 404 {object} dto.ErrorResponse "OIDC login is not configured"
+// @Failure 409 {object} dto.ErrorResponse "An account with the email exists and has to be linked, or the provider account is linked to another user"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while signing in"
+// @Router /auth/oidc/callback [get]
+func (c *oidcController) Callback(ctx *gin.Context) {
+	var flow service.OIDCFlow
+	flow.State, _ = ctx.Cookie(oidcStateCookie)
+	flow.Nonce, _ = ctx.Cookie(oidcNonceCookie)
+	flow.Verifier, _ = ctx.Cookie(oidcVerifierCookie)
+	flow.LinkToken, _ = ctx.Cookie(oidcLinkCookie)
+	// The flow can only be completed once
+	for _, name := range []string{oidcStateCookie, oidcNonceCookie, oidcVerifierCookie, oidcLinkCookie} {
+		ctx.SetCookie(name, "", -1, oidcCookiePath, "", ctx.Request.TLS != nil, true)
+	}
+
+	if providerError := ctx.Query("error"); providerError != "" {
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: providerError + ": " + ctx.Query("error_description")})
+		return
+	}
+
+	user, err := c.oidcService.Complete(ctx.Request.Context(), flow, ctx.Query("state"), ctx.Query("code"))
+	switch {
+	case errors.Is(err, service.ErrOIDCDisabled):
+		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+		return
+	case errors.Is(err, service.ErrOIDCInvalidState):
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	case errors.Is(err, service.ErrOIDCLoginRejected), errors.Is(err, service.ErrInvalidToken):
+		ctx.JSON(http.StatusUnauthorized, dto.ErrorResponse{Error: err.Error()})
+		return
+	case errors.Is(err, service.ErrOIDCLinkRequired), errors.Is(err, service.ErrOIDCIdentityLinked):
+		ctx.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+		return
+	case err != nil:
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	// The provider's own second factor is not trusted; users who need MFA
+	// pass it here as after a password login
+	if c.mfaService.Required(user) {
+		challenge, err := c.mfaService.Challenge(user)
+		if err != nil {
+			ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+			return
+		}
+		ctx.JSON(http.StatusOK, dto.LoginResponse{
+			MFARequired:           true,
+			MFAEnrollmentRequired: !user.MFAEnabled(),
+			MFAToken:              challenge,
+		})
+		return
+	}
+
+	tokens, err := c.tokenService.Issue(user)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, tokens)
+}
+
+// setFlowCookies keeps the state of a login in the browser until the
+// provider redirects back
+func setFlowCookies(ctx *gin.Context, flow *service.OIDCFlow) {
+	secure := ctx.Request.TLS != nil
+	ctx.SetSameSite(http.SameSiteLaxMode)
+	ctx.SetCookie(oidcStateCookie, flow.State, oidcCookieMaxAge, oidcCookiePath, "", secure, true)
+	ctx.SetCookie(oidcNonceCookie, flow.Nonce, oidcCookieMaxAge, oidcCookiePath, "", secure, true)
+	ctx.SetCookie(oidcVerifierCookie, flow.Verifier, oidcCookieMaxAge, oidcCookiePath, "", secure, true)
+	// A plain login drops the link request of an abandoned one
+	linkMaxAge := oidcCookieMaxAge
+	if flow.LinkToken == "" {
+		linkMaxAge = -1
+	}
+	ctx.SetCookie(oidcLinkCookie, flow.LinkToken, linkMaxAge, oidcCookiePath, "", secure, true)
+}
@@ -0,0 +1,267 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+	"github.com/muzammil-cyber/golang-gin/utils"
+)
+
+type PlaylistController interface {
+	Create(ctx *gin.Context)
+	GetAll(ctx *gin.Context)
+	GetByID(ctx *gin.Context)
+	Update(ctx *gin.Context)
+	Delete(ctx *gin.Context)
+	AddItem(ctx *gin.Context)
+	MoveItem(ctx *gin.Context)
+	RemoveItem(ctx *gin.Context)
+}
+
+type playlistController struct {
+	playlistService service.PlaylistService
+}
+
+func NewPlaylistController(playlistService service.PlaylistService) PlaylistController {
+	return &playlistController{
+		playlistService: playlistService,
+	}
+}
+
+// Create godoc
+// @Summary Create a playlist
+// @Description Create an empty playlist owned by the caller. Playlists are private unless another visibility is given. Requires the videos:write permission.
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param request body dto.PlaylistCreateRequest true "Playlist to create"
+// @Success 201 {object} entity.Playlist "Created playlist"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or validation errors"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - videos:write permission required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while creating the playlist"
+// @Security BearerAuth
+// @Router /api/playlists [post]
+func (c *playlistController) Create(ctx *gin.Context) {
+	var request dto.PlaylistCreateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	playlist, err := c.playlistService.Create(request, auth.FromContext(ctx.Request.Context()))
+	if err != nil {
+		writePlaylistError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, playlist)
+}
+
+// GetAll godoc
+// @Summary List playlists
+// @Description List the playlists of a user, without their items. Without owner_id the caller's own playlists are listed; for other users only public playlists are listed. Requires the videos:read permission.
+// @Tags Playlists
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param owner_id query string false "List the playlists of this user" format(uuid)
+// @Success 200 {array} entity.Playlist "Playlists, newest first"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching playlists"
+// @Security BearerAuth
+// @Router /api/playlists [get]
+func (c *playlistController) GetAll(ctx *gin.Context) {
+	principal := auth.FromContext(ctx.Request.Context())
+	ownerID := ctx.Query("owner_id")
+	if ownerID == "" && principal != nil {
+		ownerID = principal.UserID
+	}
+	playlists, err := c.playlistService.GetByOwner(ownerID, principal)
+	if err != nil {
+		writePlaylistError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, playlists)
+}
+
+// GetByID godoc
+// @Summary Get a playlist
+// @Description Get a playlist with its videos in order. Private playlists are only visible to their owner. Requires the videos:read permission.
+// @Tags Playlists
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Playlist UUID" format(uuid)
+// @Success 200 {object} entity.Playlist "Playlist with its items"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 404 {object} dto.ErrorResponse "Playlist not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching the playlist"
+// @Security BearerAuth
+// @Router /api/playlists/{id} [get]
+func (c *playlistController) GetByID(ctx *gin.Context) {
+	playlist, err := c.playlistService.GetByID(ctx.Param("id"), auth.FromContext(ctx.Request.Context()))
+	if err != nil {
+		writePlaylistError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, playlist)
+}
+
+// Update godoc
+// @Summary Update a playlist
+// @Description Change the title, description or visibility of a playlist; omitted fields are kept. Only the owner or a user with the videos:admin permission may update it. Requires the videos:write permission.
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Playlist UUID" format(uuid)
+// @Param request body dto.PlaylistUpdateRequest true "Fields to change"
+// @Success 200 {object} entity.Playlist "Updated playlist"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or validation errors"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - the playlist belongs to another user"
+// @Failure 404 {object} dto.ErrorResponse "Playlist not found"
+// @Failure 409 {object} dto.ErrorResponse "The playlist was changed at the same time"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while updating the playlist"
+// @Security BearerAuth
+// @Router /api/playlists/{id} [patch]
+func (c *playlistController) Update(ctx *gin.Context) {
+	var request dto.PlaylistUpdateRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	playlist, err := c.playlistService.Update(ctx.Param("id"), request, auth.FromContext(ctx.Request.Context()))
+	if err != nil {
+		writePlaylistError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, playlist)
+}
+
+// Delete godoc
+// @Summary Delete a playlist
+// @Description Delete a playlist. The videos in it are not affected. Only the owner or a user with the videos:admin permission may delete it. Requires the videos:write permission.
+// @Tags Playlists
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Playlist UUID" format(uuid)
+// @Success 200 {object} dto.MessageResponse "Playlist deleted"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - the playlist belongs to another user"
+// @Failure 404 {object} dto.ErrorResponse "Playlist not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while deleting the playlist"
+// @Security BearerAuth
+// @Router /api/playlists/{id} [delete]
+func (c *playlistController) Delete(ctx *gin.Context) {
+	if err := c.playlistService.Delete(ctx.Param("id"), auth.FromContext(ctx.Request.Context())); err != nil {
+		writePlaylistError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, dto.MessageResponse{Message: "Playlist deleted"})
+}
+
+// AddItem godoc
+// @Summary Add a video to a playlist
+// @Description Insert a video at a zero-based position, or append it when no position is given. A video can only be in a playlist once. Requires the videos:write permission.
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Playlist UUID" format(uuid)
+// @Param request body dto.PlaylistItemRequest true "Video to add"
+// @Success 201 {object} entity.Playlist "Playlist with the new item"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or validation errors"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - the playlist belongs to another user"
+// @Failure 404 {object} dto.ErrorResponse "Playlist or video not found"
+// @Failure 409 {object} dto.ErrorResponse "The video is already in the playlist"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while adding the video"
+// @Security BearerAuth
+// @Router /api/playlists/{id}/items [post]
+func (c *playlistController) AddItem(ctx *gin.Context) {
+	var request dto.PlaylistItemRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	playlist, err := c.playlistService.AddItem(ctx.Param("id"), request.VideoID, request.Position, auth.FromContext(ctx.Request.Context()))
+	if err != nil {
+		writePlaylistError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusCreated, playlist)
+}
+
+// MoveItem godoc
+// @Summary Move a playlist item
+// @Description Move an item to a zero-based position; positions past the end move it to the end. Requires the videos:write permission.
+// @Tags Playlists
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Playlist UUID" format(uuid)
+// @Param itemId path string true "Playlist item UUID" format(uuid)
+// @Param request body dto.PlaylistMoveRequest true "New position"
+// @Success 200 {object} entity.Playlist "Playlist in the new order"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or validation errors"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - the playlist belongs to another user"
+// @Failure 404 {object} dto.ErrorResponse "Playlist or item not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while moving the item"
+// @Security BearerAuth
+// @Router /api/playlists/{id}/items/{itemId} [patch]
+func (c *playlistController) MoveItem(ctx *gin.Context) {
+	var request dto.PlaylistMoveRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	playlist, err := c.playlistService.MoveItem(ctx.Param("id"), ctx.Param("itemId"), *request.Position, auth.FromContext(ctx.Request.Context()))
+	if err != nil {
+		writePlaylistError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, playlist)
+}
+
+// RemoveItem godoc
+// @Summary Remove a video from a playlist
+// @Description Remove an item from a playlist. The video itself is not affected. Requires the videos:write permission.
+// @Tags Playlists
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Playlist UUID" format(uuid)
+// @Param itemId path string true "Playlist item UUID" format(uuid)
+// @Success 200 {object} entity.Playlist "Playlist without the item"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - the playlist belongs to another user"
+// @Failure 404 {object} dto.ErrorResponse "Playlist or item not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while removing the item"
+// @Security BearerAuth
+// @Router /api/playlists/{id}/items/{itemId} [delete]
+func (c *playlistController) RemoveItem(ctx *gin.Context) {
+	playlist, err := c.playlistService.RemoveItem(ctx.Param("id"), ctx.Param("itemId"), auth.FromContext(ctx.Request.Context()))
+	if err != nil {
+		writePlaylistError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, playlist)
+}
+
+// writePlaylistError maps playlist service errors to HTTP responses
+func writePlaylistError(ctx *gin.Context, err error) {
+	switch {
+	case errors.Is(err, service.ErrPlaylistNotFound), errors.Is(err, service.ErrPlaylistItemNotFound), errors.Is(err, service.ErrVideoNotFound):
+		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrPlaylistForbidden):
+		ctx.JSON(http.StatusForbidden, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrVideoAlreadyInPlaylist), errors.Is(err, repository.ErrVersionConflict):
+		ctx.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+	}
+}
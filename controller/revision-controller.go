@@ -0,0 +1,163 @@
+package controller
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/service"
+	"github.com/muzammil-cyber/golang-gin/utils"
+)
+
+type RevisionController interface {
+	GetAll(ctx *gin.Context)
+	GetOne(ctx *gin.Context)
+	Diff(ctx *gin.Context)
+	Restore(ctx *gin.Context)
+}
+
+type revisionController struct {
+	revisionService service.RevisionService
+	videoService    service.VideoService
+}
+
+func NewRevisionController(revisionService service.RevisionService, videoService service.VideoService) RevisionController {
+	return &revisionController{
+		revisionService: revisionService,
+		videoService:    videoService,
+	}
+}
+
+// GetAll godoc
+// @Summary List the revisions of a video
+// @Description List every recorded change of a video, oldest first: who made it, when, the fields it changed and the metadata before and after. Revisions are numbered by the version the change gave the video. Requires the videos:read permission.
+// @Tags Revisions
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Video UUID" format(uuid)
+// @Success 200 {array} entity.VideoRevision "Revisions"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 404 {object} dto.ErrorResponse "Video not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching revisions"
+// @Security BearerAuth
+// @Router /api/videos/{id}/revisions [get]
+func (c *revisionController) GetAll(ctx *gin.Context) {
+	revisions, err := c.revisionService.List(ctx.Param("id"))
+	if err != nil {
+		writeVideoError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, revisions)
+}
+
+// GetOne godoc
+// @Summary Get a revision of a video
+// @Description Get one recorded change of a video. Requires the videos:read permission.
+// @Tags Revisions
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Video UUID" format(uuid)
+// @Param rev path int true "Revision number"
+// @Success 200 {object} entity.VideoRevision "Revision"
+// @Failure 400 {object} dto.ErrorResponse "Invalid revision number"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 404 {object} dto.ErrorResponse "Video or revision not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching the revision"
+// @Security BearerAuth
+// @Router /api/videos/{id}/revisions/{rev} [get]
+func (c *revisionController) GetOne(ctx *gin.Context) {
+	number, ok := revisionNumber(ctx)
+	if !ok {
+		return
+	}
+	revision, err := c.revisionService.Get(ctx.Param("id"), number)
+	if err != nil {
+		writeVideoError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, revision)
+}
+
+// Diff godoc
+// @Summary Compare two revisions of a video
+// @Description List the fields whose values differ between the video as it was after revision from and as it was after revision to. Requires the videos:read permission.
+// @Tags Revisions
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Video UUID" format(uuid)
+// @Param query query dto.RevisionDiffQuery true "Revisions to compare"
+// @Success 200 {object} dto.RevisionDiffResponse "Changed fields"
+// @Failure 400 {object} dto.ValidationErrorResponse "Missing or invalid revision numbers"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 404 {object} dto.ErrorResponse "Video or revision not found"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while comparing revisions"
+// @Security BearerAuth
+// @Router /api/videos/{id}/revisions/diff [get]
+func (c *revisionController) Diff(ctx *gin.Context) {
+	var query dto.RevisionDiffQuery
+	if err := ctx.ShouldBindQuery(&query); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	changes, err := c.revisionService.Diff(ctx.Param("id"), query.From, query.To)
+	if err != nil {
+		writeVideoError(ctx, err)
+		return
+	}
+	response := dto.RevisionDiffResponse{From: query.From, To: query.To, Changes: make([]dto.RevisionFieldChange, len(changes))}
+	for i, change := range changes {
+		response.Changes[i] = dto.RevisionFieldChange{Field: change.Field, From: change.From, To: change.To}
+	}
+	ctx.JSON(http.StatusOK, response)
+}
+
+// Restore godoc
+// @Summary Roll a video back to a revision
+// @Description Set the title, description, URL, author and tags of a video back to how they were after the given revision. The rollback is recorded as a new revision; nothing changes if the video already matches. The If-Match header must hold the ETag from GET, or * to roll back any version. Only the creator of the video or a user with the videos:admin permission may roll it back. Requires the videos:write permission.
+// @Tags Revisions
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Video UUID" format(uuid)
+// @Param rev path int true "Revision number"
+// @Param If-Match header string true "ETag of the version being changed, or *"
+// @Success 200 {object} entity.Video "Video as of the revision"
+// @Header 200 {string} ETag "New version of the video"
+// @Failure 400 {object} dto.ErrorResponse "Invalid revision number, or the revision's author no longer exists"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - the video belongs to another user"
+// @Failure 404 {object} dto.ErrorResponse "Video or revision not found"
+// @Failure 412 {object} dto.ErrorResponse "The video has been changed since the If-Match version"
+// @Failure 428 {object} dto.ErrorResponse "If-Match header missing"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while rolling back the video"
+// @Security BearerAuth
+// @Router /api/videos/{id}/revisions/{rev}/restore [post]
+func (c *revisionController) Restore(ctx *gin.Context) {
+	number, ok := revisionNumber(ctx)
+	if !ok {
+		return
+	}
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		return
+	}
+	video, err := c.videoService.RestoreRevision(ctx.Param("id"), number, version, auth.FromContext(ctx.Request.Context()))
+	if err != nil {
+		writeVideoError(ctx, err)
+		return
+	}
+	ctx.Header("ETag", videoETag(&video))
+	ctx.JSON(http.StatusOK, video)
+}
+
+// revisionNumber parses the rev path parameter, writing the error response
+// and returning false if it is not a revision number
+func revisionNumber(ctx *gin.Context) (int64, bool) {
+	number, err := strconv.ParseInt(ctx.Param("rev"), 10, 64)
+	if err != nil || number <= 0 {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: "the revision must be a positive number"})
+		return 0, false
+	}
+	return number, true
+}
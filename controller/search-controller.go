@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+	"github.com/muzammil-cyber/golang-gin/utils"
+)
+
+type SearchController interface {
+	SearchVideos(ctx *gin.Context)
+}
+
+type searchController struct {
+	searchService service.SearchService
+}
+
+func NewSearchController(searchService service.SearchService) SearchController {
+	return &searchController{
+		searchService: searchService,
+	}
+}
+
+// SearchVideos godoc
+// @Summary Search videos
+// @Description Full-text search over video titles and descriptions, best matches first. Every word must match; "quoted words" match as a phrase and a trailing * matches words starting with the given prefix, e.g. q="getting started" gola*. Title matches rank above description matches. Requires JWT authentication.
+// @Tags Videos
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param q query string true "Search query"
+// @Param limit query int false "Page size" minimum(1) maximum(100) default(20)
+// @Param offset query int false "Number of hits to skip" minimum(0) default(0)
+// @Success 200 {object} dto.VideoSearchResponse "Matching videos with scores and highlights"
+// @Failure 400 {object} dto.ValidationErrorResponse "Missing query or invalid parameters"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while searching"
+// @Security BearerAuth
+// @Router /api/videos/search [get]
+func (c *searchController) SearchVideos(ctx *gin.Context) {
+	var request dto.VideoSearchQuery
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	results, err := c.searchService.SearchVideos(request.Q, request.Limit, request.Offset)
+	if errors.Is(err, repository.ErrEmptySearch) {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	items := make([]dto.VideoSearchHit, len(results.Hits))
+	for i, hit := range results.Hits {
+		items[i] = dto.VideoSearchHit{
+			Video:          hit.Video,
+			Score:          hit.Score,
+			TitleHighlight: hit.TitleHighlight,
+			Snippet:        hit.Snippet,
+		}
+	}
+	ctx.JSON(http.StatusOK, dto.VideoSearchResponse{Items: items, TotalCount: results.Total})
+}
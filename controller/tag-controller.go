@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+type TagController interface {
+	GetAll(ctx *gin.Context)
+}
+
+type tagController struct {
+	tagService service.TagService
+}
+
+func NewTagController(tagService service.TagService) TagController {
+	return &tagController{
+		tagService: tagService,
+	}
+}
+
+// GetAll godoc
+// @Summary List tags
+// @Description List the tags on videos with the number of videos carrying each, most used first. Requires JWT authentication.
+// @Tags Videos
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {array} dto.TagResponse "Tags with usage counts"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching tags"
+// @Security BearerAuth
+// @Router /api/tags [get]
+func (c *tagController) GetAll(ctx *gin.Context) {
+	counts, err := c.tagService.GetAll()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	tags := make([]dto.TagResponse, len(counts))
+	for i, count := range counts {
+		tags[i] = dto.TagResponse{Name: count.Name, Count: count.Count}
+	}
+	ctx.JSON(http.StatusOK, tags)
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+type TrashController interface {
+	GetAll(ctx *gin.Context)
+	Restore(ctx *gin.Context)
+	Purge(ctx *gin.Context)
+}
+
+type trashController struct {
+	trashService service.TrashService
+}
+
+func NewTrashController(trashService service.TrashService) TrashController {
+	return &trashController{
+		trashService: trashService,
+	}
+}
+
+// GetAll godoc
+// @Summary List deleted videos
+// @Description List the caller's deleted videos, most recently deleted first, with the time each will be purged. Users with the videos:admin permission see the deleted videos of all users. Requires the videos:read permission.
+// @Tags Trash
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {array} dto.TrashItem "Deleted videos"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching the trash"
+// @Security BearerAuth
+// @Router /api/trash [get]
+func (c *trashController) GetAll(ctx *gin.Context) {
+	trashed, err := c.trashService.List(auth.FromContext(ctx.Request.Context()))
+	if err != nil {
+		writeVideoError(ctx, err)
+		return
+	}
+	items := make([]dto.TrashItem, len(trashed))
+	for i, item := range trashed {
+		items[i] = dto.TrashItem{Video: item.Video, DeletedAt: item.Video.DeletedAt.Time, PurgeAt: item.PurgeAt}
+	}
+	ctx.JSON(http.StatusOK, items)
+}
+
+// Restore godoc
+// @Summary Restore a deleted video
+// @Description Take a video out of the trash. It shows up in lists, searches and playlists again. Only the creator of the video or a user with the videos:admin permission may restore it. Requires the videos:delete permission.
+// @Tags Trash
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Video UUID" format(uuid)
+// @Success 200 {object} entity.Video "Restored video"
+// @Header 200 {string} ETag "New version of the video"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 404 {object} dto.ErrorResponse "No such video in the caller's trash"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while restoring the video"
+// @Security BearerAuth
+// @Router /api/trash/{id}/restore [post]
+func (c *trashController) Restore(ctx *gin.Context) {
+	video, err := c.trashService.Restore(ctx.Param("id"), auth.FromContext(ctx.Request.Context()))
+	if err != nil {
+		writeVideoError(ctx, err)
+		return
+	}
+	ctx.Header("ETag", videoETag(video))
+	ctx.JSON(http.StatusOK, video)
+}
+
+// Purge godoc
+// @Summary Purge a deleted video
+// @Description Delete a video in the trash for good, along with its tags and playlist entries. This cannot be undone. The revision history is kept and ends with a purge revision. Only the creator of the video or a user with the videos:admin permission may purge it. Requires the videos:delete permission.
+// @Tags Trash
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Video UUID" format(uuid)
+// @Success 200 {object} dto.MessageResponse "Video purged"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 404 {object} dto.ErrorResponse "No such video in the caller's trash"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while purging the video"
+// @Security BearerAuth
+// @Router /api/trash/{id} [delete]
+func (c *trashController) Purge(ctx *gin.Context) {
+	if err := c.trashService.Purge(ctx.Param("id"), auth.FromContext(ctx.Request.Context())); err != nil {
+		writeVideoError(ctx, err)
+		return
+	}
+	ctx.JSON(http.StatusOK, dto.MessageResponse{Message: "Video purged"})
+}
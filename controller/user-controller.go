@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/service"
+	"github.com/muzammil-cyber/golang-gin/utils"
+)
+
+type UserController interface {
+	GetAll(ctx *gin.Context)
+	GetRoles(ctx *gin.Context)
+	SetRoles(ctx *gin.Context)
+	Unlock(ctx *gin.Context)
+}
+
+type userController struct {
+	userService   service.UserService
+	loginThrottle service.LoginThrottle
+}
+
+func NewUserController(userService service.UserService, loginThrottle service.LoginThrottle) UserController {
+	return &userController{
+		userService:   userService,
+		loginThrottle: loginThrottle,
+	}
+}
+
+// GetAll godoc
+// @Summary List users
+// @Description List every account with its roles. Requires the users:admin permission.
+// @Tags Users
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {array} entity.User "Users with their roles"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - users:admin permission required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching users"
+// @Security BearerAuth
+// @Router /api/users [get]
+func (c *userController) GetAll(ctx *gin.Context) {
+	users, err := c.userService.GetAll()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, users)
+}
+
+// GetRoles godoc
+// @Summary List roles
+// @Description List the roles that can be granted and their permissions. Requires the users:admin permission.
+// @Tags Users
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {array} entity.Role "Roles with their permissions"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - users:admin permission required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching roles"
+// @Security BearerAuth
+// @Router /api/roles [get]
+func (c *userController) GetRoles(ctx *gin.Context) {
+	roles, err := c.userService.GetRoles()
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, roles)
+}
+
+// SetRoles godoc
+// @Summary Set the roles of a user
+// @Description Replace the roles granted to a user. Takes effect for access tokens issued afterwards. Requires the users:admin permission.
+// @Tags Users
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "User UUID" format(uuid)
+// @Param request body dto.SetRolesRequest true "Roles to grant"
+// @Success 200 {object} entity.User "User with the new roles"
+// @Failure 400 {object} dto.ErrorResponse "Invalid request format or unknown role"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - users:admin permission required"
+// @Failure 404 {object} dto.ErrorResponse "User not found with provided ID"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while updating roles"
+// @Security BearerAuth
+// @Router /api/users/{id}/roles [put]
+func (c *userController) SetRoles(ctx *gin.Context) {
+	var request dto.SetRolesRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return
+	}
+	user, err := c.userService.SetRoles(ctx.Param("id"), request.Roles)
+	if errors.Is(err, service.ErrUnknownRole) {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if errors.Is(err, service.ErrUserNotFound) {
+		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, user)
+}
+
+// Unlock godoc
+// @Summary Unlock a user
+// @Description Lift a lockout caused by failed logins and forget the user's failed attempts. Lockouts of client IP addresses are not affected. Requires the users:admin permission.
+// @Tags Users
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "User UUID" format(uuid)
+// @Success 200 {object} dto.MessageResponse "User unlocked"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - users:admin permission required"
+// @Failure 404 {object} dto.ErrorResponse "User not found with provided ID"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while unlocking"
+// @Security BearerAuth
+// @Router /api/users/{id}/unlock [post]
+func (c *userController) Unlock(ctx *gin.Context) {
+	user, err := c.userService.GetByID(ctx.Param("id"))
+	if errors.Is(err, service.ErrUserNotFound) {
+		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err := c.loginThrottle.Unlock(user.Username); err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return
+	}
+	ctx.JSON(http.StatusOK, dto.MessageResponse{Message: "User unlocked"})
+}
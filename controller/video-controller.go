@@ -1,12 +1,17 @@
 package controller
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-playground/validator/v10"
+	"github.com/muzammil-cyber/golang-gin/auth"
 	"github.com/muzammil-cyber/golang-gin/dto"
 	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
 	"github.com/muzammil-cyber/golang-gin/service"
 	"github.com/muzammil-cyber/golang-gin/utils"
 	"github.com/muzammil-cyber/golang-gin/validators"
@@ -14,10 +19,12 @@ import (
 
 type VideoController interface {
 	Save(ctx *gin.Context)
-	GetAll(ctx *gin.Context) []entity.Video
+	GetAll(ctx *gin.Context) *dto.VideoPageResponse
+	GetMine(ctx *gin.Context) []entity.Video
 	ShowAll(ctx *gin.Context)
 	GetByID(ctx *gin.Context) entity.Video
 	Update(ctx *gin.Context) entity.Video
+	Patch(ctx *gin.Context) entity.Video
 	Delete(ctx *gin.Context) error
 }
 
@@ -38,14 +45,14 @@ func New(videoService service.VideoService) VideoController {
 
 // Save godoc
 // @Summary Create a new video
-// @Description Create a new video entry with associated author information. Requires JWT authentication.
+// @Description Create a new video entry with optional tags. Give author_id to credit an existing author, or an author object to create one or update the author with the same email. Tags are lowercased and created on first use. Requires JWT authentication.
 // @Tags Videos
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token" default(Bearer <token>)
 // @Param video body dto.VideoCreateRequest true "Video object with nested author information"
 // @Success 200 {object} entity.Video "Successfully created video with generated ID"
-// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or validation errors"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format, validation errors, invalid tag or unknown author_id"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error while saving video"
 // @Security BearerAuth
@@ -62,28 +69,88 @@ func (c *controller) Save(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
 		return
 	}
-	savedVideo, err := c.videoService.Save(video)
+	savedVideo, err := c.videoService.Save(video, auth.FromContext(ctx.Request.Context()))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		writeVideoError(ctx, err)
 		return
 	}
 	ctx.JSON(200, savedVideo)
 }
 
 // GetAll godoc
-// @Summary Get all videos
-// @Description Retrieve a complete list of all videos with their author information. Requires JWT authentication.
+// @Summary List videos
+// @Description Retrieve a page of videos with their author information. Pass next_cursor from a response as cursor to get the following page; cursors are only valid with the same sort and order. Requires JWT authentication.
 // @Tags Videos
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token" default(Bearer <token>)
-// @Success 200 {array} entity.Video "List of all videos with author details"
+// @Param limit query int false "Page size" minimum(1) maximum(100) default(20)
+// @Param cursor query string false "Cursor from next_cursor of the previous page"
+// @Param sort query string false "Sort field" Enums(created_at, updated_at, title) default(created_at)
+// @Param order query string false "Sort direction" Enums(asc, desc) default(asc)
+// @Param author_id query string false "Only videos by this author" format(uuid)
+// @Param title query string false "Only videos whose title contains this text, ignoring case"
+// @Param created_after query string false "Only videos created after this time" format(date-time)
+// @Param created_before query string false "Only videos created before this time" format(date-time)
+// @Param tag query string false "Only videos with these comma-separated tags"
+// @Param tag_match query string false "Whether videos need any or all of the tags" Enums(any, all) default(any)
+// @Success 200 {object} dto.VideoPageResponse "A page of videos with author details"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid query parameters, cursor or tag"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching videos"
 // @Security BearerAuth
 // @Router /api/videos [get]
-func (c *controller) GetAll(ctx *gin.Context) []entity.Video {
-	videos, err := c.videoService.GetAll()
+func (c *controller) GetAll(ctx *gin.Context) *dto.VideoPageResponse {
+	var request dto.VideoListQuery
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
+		return nil
+	}
+	page, err := c.videoService.List(repository.VideoQuery{
+		Limit:         request.Limit,
+		After:         request.Cursor,
+		Sort:          request.Sort,
+		Desc:          request.Order == "desc",
+		AuthorID:      request.AuthorID,
+		TitleContains: request.Title,
+		CreatedAfter:  request.CreatedAfter,
+		CreatedBefore: request.CreatedBefore,
+		Tags:          splitTags(request.Tag),
+		MatchAllTags:  request.TagMatch == "all",
+	})
+	if errors.Is(err, repository.ErrInvalidCursor) || errors.Is(err, repository.ErrInvalidSort) || errors.Is(err, service.ErrInvalidTag) {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return nil
+	}
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		return nil
+	}
+	items := page.Videos
+	if items == nil {
+		items = []entity.Video{}
+	}
+	return &dto.VideoPageResponse{
+		Items:      items,
+		NextCursor: page.NextCursor,
+		TotalCount: page.Total,
+	}
+}
+
+// GetMine godoc
+// @Summary Get my videos
+// @Description Retrieve the videos created by the authenticated user. Requires JWT authentication.
+// @Tags Videos
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Success 200 {array} entity.Video "Videos created by the caller"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching videos"
+// @Security BearerAuth
+// @Router /api/me/videos [get]
+func (c *controller) GetMine(ctx *gin.Context) []entity.Video {
+	videos, err := c.videoService.GetByOwner(ctx.GetString("user_id"))
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
 		return nil
@@ -114,13 +181,16 @@ func (c *controller) ShowAll(ctx *gin.Context) {
 
 // GetByID godoc
 // @Summary Get video by ID
-// @Description Retrieve detailed information for a specific video by its unique ID. Requires JWT authentication.
+// @Description Retrieve detailed information for a specific video by its unique ID. The ETag header holds the versions of the video and its author; send it as If-None-Match to get 304 while neither changed, or as If-Match to update or delete the video. Requires JWT authentication.
 // @Tags Videos
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token" default(Bearer <token>)
 // @Param id path string true "Video UUID" format(uuid)
+// @Param If-None-Match header string false "ETags of versions the client has"
 // @Success 200 {object} entity.Video "Video details with author information"
+// @Success 304 "The video and its author still have one of the If-None-Match versions"
+// @Header 200 {string} ETag "Versions of the video and its author"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
 // @Failure 404 {object} dto.ErrorResponse "Video not found with provided ID"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error while fetching video"
@@ -130,7 +200,13 @@ func (c *controller) GetByID(ctx *gin.Context) entity.Video {
 	id := ctx.Param("id")
 	video, err := c.videoService.GetByID(id)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		writeVideoError(ctx, err)
+		return entity.Video{}
+	}
+	etag := videoETag(video)
+	ctx.Header("ETag", etag)
+	if !noneMatch(ctx, etag) {
+		ctx.AbortWithStatus(http.StatusNotModified)
 		return entity.Video{}
 	}
 	return *video
@@ -138,17 +214,22 @@ func (c *controller) GetByID(ctx *gin.Context) entity.Video {
 
 // Update godoc
 // @Summary Update a video
-// @Description Update an existing video's information by its ID. All fields in the video object can be updated. Requires JWT authentication.
+// @Description Replace an existing video's information by its ID; use PATCH to change only some fields. All fields in the video object can be updated; tags are replaced when given and kept when omitted. The If-Match header must hold the ETag from GET, or * to overwrite any version. Only the creator of the video or a user with the videos:admin permission may update it. Requires JWT authentication.
 // @Tags Videos
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token" default(Bearer <token>)
 // @Param id path string true "Video UUID" format(uuid)
 // @Param video body entity.Video true "Updated video object with new information"
+// @Param If-Match header string true "ETag of the version being changed, or *"
 // @Success 200 {object} entity.Video "Successfully updated video"
-// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format or validation errors"
+// @Header 200 {string} ETag "New version of the video"
+// @Failure 400 {object} dto.ValidationErrorResponse "Invalid request format, validation errors or invalid tag"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - the video belongs to another user"
 // @Failure 404 {object} dto.ErrorResponse "Video not found with provided ID"
+// @Failure 412 {object} dto.ErrorResponse "The video has been changed since the If-Match version"
+// @Failure 428 {object} dto.ErrorResponse "If-Match header missing"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error while updating video"
 // @Security BearerAuth
 // @Router /api/videos/{id} [put]
@@ -166,34 +247,133 @@ func (c *controller) Update(ctx *gin.Context) entity.Video {
 		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(err)})
 		return entity.Video{}
 	}
-	updatedVideo, err := c.videoService.Update(video)
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		return entity.Video{}
+	}
+	video.Version = version
+	updatedVideo, err := c.videoService.Update(video, auth.FromContext(ctx.Request.Context()))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		writeVideoError(ctx, err)
 		return entity.Video{}
 	}
+	ctx.Header("ETag", videoETag(&updatedVideo))
 	return updatedVideo
 }
 
+// Patch godoc
+// @Summary Partially update a video
+// @Description Change some fields of a video. The patch applies to the document {title, description, url, author_id, tags}: send an RFC 7396 merge patch as application/merge-patch+json (or application/json), or an RFC 6902 operation list as application/json-patch+json. The patched document is validated as a whole and only changed fields are saved. Authors are changed through author_id. The If-Match header must hold the ETag from GET, or * to patch any version. Only the creator of the video or a user with the videos:admin permission may patch it. Requires JWT authentication.
+// @Tags Videos
+// @Accept application/merge-patch+json,application/json-patch+json,json
+// @Produce json
+// @Param Authorization header string true "Bearer token" default(Bearer <token>)
+// @Param id path string true "Video UUID" format(uuid)
+// @Param patch body dto.VideoPatchDocument true "Merge patch with the fields to change, or a list of JSON Patch operations on this document"
+// @Param If-Match header string true "ETag of the version being changed, or *"
+// @Success 200 {object} entity.Video "Patched video"
+// @Header 200 {string} ETag "New version of the video"
+// @Failure 400 {object} dto.ValidationErrorResponse "Malformed patch, patched document failing validation, invalid tag or unknown author_id"
+// @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - the video belongs to another user"
+// @Failure 404 {object} dto.ErrorResponse "Video not found with provided ID"
+// @Failure 409 {object} dto.ErrorResponse "A JSON Patch test operation failed"
+// @Failure 412 {object} dto.ErrorResponse "The video has been changed since the If-Match version"
+// @Failure 415 {object} dto.ErrorResponse "Unsupported patch content type"
+// @Failure 428 {object} dto.ErrorResponse "If-Match header missing"
+// @Failure 500 {object} dto.ErrorResponse "Internal server error while updating video"
+// @Security BearerAuth
+// @Router /api/videos/{id} [patch]
+func (c *controller) Patch(ctx *gin.Context) entity.Video {
+	var format service.PatchFormat
+	switch ctx.ContentType() {
+	case "application/merge-patch+json", "application/json":
+		format = service.MergePatch
+	case "application/json-patch+json":
+		format = service.JSONPatch
+	default:
+		ctx.JSON(http.StatusUnsupportedMediaType, dto.ErrorResponse{Error: "use application/merge-patch+json or application/json-patch+json"})
+		return entity.Video{}
+	}
+	patch, err := io.ReadAll(ctx.Request.Body)
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+		return entity.Video{}
+	}
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		return entity.Video{}
+	}
+	patchedVideo, err := c.videoService.Patch(ctx.Param("id"), format, patch, version, auth.FromContext(ctx.Request.Context()))
+	if err != nil {
+		writeVideoError(ctx, err)
+		return entity.Video{}
+	}
+	ctx.Header("ETag", videoETag(&patchedVideo))
+	return patchedVideo
+}
+
 // Delete godoc
 // @Summary Delete a video
-// @Description Permanently delete a video by its ID. This action cannot be undone. Requires JWT authentication.
+// @Description Move a video to the trash by its ID. It can be restored from /api/trash until it is purged after the retention period. The If-Match header must hold the ETag from GET, or * to delete any version. Only the creator of the video or a user with the videos:admin permission may delete it. Requires JWT authentication.
 // @Tags Videos
 // @Accept json
 // @Produce json
 // @Param Authorization header string true "Bearer token" default(Bearer <token>)
 // @Param id path string true "Video UUID" format(uuid)
+// @Param If-Match header string true "ETag of the version being changed, or *"
 // @Success 200 {object} dto.MessageResponse "Video successfully deleted"
 // @Failure 401 {object} dto.ErrorResponse "Unauthorized - valid JWT token required"
+// @Failure 403 {object} dto.ErrorResponse "Forbidden - the video belongs to another user"
 // @Failure 404 {object} dto.ErrorResponse "Video not found with provided ID"
+// @Failure 412 {object} dto.ErrorResponse "The video has been changed since the If-Match version"
+// @Failure 428 {object} dto.ErrorResponse "If-Match header missing"
 // @Failure 500 {object} dto.ErrorResponse "Internal server error while deleting video"
 // @Security BearerAuth
 // @Router /api/videos/{id} [delete]
 func (c *controller) Delete(ctx *gin.Context) error {
 	id := ctx.Param("id")
-	err := c.videoService.Delete(id)
+	version, ok := ifMatchVersion(ctx)
+	if !ok {
+		return errPreconditionResponse
+	}
+	err := c.videoService.Delete(id, version, auth.FromContext(ctx.Request.Context()))
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+		writeVideoError(ctx, err)
 		return err
 	}
 	return nil
 }
+
+// writeVideoError maps video service errors to HTTP responses
+func writeVideoError(ctx *gin.Context, err error) {
+	var invalid validator.ValidationErrors
+	switch {
+	case errors.As(err, &invalid):
+		ctx.JSON(http.StatusBadRequest, dto.ValidationErrorResponse{Errors: utils.FormatValidationError(invalid)})
+	case errors.Is(err, service.ErrVideoNotFound), errors.Is(err, service.ErrRevisionNotFound):
+		ctx.JSON(http.StatusNotFound, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrForbidden):
+		ctx.JSON(http.StatusForbidden, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrPatchTestFailed):
+		ctx.JSON(http.StatusConflict, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrVersionMismatch):
+		ctx.JSON(http.StatusPreconditionFailed, dto.ErrorResponse{Error: err.Error()})
+	case errors.Is(err, service.ErrInvalidTag), errors.Is(err, service.ErrAuthorNotFound), errors.Is(err, service.ErrAuthorRequired),
+		errors.Is(err, service.ErrInvalidPatch):
+		ctx.JSON(http.StatusBadRequest, dto.ErrorResponse{Error: err.Error()})
+	default:
+		ctx.JSON(http.StatusInternalServerError, dto.ErrorResponse{Error: err.Error()})
+	}
+}
+
+// splitTags splits a comma-separated tag list, ignoring empty entries
+func splitTags(list string) []string {
+	var tags []string
+	for _, tag := range strings.Split(list, ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return tags
+}
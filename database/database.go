@@ -6,4 +6,15 @@ import (
 
 type Database interface {
 	GetDB() *gorm.DB
+	// Close closes the connections to the database
+	Close() error
+}
+
+// Close closes the connection pool of db
+func Close(db *gorm.DB) error {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return err
+	}
+	return sqlDB.Close()
 }
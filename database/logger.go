@@ -0,0 +1,21 @@
+package database
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"gorm.io/gorm/logger"
+)
+
+// NewLogger returns the SQL logger shared by all database drivers
+func NewLogger() logger.Interface {
+	return logger.New(
+		log.New(os.Stdout, "\r\n", log.LstdFlags),
+		logger.Config{
+			SlowThreshold: time.Second,
+			LogLevel:      logger.Info,
+			Colorful:      true,
+		},
+	)
+}
@@ -0,0 +1,35 @@
+package postgres
+
+import (
+	"fmt"
+
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+)
+
+type PostgresDB struct {
+	DB *gorm.DB
+}
+
+// NewPostgresDB connects to PostgreSQL. dsn is a postgres:// URL or a
+// list of key=value settings.
+func NewPostgresDB(dsn string) (database.Database, error) {
+	gormDB, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
+		Logger: database.NewLogger(),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize gorm with postgres: %w", err)
+	}
+
+	return &PostgresDB{DB: gormDB}, nil
+}
+
+func (p *PostgresDB) GetDB() *gorm.DB {
+	return p.DB
+}
+
+func (p *PostgresDB) Close() error {
+	return database.Close(p.DB)
+}
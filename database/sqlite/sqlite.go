@@ -2,14 +2,11 @@ package sqlite
 
 import (
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
-	"time"
 
 	"gorm.io/driver/sqlite"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
 
 	"github.com/muzammil-cyber/golang-gin/database"
 )
@@ -18,16 +15,9 @@ type SQLiteDB struct {
 	DB *gorm.DB
 }
 
-func getPath() string {
-	homeDir := os.Getenv("DB_PATH")
-	if homeDir == "" {
-		homeDir = "./data/sqlite.db"
-	}
-	return homeDir
-}
-
-func NewSQLiteDB() (database.Database, error) {
-	dbPath := getPath()
+// NewSQLiteDBAt opens the database file at dbPath, creating its directory
+// if needed
+func NewSQLiteDBAt(dbPath string) (database.Database, error) {
 	dir := filepath.Dir(dbPath)
 	if _, err := os.Stat(dir); os.IsNotExist(err) {
 		if err := os.MkdirAll(dir, os.ModePerm); err != nil {
@@ -35,17 +25,8 @@ func NewSQLiteDB() (database.Database, error) {
 		}
 	}
 
-	gormLogger := logger.New(
-		log.New(os.Stdout, "\r\n", log.LstdFlags),
-		logger.Config{
-			SlowThreshold: time.Second,
-			LogLevel:      logger.Info,
-			Colorful:      true,
-		},
-	)
-
 	gormDB, err := gorm.Open(sqlite.Open(dbPath), &gorm.Config{
-		Logger: gormLogger,
+		Logger: database.NewLogger(),
 	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to initialize gorm with sqlite: %w", err)
@@ -57,3 +38,7 @@ func NewSQLiteDB() (database.Database, error) {
 func (s *SQLiteDB) GetDB() *gorm.DB {
 	return s.DB
 }
+
+func (s *SQLiteDB) Close() error {
+	return database.Close(s.DB)
+}
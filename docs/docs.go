@@ -24,24 +24,41 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/videos": {
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Public keys that verify the tokens issued by this API, for services that validate tokens without the signing key. Empty when tokens are signed with HS256.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "JSON Web Key Set",
+                "responses": {
+                    "200": {
+                        "description": "Active verification keys",
+                        "schema": {
+                            "$ref": "#/definitions/dto.JSONWebKeySet"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/authors": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a complete list of all videos with their author information. Requires JWT authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "List all authors ordered by name. Requires the videos:read permission.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Videos"
+                    "Authors"
                 ],
-                "summary": "Get all videos",
+                "summary": "List authors",
                 "parameters": [
                     {
                         "type": "string",
@@ -54,11 +71,11 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of all videos with author details",
+                        "description": "Authors",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/entity.Video"
+                                "$ref": "#/definitions/entity.Person"
                             }
                         }
                     },
@@ -69,7 +86,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal server error while fetching videos",
+                        "description": "Internal server error while fetching authors",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -82,7 +99,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new video entry with associated author information. Requires JWT authentication.",
+                "description": "Create an author that videos can refer to by author_id. Emails are unique and compared without case. Requires the videos:write permission.",
                 "consumes": [
                     "application/json"
                 ],
@@ -90,9 +107,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Videos"
+                    "Authors"
                 ],
-                "summary": "Create a new video",
+                "summary": "Create an author",
                 "parameters": [
                     {
                         "type": "string",
@@ -103,20 +120,20 @@ const docTemplate = `{
                         "required": true
                     },
                     {
-                        "description": "Video object with nested author information",
-                        "name": "video",
+                        "description": "Author to create",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dto.VideoCreateRequest"
+                            "$ref": "#/definitions/dto.AuthorRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully created video with generated ID",
+                    "201": {
+                        "description": "Created author",
                         "schema": {
-                            "$ref": "#/definitions/entity.Video"
+                            "$ref": "#/definitions/entity.Person"
                         }
                     },
                     "400": {
@@ -131,8 +148,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden - videos:write permission required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another author has this email",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Internal server error while saving video",
+                        "description": "Internal server error while creating the author",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -140,24 +169,21 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/videos/{id}": {
+        "/api/authors/{id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve detailed information for a specific video by its unique ID. Requires JWT authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get an author by ID. Use GET /api/videos?author_id= for their videos. Requires the videos:read permission.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Videos"
+                    "Authors"
                 ],
-                "summary": "Get video by ID",
+                "summary": "Get an author",
                 "parameters": [
                     {
                         "type": "string",
@@ -170,7 +196,7 @@ const docTemplate = `{
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Video UUID",
+                        "description": "Author UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -178,9 +204,9 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Video details with author information",
+                        "description": "Author",
                         "schema": {
-                            "$ref": "#/definitions/entity.Video"
+                            "$ref": "#/definitions/entity.Person"
                         }
                     },
                     "401": {
@@ -190,13 +216,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Video not found with provided ID",
+                        "description": "Author not found",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error while fetching video",
+                        "description": "Internal server error while fetching the author",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -209,7 +235,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update an existing video's information by its ID. All fields in the video object can be updated. Requires JWT authentication.",
+                "description": "Replace the name, age and email of an author. The change shows on all of the author's videos. Requires the videos:write permission.",
                 "consumes": [
                     "application/json"
                 ],
@@ -217,9 +243,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Videos"
+                    "Authors"
                 ],
-                "summary": "Update a video",
+                "summary": "Update an author",
                 "parameters": [
                     {
                         "type": "string",
@@ -232,26 +258,26 @@ const docTemplate = `{
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Video UUID",
+                        "description": "Author UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Updated video object with new information",
-                        "name": "video",
+                        "description": "New author details",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.Video"
+                            "$ref": "#/definitions/dto.AuthorRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated video",
+                        "description": "Updated author",
                         "schema": {
-                            "$ref": "#/definitions/entity.Video"
+                            "$ref": "#/definitions/entity.Person"
                         }
                     },
                     "400": {
@@ -266,14 +292,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden - videos:write permission required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Video not found with provided ID",
+                        "description": "Author not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Another author has this email, or the author was changed at the same time",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error while updating video",
+                        "description": "Internal server error while updating the author",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -286,17 +324,14 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Permanently delete a video by its ID. This action cannot be undone. Requires JWT authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Delete an author who has no videos left, counting videos in the trash. Requires the videos:delete permission.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Videos"
+                    "Authors"
                 ],
-                "summary": "Delete a video",
+                "summary": "Delete an author",
                 "parameters": [
                     {
                         "type": "string",
@@ -309,7 +344,7 @@ const docTemplate = `{
                     {
                         "type": "string",
                         "format": "uuid",
-                        "description": "Video UUID",
+                        "description": "Author UUID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -317,7 +352,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Video successfully deleted",
+                        "description": "Author deleted",
                         "schema": {
                             "$ref": "#/definitions/dto.MessageResponse"
                         }
@@ -328,14 +363,26 @@ const docTemplate = `{
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden - videos:delete permission required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Video not found with provided ID",
+                        "description": "Author not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The author still has videos",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error while deleting video",
+                        "description": "Internal server error while deleting the author",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -343,9 +390,68 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/login": {
+        "/api/keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the caller's API keys, including revoked ones. Secrets are never returned. Requires JWT authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "API Keys"
+                ],
+                "summary": "List API keys",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The caller's API keys",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.APIKey"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Called with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while fetching keys",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
             "post": {
-                "description": "Authenticate user with username and password to receive a JWT token for accessing protected endpoints",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a long-lived API key for machine clients, sent in the X-API-Key header. The key is only shown in this response. Its scopes must be permissions the caller holds. Requires JWT authentication; API keys cannot manage API keys.",
                 "consumes": [
                     "application/json"
                 ],
@@ -353,35 +459,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "API Keys"
                 ],
-                "summary": "User Login",
+                "summary": "Create an API key",
                 "parameters": [
                     {
-                        "description": "User login credentials (username and password)",
-                        "name": "credentials",
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Name, scopes and lifetime of the key",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/entity.LoginCredentials"
+                            "$ref": "#/definitions/dto.APIKeyCreateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully authenticated, returns JWT token",
+                    "201": {
+                        "description": "Created key, including the secret",
                         "schema": {
-                            "$ref": "#/definitions/dto.LoginResponse"
+                            "$ref": "#/definitions/dto.APIKeyCreateResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request format or missing required fields",
+                        "description": "Invalid request format or validation errors",
                         "schema": {
                             "$ref": "#/definitions/dto.ValidationErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Authentication failed - invalid username or password",
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Scopes exceed the caller's permissions, or called with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while creating the key",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
@@ -389,74 +515,3775 @@ const docTemplate = `{
                 }
             }
         },
-        "/view/": {
-            "get": {
-                "description": "Display all videos in an HTML template for browser viewing. This endpoint is public and does not require authentication.",
-                "consumes": [
-                    "text/html"
+        "/api/keys/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Revoke one of the caller's API keys. Requests using it are rejected from then on. Requires JWT authentication.",
                 "produces": [
-                    "text/html"
+                    "application/json"
                 ],
                 "tags": [
-                    "Views"
+                    "API Keys"
+                ],
+                "summary": "Revoke an API key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "API key UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Show all videos (HTML view)",
                 "responses": {
                     "200": {
-                        "description": "HTML page displaying all videos",
+                        "description": "Key revoked",
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Called with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No active key with this ID belongs to the caller",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error while loading videos",
+                        "description": "Internal server error while revoking the key",
                         "schema": {
                             "$ref": "#/definitions/dto.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "dto.ErrorResponse": {
-            "type": "object",
-            "properties": {
-                "error": {
-                    "description": "Error message",
-                    "type": "string",
-                    "example": "Invalid credentials"
-                }
-            }
-        },
-        "dto.LoginResponse": {
-            "type": "object",
-            "properties": {
-                "token": {
-                    "description": "JWT token",
-                    "type": "string",
-                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
-                }
-            }
-        },
-        "dto.MessageResponse": {
-            "type": "object",
-            "properties": {
-                "message": {
-                    "description": "Success message",
-                    "type": "string",
-                    "example": "Video deleted successfully"
-                }
-            }
         },
-        "dto.ValidationErrorResponse": {
-            "type": "object",
-            "properties": {
-                "errors": {
-                    "description": "Validation errors as string",
-                    "type": "array",
-                    "items": {
+        "/api/me/mfa": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turn two-factor authentication off after checking a TOTP or recovery code. Accounts whose role requires MFA have to enroll again at their next login. Wrong codes count towards the login lockout of the user and the client. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "Turn MFA off",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "TOTP or recovery code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "MFA turned off",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or missing required fields",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized, or wrong code",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Called with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "MFA is not enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts for the user or from the client; see the Retry-After header",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until codes are accepted again"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while turning MFA off",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/mfa/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Confirm enrollment with a code from the authenticator app. Returns recovery codes, which are only shown once. Wrong codes count towards the login lockout of the user and the client. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "Turn MFA on",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "TOTP code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFACodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Recovery codes",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFAActivateResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or missing required fields",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized, or wrong code",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Called with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "MFA is already enabled or enrollment has not been started",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts for the user or from the client; see the Retry-After header",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until codes are accepted again"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while activating",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/mfa/enroll": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generate a TOTP secret for the caller. Render the provisioning URI as a QR code for an authenticator app, then confirm with /api/me/mfa/activate. Requires JWT authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MFA"
+                ],
+                "summary": "Start MFA enrollment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "TOTP secret and provisioning URI",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFAEnrollResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Called with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "MFA is already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while enrolling",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/oidc/link": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Start an OpenID Connect login that links the provider account to the caller instead of signing in. Open the returned URL in the browser that made this request; the provider returns to /auth/oidc/callback, which links the account and returns new tokens. Accounts with a password or MFA can only be linked this way. Requires JWT authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Link the identity provider to your account",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "URL of the identity provider's login page",
+                        "schema": {
+                            "$ref": "#/definitions/dto.OIDCLinkResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Called with an API key",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "OIDC login is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "The identity provider could not be reached",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/me/videos": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the videos created by the authenticated user. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Videos"
+                ],
+                "summary": "Get my videos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Videos created by the caller",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Video"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while fetching videos",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/playlists": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the playlists of a user, without their items. Without owner_id the caller's own playlists are listed; for other users only public playlists are listed. Requires the videos:read permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "List playlists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "List the playlists of this user",
+                        "name": "owner_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Playlists, newest first",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Playlist"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while fetching playlists",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create an empty playlist owned by the caller. Playlists are private unless another visibility is given. Requires the videos:write permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Create a playlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Playlist to create",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlaylistCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created playlist",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Playlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - videos:write permission required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while creating the playlist",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/playlists/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a playlist with its videos in order. Private playlists are only visible to their owner. Requires the videos:read permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Get a playlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Playlist UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Playlist with its items",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Playlist"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Playlist not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while fetching the playlist",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a playlist. The videos in it are not affected. Only the owner or a user with the videos:admin permission may delete it. Requires the videos:write permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Delete a playlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Playlist UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Playlist deleted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the playlist belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Playlist not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while deleting the playlist",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change the title, description or visibility of a playlist; omitted fields are kept. Only the owner or a user with the videos:admin permission may update it. Requires the videos:write permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Update a playlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Playlist UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlaylistUpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated playlist",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Playlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the playlist belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Playlist not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The playlist was changed at the same time",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while updating the playlist",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/playlists/{id}/items": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Insert a video at a zero-based position, or append it when no position is given. A video can only be in a playlist once. Requires the videos:write permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Add a video to a playlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Playlist UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Video to add",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlaylistItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Playlist with the new item",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Playlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the playlist belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Playlist or video not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "The video is already in the playlist",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while adding the video",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/playlists/{id}/items/{itemId}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Remove an item from a playlist. The video itself is not affected. Requires the videos:write permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Remove a video from a playlist",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Playlist UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Playlist item UUID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Playlist without the item",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Playlist"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the playlist belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Playlist or item not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while removing the item",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move an item to a zero-based position; positions past the end move it to the end. Requires the videos:write permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Playlists"
+                ],
+                "summary": "Move a playlist item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Playlist UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Playlist item UUID",
+                        "name": "itemId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New position",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.PlaylistMoveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Playlist in the new order",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Playlist"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the playlist belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Playlist or item not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while moving the item",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/roles": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the roles that can be granted and their permissions. Requires the users:admin permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List roles",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Roles with their permissions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.Role"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - users:admin permission required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while fetching roles",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/tags": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the tags on videos with the number of videos carrying each, most used first. Requires JWT authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Videos"
+                ],
+                "summary": "List tags",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Tags with usage counts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TagResponse"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while fetching tags",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trash": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the caller's deleted videos, most recently deleted first, with the time each will be purged. Users with the videos:admin permission see the deleted videos of all users. Requires the videos:read permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "List deleted videos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted videos",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dto.TrashItem"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while fetching the trash",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trash/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a video in the trash for good, along with its tags and playlist entries. This cannot be undone. The revision history is kept and ends with a purge revision. Only the creator of the video or a user with the videos:admin permission may purge it. Requires the videos:delete permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Purge a deleted video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Video UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Video purged",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No such video in the caller's trash",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while purging the video",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/trash/{id}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Take a video out of the trash. It shows up in lists, searches and playlists again. Only the creator of the video or a user with the videos:admin permission may restore it. Requires the videos:delete permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Trash"
+                ],
+                "summary": "Restore a deleted video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Video UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Restored video",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Video"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the video"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No such video in the caller's trash",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while restoring the video",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every account with its roles. Requires the users:admin permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users with their roles",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.User"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - users:admin permission required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while fetching users",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/roles": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace the roles granted to a user. Takes effect for access tokens issued afterwards. Requires the users:admin permission.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Set the roles of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Roles to grant",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.SetRolesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User with the new roles",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or unknown role",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - users:admin permission required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found with provided ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while updating roles",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/users/{id}/unlock": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lift a lockout caused by failed logins and forget the user's failed attempts. Lockouts of client IP addresses are not affected. Requires the users:admin permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Unlock a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "User UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User unlocked",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - users:admin permission required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found with provided ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while unlocking",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a page of videos with their author information. Pass next_cursor from a response as cursor to get the following page; cursors are only valid with the same sort and order. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Videos"
+                ],
+                "summary": "List videos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor from next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "updated_at",
+                            "title"
+                        ],
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Sort field",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort direction",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Only videos by this author",
+                        "name": "author_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only videos whose title contains this text, ignoring case",
+                        "name": "title",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Only videos created after this time",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "format": "date-time",
+                        "description": "Only videos created before this time",
+                        "name": "created_before",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only videos with these comma-separated tags",
+                        "name": "tag",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "any",
+                            "all"
+                        ],
+                        "type": "string",
+                        "default": "any",
+                        "description": "Whether videos need any or all of the tags",
+                        "name": "tag_match",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "A page of videos with author details",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VideoPageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid query parameters, cursor or tag",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while fetching videos",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new video entry with optional tags. Give author_id to credit an existing author, or an author object to create one or update the author with the same email. Tags are lowercased and created on first use. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Videos"
+                ],
+                "summary": "Create a new video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Video object with nested author information",
+                        "name": "video",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VideoCreateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully created video with generated ID",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Video"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format, validation errors, invalid tag or unknown author_id",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while saving video",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/search": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Full-text search over video titles and descriptions, best matches first. Every word must match; \"quoted words\" match as a phrase and a trailing * matches words starting with the given prefix, e.g. q=\"getting started\" gola*. Title matches rank above description matches. Requires JWT authentication.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Videos"
+                ],
+                "summary": "Search videos",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search query",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "minimum": 0,
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Number of hits to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Matching videos with scores and highlights",
+                        "schema": {
+                            "$ref": "#/definitions/dto.VideoSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing query or invalid parameters",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while searching",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve detailed information for a specific video by its unique ID. The ETag header holds the versions of the video and its author; send it as If-None-Match to get 304 while neither changed, or as If-Match to update or delete the video. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Videos"
+                ],
+                "summary": "Get video by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Video UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETags of versions the client has",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Video details with author information",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Video"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Versions of the video and its author"
+                            }
+                        }
+                    },
+                    "304": {
+                        "description": "The video and its author still have one of the If-None-Match versions"
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Video not found with provided ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while fetching video",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replace an existing video's information by its ID; use PATCH to change only some fields. All fields in the video object can be updated; tags are replaced when given and kept when omitted. The If-Match header must hold the ETag from GET, or * to overwrite any version. Only the creator of the video or a user with the videos:admin permission may update it. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Videos"
+                ],
+                "summary": "Update a video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Video UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Updated video object with new information",
+                        "name": "video",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.Video"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated video",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Video"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the video"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format, validation errors or invalid tag",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the video belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Video not found with provided ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "The video has been changed since the If-Match version",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while updating video",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Move a video to the trash by its ID. It can be restored from /api/trash until it is purged after the retention period. The If-Match header must hold the ETag from GET, or * to delete any version. Only the creator of the video or a user with the videos:admin permission may delete it. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Videos"
+                ],
+                "summary": "Delete a video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Video UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Video successfully deleted",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the video belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Video not found with provided ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "The video has been changed since the If-Match version",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while deleting video",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Change some fields of a video. The patch applies to the document {title, description, url, author_id, tags}: send an RFC 7396 merge patch as application/merge-patch+json (or application/json), or an RFC 6902 operation list as application/json-patch+json. The patched document is validated as a whole and only changed fields are saved. Authors are changed through author_id. The If-Match header must hold the ETag from GET, or * to patch any version. Only the creator of the video or a user with the videos:admin permission may patch it. Requires JWT authentication.",
+                "consumes": [
+                    "application/merge-patch+json",
+                    "application/json-patch+json",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Videos"
+                ],
+                "summary": "Partially update a video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Video UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Merge patch with the fields to change, or a list of JSON Patch operations on this document",
+                        "name": "patch",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.VideoPatchDocument"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Patched video",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Video"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the video"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed patch, patched document failing validation, invalid tag or unknown author_id",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the video belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Video not found with provided ID",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A JSON Patch test operation failed",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "The video has been changed since the If-Match version",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "415": {
+                        "description": "Unsupported patch content type",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while updating video",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{id}/revisions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every recorded change of a video, oldest first: who made it, when, the fields it changed and the metadata before and after. Revisions are numbered by the version the change gave the video. Requires the videos:read permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "List the revisions of a video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Video UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Revisions",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/entity.VideoRevision"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Video not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while fetching revisions",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{id}/revisions/diff": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the fields whose values differ between the video as it was after revision from and as it was after revision to. Requires the videos:read permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Compare two revisions of a video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Video UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "First revision",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "minimum": 1,
+                        "type": "integer",
+                        "description": "Second revision",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Changed fields",
+                        "schema": {
+                            "$ref": "#/definitions/dto.RevisionDiffResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing or invalid revision numbers",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Video or revision not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while comparing revisions",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{id}/revisions/{rev}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get one recorded change of a video. Requires the videos:read permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Get a revision of a video",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Video UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Revision",
+                        "schema": {
+                            "$ref": "#/definitions/entity.VideoRevision"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid revision number",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Video or revision not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while fetching the revision",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/videos/{id}/revisions/{rev}/restore": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set the title, description, URL, author and tags of a video back to how they were after the given revision. The rollback is recorded as a new revision; nothing changes if the video already matches. The If-Match header must hold the ETag from GET, or * to roll back any version. Only the creator of the video or a user with the videos:admin permission may roll it back. Requires the videos:write permission.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Revisions"
+                ],
+                "summary": "Roll a video back to a revision",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "format": "uuid",
+                        "description": "Video UUID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Revision number",
+                        "name": "rev",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag of the version being changed, or *",
+                        "name": "If-Match",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Video as of the revision",
+                        "schema": {
+                            "$ref": "#/definitions/entity.Video"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the video"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid revision number, or the revision's author no longer exists",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - the video belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Video or revision not found",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "The video has been changed since the If-Match version",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "If-Match header missing",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while rolling back the video",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/forgot-password": {
+            "post": {
+                "description": "Email a single-use password reset token. The response is the same whether or not the address is registered.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Request a password reset",
+                "parameters": [
+                    {
+                        "description": "Account email address",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ForgotPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Reset email sent if the address is registered",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while sending the email",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user with username and password to receive a JWT access token for accessing protected endpoints and a refresh token for /auth/refresh. Accounts with two-factor authentication instead receive an MFA challenge token to exchange at /auth/mfa/verify.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "User Login",
+                "parameters": [
+                    {
+                        "description": "User login credentials (username and password)",
+                        "name": "credentials",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/entity.LoginCredentials"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully authenticated, returns JWT access and refresh tokens, or an MFA challenge",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or missing required fields",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication failed - invalid username or password",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Email address has not been verified yet",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts for the username or from the client; see the Retry-After header",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until logins are accepted again"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while issuing tokens",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revoke the access token used for this request and, if given, the refresh token issued with it. Requires JWT authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "Bearer \u003ctoken\u003e",
+                        "description": "Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Refresh token to revoke",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LogoutRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Logged out",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - valid JWT token required, or refresh token does not belong to the user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while revoking tokens",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/enroll": {
+            "post": {
+                "description": "For accounts that must use two-factor authentication but have not set it up. Takes the MFA challenge token from /auth/login and returns a TOTP secret; the login is completed with a code at /auth/mfa/verify.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Set up MFA during login",
+                "parameters": [
+                    {
+                        "description": "Challenge token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFAChallengeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "TOTP secret and provisioning URI",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFAEnrollResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or missing required fields",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired challenge token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "MFA is already enabled",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while enrolling",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/mfa/verify": {
+            "post": {
+                "description": "Exchange the MFA challenge token from /auth/login and a TOTP or recovery code for JWT access and refresh tokens. For accounts that enrolled through /auth/mfa/enroll this also turns MFA on and returns the recovery codes. A challenge is void after 5 wrong codes, and wrong codes count towards the login lockout of the user and the client.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Complete a two-step login",
+                "parameters": [
+                    {
+                        "description": "Challenge token and code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.MFAVerifyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully authenticated, returns JWT access and refresh tokens",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or missing required fields",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired challenge token, or wrong code",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "MFA enrollment has not been started",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many failed attempts for the user or from the client; see the Retry-After header",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds until codes are accepted again"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while issuing tokens",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/callback": {
+            "get": {
+                "description": "Complete an OpenID Connect login. The provider account is linked to a local user, which is created on first login, and the API's own access and refresh tokens are returned. Accounts with two-factor authentication instead receive an MFA challenge token to exchange at /auth/mfa/verify, as with /auth/login. An existing account with a password or MFA is only linked after its owner starts the login at /api/me/oidc/link.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Identity provider callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "State set by /auth/oidc/login",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully authenticated, returns JWT access and refresh tokens, or an MFA challenge",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Login state is missing or does not match",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "The identity provider rejected the login, or the link request expired",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "OIDC login is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "An account with the email exists and has to be linked, or the provider account is linked to another user",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while signing in",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/oidc/login": {
+            "get": {
+                "description": "Start an OpenID Connect authorization code login with PKCE. Redirects the browser to the configured identity provider, which returns to /auth/oidc/callback.",
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Sign in with the identity provider",
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the identity provider"
+                    },
+                    "404": {
+                        "description": "OIDC login is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "The identity provider could not be reached",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Exchange a refresh token for a new access and refresh token pair. Each refresh token can be used once; reusing one revokes every token issued from the same login.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Refresh tokens",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RefreshRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "New access and refresh tokens",
+                        "schema": {
+                            "$ref": "#/definitions/dto.LoginResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or missing required fields",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid, expired, revoked or reused refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while issuing tokens",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/register": {
+            "post": {
+                "description": "Create a new account. A verification link is emailed to the given address and must be followed before the account can log in.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Register an account",
+                "parameters": [
+                    {
+                        "description": "Username, email and password",
+                        "name": "account",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.RegisterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Account created, verification email sent",
+                        "schema": {
+                            "$ref": "#/definitions/entity.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Username or email already registered",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while creating the account",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/reset-password": {
+            "post": {
+                "description": "Set a new password with the single-use token from the password reset email.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Reset a password",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dto.ResetPasswordRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password updated",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format or validation errors",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid, expired or already used token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while updating the password",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/verify": {
+            "get": {
+                "description": "Confirm the email address of an account with the single-use token from the verification email.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Verify an email address",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Verification token",
+                        "name": "token",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Email address verified",
+                        "schema": {
+                            "$ref": "#/definitions/dto.MessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ValidationErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid, expired or already used token",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while verifying the email",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/healthz": {
+            "get": {
+                "description": "Succeeds while the server process is running, including while it shuts down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "The server is running",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/readyz": {
+            "get": {
+                "description": "Succeeds while the server accepts new requests. It fails as soon as the server starts shutting down, so that load balancers stop sending requests before connections are closed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Health"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "The server accepts requests",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "The server is shutting down",
+                        "schema": {
+                            "$ref": "#/definitions/dto.HealthResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/view/": {
+            "get": {
+                "description": "Display all videos in an HTML template for browser viewing. This endpoint is public and does not require authentication.",
+                "consumes": [
+                    "text/html"
+                ],
+                "produces": [
+                    "text/html"
+                ],
+                "tags": [
+                    "Views"
+                ],
+                "summary": "Show all videos (HTML view)",
+                "responses": {
+                    "200": {
+                        "description": "HTML page displaying all videos",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error while loading videos",
+                        "schema": {
+                            "$ref": "#/definitions/dto.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dto.APIKeyCreateRequest": {
+            "type": "object",
+            "required": [
+                "name",
+                "scopes"
+            ],
+            "properties": {
+                "expires_in_days": {
+                    "description": "Lifetime in days, 0 for keys that do not expire",
+                    "type": "integer",
+                    "maximum": 3650,
+                    "minimum": 0,
+                    "example": 90
+                },
+                "name": {
+                    "description": "Human readable label",
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "CI ingestion"
+                },
+                "scopes": {
+                    "description": "Permissions granted to the key, a subset of the caller's",
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "videos:read",
+                        "videos:write"
+                    ]
+                }
+            }
+        },
+        "dto.APIKeyCreateResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "Expiry, if any",
+                    "type": "string",
+                    "example": "2030-01-01T00:00:00Z"
+                },
+                "id": {
+                    "description": "API key ID",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174003"
+                },
+                "key": {
+                    "description": "The full key; it cannot be retrieved again",
+                    "type": "string",
+                    "example": "gk_7hq2m4xk_5mB2y8wq..."
+                },
+                "name": {
+                    "description": "Human readable label",
+                    "type": "string",
+                    "example": "CI ingestion"
+                },
+                "prefix": {
+                    "description": "Public part of the key",
+                    "type": "string",
+                    "example": "gk_7hq2m4xk"
+                },
+                "scopes": {
+                    "description": "Permissions the key may use",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "videos:read",
+                        "videos:write"
+                    ]
+                }
+            }
+        },
+        "dto.AuthorRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "name"
+            ],
+            "properties": {
+                "age": {
+                    "type": "integer",
+                    "maximum": 120,
+                    "minimum": 0,
+                    "example": 30
+                },
+                "email": {
+                    "description": "Unique, compared without case",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "john.doe@example.com"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 2,
+                    "example": "John Doe"
+                }
+            }
+        },
+        "dto.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error message",
+                    "type": "string",
+                    "example": "Invalid credentials"
+                }
+            }
+        },
+        "dto.ForgotPasswordRequest": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Account email address",
+                    "type": "string",
+                    "example": "john.doe@example.com"
+                }
+            }
+        },
+        "dto.HealthResponse": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "description": "ok, or draining while the server shuts down",
+                    "type": "string",
+                    "example": "ok"
+                }
+            }
+        },
+        "dto.JSONWebKey": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "description": "Signing algorithm",
+                    "type": "string",
+                    "example": "RS256"
+                },
+                "crv": {
+                    "description": "Curve of EC and OKP keys",
+                    "type": "string",
+                    "example": "P-256"
+                },
+                "e": {
+                    "description": "RSA exponent",
+                    "type": "string",
+                    "example": "AQAB"
+                },
+                "kid": {
+                    "description": "Key ID, matches the kid header of tokens",
+                    "type": "string",
+                    "example": "3kR9x..."
+                },
+                "kty": {
+                    "description": "Key type (RSA, EC or OKP)",
+                    "type": "string",
+                    "example": "RSA"
+                },
+                "n": {
+                    "description": "RSA modulus",
+                    "type": "string",
+                    "example": "0vx7ago..."
+                },
+                "use": {
+                    "description": "Public key use",
+                    "type": "string",
+                    "example": "sig"
+                },
+                "x": {
+                    "description": "X coordinate of EC keys, public key of OKP keys",
+                    "type": "string"
+                },
+                "y": {
+                    "description": "Y coordinate of EC keys",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.JSONWebKeySet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "description": "Active verification keys",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.JSONWebKey"
+                    }
+                }
+            }
+        },
+        "dto.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "mfa_enrollment_required": {
+                    "description": "MFA is mandatory but not set up yet; enroll at /auth/mfa/enroll first",
+                    "type": "boolean",
+                    "example": false
+                },
+                "mfa_required": {
+                    "description": "A code has to be sent to /auth/mfa/verify",
+                    "type": "boolean",
+                    "example": false
+                },
+                "mfa_token": {
+                    "description": "Short-lived challenge token for /auth/mfa/verify",
+                    "type": "string"
+                },
+                "recovery_codes": {
+                    "description": "Recovery codes, returned once when MFA is set up during login",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "refresh_token": {
+                    "description": "Single-use token for /auth/refresh",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                },
+                "token": {
+                    "description": "JWT access token",
+                    "type": "string",
+                    "example": "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
+                }
+            }
+        },
+        "dto.LogoutRequest": {
+            "type": "object",
+            "properties": {
+                "refresh_token": {
+                    "description": "Refresh token to revoke along with the access token",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MFAActivateResponse": {
+            "type": "object",
+            "properties": {
+                "recovery_codes": {
+                    "description": "Single-use codes for when the authenticator is lost",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "k3j2h-x8f7d"
+                    ]
+                }
+            }
+        },
+        "dto.MFAChallengeRequest": {
+            "type": "object",
+            "required": [
+                "mfa_token"
+            ],
+            "properties": {
+                "mfa_token": {
+                    "description": "Challenge token returned by /auth/login",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MFACodeRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "description": "TOTP code, or a recovery code when turning MFA off",
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "123456"
+                }
+            }
+        },
+        "dto.MFAEnrollResponse": {
+            "type": "object",
+            "properties": {
+                "provisioning_uri": {
+                    "description": "URI to render as a QR code",
+                    "type": "string",
+                    "example": "otpauth://totp/Video%20Management%20API:jdoe?secret=JBSWY3DPEHPK3PXP"
+                },
+                "secret": {
+                    "description": "Base32 secret for manual entry",
+                    "type": "string",
+                    "example": "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+                }
+            }
+        },
+        "dto.MFAVerifyRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "mfa_token"
+            ],
+            "properties": {
+                "code": {
+                    "description": "TOTP code or recovery code",
+                    "type": "string",
+                    "maxLength": 32,
+                    "example": "123456"
+                },
+                "mfa_token": {
+                    "description": "Challenge token returned by /auth/login",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.MessageResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "Success message",
+                    "type": "string",
+                    "example": "Video deleted successfully"
+                }
+            }
+        },
+        "dto.OIDCLinkResponse": {
+            "type": "object",
+            "properties": {
+                "auth_url": {
+                    "description": "URL of the provider's login page",
+                    "type": "string",
+                    "example": "https://idp.example.com/authorize?client_id=video-api\u0026state=..."
+                }
+            }
+        },
+        "dto.PlaylistCreateRequest": {
+            "type": "object",
+            "required": [
+                "title"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Start here"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Go fundamentals"
+                },
+                "visibility": {
+                    "description": "Defaults to private",
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "private"
+                    ],
+                    "example": "public"
+                }
+            }
+        },
+        "dto.PlaylistItemRequest": {
+            "type": "object",
+            "required": [
+                "video_id"
+            ],
+            "properties": {
+                "position": {
+                    "description": "Zero-based index to insert at, the end when omitted",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 0
+                },
+                "video_id": {
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174001"
+                }
+            }
+        },
+        "dto.PlaylistMoveRequest": {
+            "type": "object",
+            "required": [
+                "position"
+            ],
+            "properties": {
+                "position": {
+                    "description": "Zero-based index the item should end up at",
+                    "type": "integer",
+                    "minimum": 0,
+                    "example": 2
+                }
+            }
+        },
+        "dto.PlaylistUpdateRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Start here"
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 1,
+                    "example": "Go fundamentals"
+                },
+                "visibility": {
+                    "type": "string",
+                    "enum": [
+                        "public",
+                        "unlisted",
+                        "private"
+                    ],
+                    "example": "unlisted"
+                }
+            }
+        },
+        "dto.RefreshRequest": {
+            "type": "object",
+            "required": [
+                "refresh_token"
+            ],
+            "properties": {
+                "refresh_token": {
+                    "description": "Refresh token from login or a previous refresh",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RegisterRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password",
+                "username"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Email address, must be verified before login",
+                    "type": "string",
+                    "maxLength": 255,
+                    "example": "john.doe@example.com"
+                },
+                "password": {
+                    "description": "Password (8-72 characters)",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "correct-horse"
+                },
+                "username": {
+                    "description": "Username (3-50 letters or digits)",
+                    "type": "string",
+                    "maxLength": 50,
+                    "minLength": 3,
+                    "example": "jdoe"
+                }
+            }
+        },
+        "dto.ResetPasswordRequest": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "description": "New password (8-72 characters)",
+                    "type": "string",
+                    "maxLength": 72,
+                    "minLength": 8,
+                    "example": "correct-horse"
+                },
+                "token": {
+                    "description": "Token from the password reset email",
+                    "type": "string"
+                }
+            }
+        },
+        "dto.RevisionDiffResponse": {
+            "type": "object",
+            "properties": {
+                "changes": {
+                    "description": "Fields that differ, empty if none",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.RevisionFieldChange"
+                    }
+                },
+                "from": {
+                    "description": "First revision",
+                    "type": "integer",
+                    "example": 1
+                },
+                "to": {
+                    "description": "Second revision",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "dto.RevisionFieldChange": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "title, description, url, author_id or tags",
+                    "type": "string",
+                    "example": "title"
+                },
+                "from": {
+                    "description": "Value after the first revision",
+                    "type": "string",
+                    "example": "Old title"
+                },
+                "to": {
+                    "description": "Value after the second revision",
+                    "type": "string",
+                    "example": "New title"
+                }
+            }
+        },
+        "dto.SetRolesRequest": {
+            "type": "object",
+            "required": [
+                "roles"
+            ],
+            "properties": {
+                "roles": {
+                    "description": "Names of the roles to grant",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "member"
+                    ]
+                }
+            }
+        },
+        "dto.TagResponse": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "description": "Number of videos with the tag",
+                    "type": "integer",
+                    "example": 12
+                },
+                "name": {
+                    "description": "Tag name",
+                    "type": "string",
+                    "example": "golang"
+                }
+            }
+        },
+        "dto.TrashItem": {
+            "type": "object",
+            "properties": {
+                "deleted_at": {
+                    "description": "When the video was deleted",
+                    "type": "string",
+                    "example": "2024-01-01T12:00:00Z"
+                },
+                "purge_at": {
+                    "description": "When the video will be deleted for good",
+                    "type": "string",
+                    "example": "2024-01-31T12:00:00Z"
+                },
+                "video": {
+                    "description": "Deleted video",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Video"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.ValidationErrorResponse": {
+            "type": "object",
+            "properties": {
+                "errors": {
+                    "description": "Validation errors as string",
+                    "type": "array",
+                    "items": {
                         "$ref": "#/definitions/utils.ValidationError"
                     }
                 }
@@ -465,18 +4292,100 @@ const docTemplate = `{
         "dto.VideoCreateRequest": {
             "type": "object",
             "required": [
-                "author",
                 "url"
             ],
             "properties": {
                 "author": {
-                    "$ref": "#/definitions/entity.Person"
+                    "description": "Author to create, or to update if one with the same email exists",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Person"
+                        }
+                    ]
+                },
+                "author_id": {
+                    "description": "Existing author; takes precedence over author",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "description": {
+                    "type": "string",
+                    "maxLength": 500,
+                    "example": "Learn Golang basics"
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "golang",
+                        "tutorial"
+                    ]
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 100,
+                    "minLength": 3,
+                    "example": "Introduction to Golang"
+                },
+                "url": {
+                    "type": "string",
+                    "example": "https://www.youtube.com/watch?v=abc"
+                }
+            }
+        },
+        "dto.VideoPageResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Videos on this page",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Video"
+                    }
+                },
+                "next_cursor": {
+                    "description": "Cursor of the next page, absent on the last page",
+                    "type": "string",
+                    "example": "eyJzIjoiY3JlYXRlZF9hdCJ9"
+                },
+                "total_count": {
+                    "description": "Number of videos matching the filters",
+                    "type": "integer",
+                    "example": 42
+                }
+            }
+        },
+        "dto.VideoPatchDocument": {
+            "type": "object",
+            "required": [
+                "author_id",
+                "url"
+            ],
+            "properties": {
+                "author_id": {
+                    "description": "Existing author; see /api/authors",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
                 },
                 "description": {
                     "type": "string",
                     "maxLength": 500,
                     "example": "Learn Golang basics"
                 },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "golang",
+                        "tutorial"
+                    ]
+                },
                 "title": {
                     "type": "string",
                     "maxLength": 100,
@@ -489,6 +4398,87 @@ const docTemplate = `{
                 }
             }
         },
+        "dto.VideoSearchHit": {
+            "type": "object",
+            "properties": {
+                "score": {
+                    "description": "Relevance, higher is better; only comparable within one search",
+                    "type": "number",
+                    "example": 12.5
+                },
+                "snippet": {
+                    "description": "HTML-escaped excerpt of the description with matches wrapped in \u003cmark\u003e tags",
+                    "type": "string",
+                    "example": "Learn \u003cmark\u003eGolang\u003c/mark\u003e basics"
+                },
+                "title_highlight": {
+                    "description": "HTML-escaped title with matches wrapped in \u003cmark\u003e tags",
+                    "type": "string",
+                    "example": "Introduction to \u003cmark\u003eGolang\u003c/mark\u003e"
+                },
+                "video": {
+                    "description": "Matching video",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Video"
+                        }
+                    ]
+                }
+            }
+        },
+        "dto.VideoSearchResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "description": "Hits on this page, best first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dto.VideoSearchHit"
+                    }
+                },
+                "total_count": {
+                    "description": "Number of hits on all pages",
+                    "type": "integer",
+                    "example": 3
+                }
+            }
+        },
+        "entity.APIKey": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "description": "Expiry, if any",
+                    "type": "string",
+                    "example": "2030-01-01T00:00:00Z"
+                },
+                "id": {
+                    "description": "API key ID",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174003"
+                },
+                "name": {
+                    "description": "Human readable label",
+                    "type": "string",
+                    "example": "CI ingestion"
+                },
+                "prefix": {
+                    "description": "Public part of the key",
+                    "type": "string",
+                    "example": "gk_7hq2m4xk"
+                },
+                "scopes": {
+                    "description": "Permissions the key may use",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "videos:read",
+                        "videos:write"
+                    ]
+                }
+            }
+        },
         "entity.LoginCredentials": {
             "type": "object",
             "properties": {
@@ -519,7 +4509,7 @@ const docTemplate = `{
                     "example": 30
                 },
                 "email": {
-                    "description": "Person email",
+                    "description": "Person email, unique and stored in lowercase",
                     "type": "string",
                     "example": "john.doe@example.com"
                 },
@@ -532,6 +4522,115 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.Playlist": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Playlist description",
+                    "type": "string",
+                    "example": "Start here"
+                },
+                "id": {
+                    "description": "Playlist ID",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174004"
+                },
+                "items": {
+                    "description": "Entries in playlist order",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.PlaylistItem"
+                    }
+                },
+                "owner_id": {
+                    "description": "User who created the playlist",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174002"
+                },
+                "title": {
+                    "description": "Playlist title",
+                    "type": "string",
+                    "example": "Go fundamentals"
+                },
+                "visibility": {
+                    "description": "public, unlisted or private",
+                    "type": "string",
+                    "example": "public"
+                }
+            }
+        },
+        "entity.PlaylistItem": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "Item ID",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174005"
+                },
+                "video": {
+                    "description": "Video in the playlist",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.Video"
+                        }
+                    ]
+                }
+            }
+        },
+        "entity.Role": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "What the role is for",
+                    "type": "string",
+                    "example": "Can create videos and manage their own"
+                },
+                "name": {
+                    "description": "Role name",
+                    "type": "string",
+                    "example": "member"
+                },
+                "permissions": {
+                    "description": "Granted permissions",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "videos:read",
+                        "videos:write",
+                        "videos:delete"
+                    ]
+                }
+            }
+        },
+        "entity.User": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "description": "Unique email, empty for bootstrapped accounts",
+                    "type": "string",
+                    "example": "admin@example.com"
+                },
+                "id": {
+                    "description": "User ID",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174002"
+                },
+                "roles": {
+                    "description": "Granted roles",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/entity.Role"
+                    }
+                },
+                "username": {
+                    "description": "Unique username",
+                    "type": "string",
+                    "example": "admin"
+                }
+            }
+        },
         "entity.Video": {
             "type": "object",
             "required": [
@@ -553,6 +4652,17 @@ const docTemplate = `{
                     "maxLength": 500,
                     "example": "Learn Golang basics"
                 },
+                "tags": {
+                    "description": "Tags; omit in updates to keep the current ones",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "golang",
+                        "tutorial"
+                    ]
+                },
                 "title": {
                     "description": "Video title (3-100 characters)",
                     "type": "string",
@@ -567,6 +4677,109 @@ const docTemplate = `{
                 }
             }
         },
+        "entity.VideoRevision": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "create, update, delete, restore, rollback or purge",
+                    "type": "string",
+                    "example": "update"
+                },
+                "actor_id": {
+                    "description": "User who made the change",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174002"
+                },
+                "actor_name": {
+                    "description": "Username of the user who made the change",
+                    "type": "string",
+                    "example": "alice"
+                },
+                "after": {
+                    "description": "Metadata after the change",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.VideoSnapshot"
+                        }
+                    ]
+                },
+                "before": {
+                    "description": "Metadata before the change, null for creations",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/entity.VideoSnapshot"
+                        }
+                    ]
+                },
+                "changed_fields": {
+                    "description": "Metadata fields the change modified",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "title",
+                        "tags"
+                    ]
+                },
+                "created_at": {
+                    "description": "When the change was made",
+                    "type": "string",
+                    "example": "2024-01-01T00:00:00Z"
+                },
+                "number": {
+                    "description": "The video's version after the change",
+                    "type": "integer",
+                    "example": 3
+                },
+                "restored_from": {
+                    "description": "Revision a rollback returned to",
+                    "type": "integer",
+                    "example": 1
+                },
+                "video_id": {
+                    "description": "Video the revision belongs to",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174001"
+                }
+            }
+        },
+        "entity.VideoSnapshot": {
+            "type": "object",
+            "properties": {
+                "author_id": {
+                    "description": "ID of the video's author",
+                    "type": "string",
+                    "example": "123e4567-e89b-12d3-a456-426614174000"
+                },
+                "description": {
+                    "description": "Video description",
+                    "type": "string",
+                    "example": "Learn Golang basics"
+                },
+                "tags": {
+                    "description": "Tag names in alphabetical order",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    },
+                    "example": [
+                        "golang",
+                        "tutorial"
+                    ]
+                },
+                "title": {
+                    "description": "Video title",
+                    "type": "string",
+                    "example": "Introduction to Golang"
+                },
+                "url": {
+                    "description": "Video URL",
+                    "type": "string",
+                    "example": "https://www.youtube.com/watch?v=abc"
+                }
+            }
+        },
         "utils.ValidationError": {
             "type": "object",
             "properties": {
@@ -582,6 +4795,12 @@ const docTemplate = `{
         }
     },
     "securityDefinitions": {
+        "ApiKeyAuth": {
+            "description": "API key created through /api/keys. Accepted by every /api endpoint except key management.",
+            "type": "apiKey",
+            "name": "X-API-Key",
+            "in": "header"
+        },
         "BearerAuth": {
             "description": "Type \"Bearer\" followed by a space and JWT token. Example: \"Bearer eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9...\"",
             "type": "apiKey",
@@ -0,0 +1,34 @@
+package dto
+
+// RegisterRequest represents the payload to create an account
+type RegisterRequest struct {
+	Username string `json:"username" binding:"required,alphanum,min=3,max=50" example:"jdoe"`      // Username (3-50 letters or digits)
+	Email    string `json:"email" binding:"required,email,max=255" example:"john.doe@example.com"` // Email address, must be verified before login
+	Password string `json:"password" binding:"required,min=8,max=72" example:"correct-horse"`      // Password (8-72 characters)
+}
+
+// VerifyEmailRequest represents the payload to confirm an email address
+type VerifyEmailRequest struct {
+	Token string `json:"token" form:"token" binding:"required"` // Token from the verification email
+}
+
+// ForgotPasswordRequest represents the payload to request a password reset email
+type ForgotPasswordRequest struct {
+	Email string `json:"email" binding:"required,email" example:"john.doe@example.com"` // Account email address
+}
+
+// ResetPasswordRequest represents the payload to set a new password
+type ResetPasswordRequest struct {
+	Token    string `json:"token" binding:"required"`                                         // Token from the password reset email
+	Password string `json:"password" binding:"required,min=8,max=72" example:"correct-horse"` // New password (8-72 characters)
+}
+
+// RefreshRequest represents the payload to rotate a refresh token
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token" binding:"required"` // Refresh token from login or a previous refresh
+}
+
+// LogoutRequest represents the optional payload of a logout
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token"` // Refresh token to revoke along with the access token
+}
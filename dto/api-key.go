@@ -0,0 +1,16 @@
+package dto
+
+import "github.com/muzammil-cyber/golang-gin/entity"
+
+// APIKeyCreateRequest represents the payload to create an API key
+type APIKeyCreateRequest struct {
+	Name          string   `json:"name" binding:"required,min=1,max=100" example:"CI ingestion"`       // Human readable label
+	Scopes        []string `json:"scopes" binding:"required,min=1" example:"videos:read,videos:write"` // Permissions granted to the key, a subset of the caller's
+	ExpiresInDays int      `json:"expires_in_days" binding:"gte=0,lte=3650" example:"90"`              // Lifetime in days, 0 for keys that do not expire
+}
+
+// APIKeyCreateResponse is returned once, when the key is created
+type APIKeyCreateResponse struct {
+	Key string `json:"key" example:"gk_7hq2m4xk_5mB2y8wq..."` // The full key; it cannot be retrieved again
+	entity.APIKey
+}
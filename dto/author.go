@@ -0,0 +1,8 @@
+package dto
+
+// AuthorRequest represents the payload to create or update an author
+type AuthorRequest struct {
+	Name  string `json:"name" binding:"required,min=2,max=50" example:"John Doe"`
+	Age   int    `json:"age" binding:"gte=0,lte=120" example:"30"`
+	Email string `json:"email" binding:"required,email,max=255" example:"john.doe@example.com"` // Unique, compared without case
+}
@@ -0,0 +1,6 @@
+package dto
+
+// HealthResponse reports whether the server is up or ready for traffic
+type HealthResponse struct {
+	Status string `json:"status" example:"ok"` // ok, or draining while the server shuts down
+}
@@ -0,0 +1,19 @@
+package dto
+
+// JSONWebKey is a public key in RFC 7517 format
+type JSONWebKey struct {
+	Kty string `json:"kty" example:"RSA"`                // Key type (RSA, EC or OKP)
+	Kid string `json:"kid" example:"3kR9x..."`           // Key ID, matches the kid header of tokens
+	Use string `json:"use" example:"sig"`                // Public key use
+	Alg string `json:"alg" example:"RS256"`              // Signing algorithm
+	N   string `json:"n,omitempty" example:"0vx7ago..."` // RSA modulus
+	E   string `json:"e,omitempty" example:"AQAB"`       // RSA exponent
+	Crv string `json:"crv,omitempty" example:"P-256"`    // Curve of EC and OKP keys
+	X   string `json:"x,omitempty"`                      // X coordinate of EC keys, public key of OKP keys
+	Y   string `json:"y,omitempty"`                      // Y coordinate of EC keys
+}
+
+// JSONWebKeySet represents the keys that verify the tokens issued by the API
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"` // Active verification keys
+}
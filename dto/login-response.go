@@ -2,9 +2,15 @@ package dto
 
 import "github.com/muzammil-cyber/golang-gin/utils"
 
-// LoginResponse represents the login response with JWT token
+// LoginResponse represents the login response with JWT token. When a second
+// factor is required only the MFA fields are set.
 type LoginResponse struct {
-	Token string `json:"token" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // JWT token
+	Token                 string   `json:"token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."`         // JWT access token
+	RefreshToken          string   `json:"refresh_token,omitempty" example:"eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."` // Single-use token for /auth/refresh
+	MFARequired           bool     `json:"mfa_required,omitempty" example:"false"`                                    // A code has to be sent to /auth/mfa/verify
+	MFAEnrollmentRequired bool     `json:"mfa_enrollment_required,omitempty" example:"false"`                         // MFA is mandatory but not set up yet; enroll at /auth/mfa/enroll first
+	MFAToken              string   `json:"mfa_token,omitempty"`                                                       // Short-lived challenge token for /auth/mfa/verify
+	RecoveryCodes         []string `json:"recovery_codes,omitempty"`                                                  // Recovery codes, returned once when MFA is set up during login
 }
 
 // ErrorResponse represents an error response
@@ -0,0 +1,28 @@
+package dto
+
+// MFAChallengeRequest represents the payload to enroll during login
+type MFAChallengeRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"` // Challenge token returned by /auth/login
+}
+
+// MFAVerifyRequest represents the payload to complete a two-step login
+type MFAVerifyRequest struct {
+	MFAToken string `json:"mfa_token" binding:"required"`                    // Challenge token returned by /auth/login
+	Code     string `json:"code" binding:"required,max=32" example:"123456"` // TOTP code or recovery code
+}
+
+// MFACodeRequest represents the payload to confirm or turn off two-factor authentication
+type MFACodeRequest struct {
+	Code string `json:"code" binding:"required,max=32" example:"123456"` // TOTP code, or a recovery code when turning MFA off
+}
+
+// MFAEnrollResponse carries a new TOTP secret for an authenticator app
+type MFAEnrollResponse struct {
+	Secret          string `json:"secret" example:"JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"`                                               // Base32 secret for manual entry
+	ProvisioningURI string `json:"provisioning_uri" example:"otpauth://totp/Video%20Management%20API:jdoe?secret=JBSWY3DPEHPK3PXP"` // URI to render as a QR code
+}
+
+// MFAActivateResponse carries the recovery codes, which are shown only once
+type MFAActivateResponse struct {
+	RecoveryCodes []string `json:"recovery_codes" example:"k3j2h-x8f7d"` // Single-use codes for when the authenticator is lost
+}
@@ -0,0 +1,6 @@
+package dto
+
+// OIDCLinkResponse carries the identity provider URL to open to link an account
+type OIDCLinkResponse struct {
+	AuthURL string `json:"auth_url" example:"https://idp.example.com/authorize?client_id=video-api&state=..."` // URL of the provider's login page
+}
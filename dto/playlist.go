@@ -0,0 +1,27 @@
+package dto
+
+// PlaylistCreateRequest represents the payload to create a playlist
+type PlaylistCreateRequest struct {
+	Title       string `json:"title" binding:"required,min=1,max=100" example:"Go fundamentals"`
+	Description string `json:"description" binding:"max=500" example:"Start here"`
+	Visibility  string `json:"visibility" binding:"omitempty,oneof=public unlisted private" example:"public"` // Defaults to private
+}
+
+// PlaylistUpdateRequest represents changes to a playlist; omitted fields
+// are kept
+type PlaylistUpdateRequest struct {
+	Title       *string `json:"title" binding:"omitempty,min=1,max=100" example:"Go fundamentals"`
+	Description *string `json:"description" binding:"omitempty,max=500" example:"Start here"`
+	Visibility  *string `json:"visibility" binding:"omitempty,oneof=public unlisted private" example:"unlisted"`
+}
+
+// PlaylistItemRequest represents a video to add to a playlist
+type PlaylistItemRequest struct {
+	VideoID  string `json:"video_id" binding:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174001"`
+	Position *int   `json:"position" binding:"omitempty,min=0" example:"0"` // Zero-based index to insert at, the end when omitted
+}
+
+// PlaylistMoveRequest represents the new place of a playlist item
+type PlaylistMoveRequest struct {
+	Position *int `json:"position" binding:"required,min=0" example:"2"` // Zero-based index the item should end up at
+}
@@ -0,0 +1,7 @@
+package dto
+
+// TagResponse represents a tag and how many videos carry it
+type TagResponse struct {
+	Name  string `json:"name" example:"golang"` // Tag name
+	Count int64  `json:"count" example:"12"`    // Number of videos with the tag
+}
@@ -0,0 +1,6 @@
+package dto
+
+// SetRolesRequest represents the payload to replace the roles of a user
+type SetRolesRequest struct {
+	Roles []string `json:"roles" binding:"required" example:"member"` // Names of the roles to grant
+}
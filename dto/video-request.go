@@ -1,12 +1,55 @@
 package dto
 
-import "github.com/muzammil-cyber/golang-gin/entity"
+import (
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/entity"
+)
 
 // VideoCreateRequest represents the payload to create a video
 // IDs and timestamps are omitted; they are generated server-side.
 type VideoCreateRequest struct {
-	Title       string        `json:"title" binding:"min=3,max=100" example:"Introduction to Golang"`
-	Description string        `json:"description" binding:"max=500" example:"Learn Golang basics"`
-	URL         string        `json:"url" binding:"required,url" example:"https://www.youtube.com/watch?v=abc"`
-	Author      entity.Person `json:"author" binding:"required"`
+	Title       string         `json:"title" binding:"min=3,max=100" example:"Introduction to Golang"`
+	Description string         `json:"description" binding:"max=500" example:"Learn Golang basics"`
+	URL         string         `json:"url" binding:"required,url" example:"https://www.youtube.com/watch?v=abc"`
+	AuthorID    string         `json:"author_id" binding:"omitempty,uuid" example:"123e4567-e89b-12d3-a456-426614174000"` // Existing author; takes precedence over author
+	Author      *entity.Person `json:"author" binding:"required_without=AuthorID"`                                        // Author to create, or to update if one with the same email exists
+	Tags        []string       `json:"tags" binding:"max=20" example:"golang,tutorial"`
+}
+
+// VideoPatchDocument represents the fields of a video that PATCH requests
+// change. Merge patches and JSON Patch operations apply to this document.
+type VideoPatchDocument struct {
+	Title       string   `json:"title" binding:"min=3,max=100" example:"Introduction to Golang"`
+	Description string   `json:"description" binding:"max=500" example:"Learn Golang basics"`
+	URL         string   `json:"url" binding:"required,url" example:"https://www.youtube.com/watch?v=abc"`
+	AuthorID    string   `json:"author_id" binding:"required,uuid" example:"123e4567-e89b-12d3-a456-426614174000"` // Existing author; see /api/authors
+	Tags        []string `json:"tags" binding:"max=20" example:"golang,tutorial"`
+}
+
+// RevisionDiffQuery represents the revisions to compare
+type RevisionDiffQuery struct {
+	From int64 `form:"from" binding:"required,min=1"` // First revision
+	To   int64 `form:"to" binding:"required,min=1"`   // Second revision
+}
+
+// VideoListQuery represents the query parameters for listing videos
+type VideoListQuery struct {
+	Limit         int        `form:"limit" binding:"omitempty,min=1,max=100"`                    // Page size (1-100, default 20)
+	Cursor        string     `form:"cursor"`                                                     // next_cursor of the previous page
+	Sort          string     `form:"sort" binding:"omitempty,oneof=created_at updated_at title"` // Sort field
+	Order         string     `form:"order" binding:"omitempty,oneof=asc desc"`                   // Sort direction
+	AuthorID      string     `form:"author_id" binding:"omitempty,uuid"`                         // Only videos by this author
+	Title         string     `form:"title" binding:"omitempty,max=100"`                          // Only videos whose title contains this text
+	CreatedAfter  *time.Time `form:"created_after" time_format:"2006-01-02T15:04:05Z07:00"`      // Only videos created after this RFC 3339 time
+	CreatedBefore *time.Time `form:"created_before" time_format:"2006-01-02T15:04:05Z07:00"`     // Only videos created before this RFC 3339 time
+	Tag           string     `form:"tag" binding:"omitempty,max=1000"`                           // Comma-separated tags
+	TagMatch      string     `form:"tag_match" binding:"omitempty,oneof=any all"`                // Whether videos need any or all of the tags
+}
+
+// VideoSearchQuery represents the query parameters for searching videos
+type VideoSearchQuery struct {
+	Q      string `form:"q" binding:"required,max=200"`            // Search words; "quoted words" match a phrase, a trailing * a prefix
+	Limit  int    `form:"limit" binding:"omitempty,min=1,max=100"` // Page size (1-100, default 20)
+	Offset int    `form:"offset" binding:"omitempty,min=0"`        // Number of hits to skip
 }
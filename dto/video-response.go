@@ -1,6 +1,10 @@
 package dto
 
-import "github.com/muzammil-cyber/golang-gin/entity"
+import (
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/entity"
+)
 
 // VideoResponse represents a single video response
 type VideoResponse struct {
@@ -12,7 +16,50 @@ type VideosResponse struct {
 	Videos []entity.Video `json:"videos"` // List of videos
 }
 
+// VideoPageResponse represents one page of videos
+type VideoPageResponse struct {
+	Items      []entity.Video `json:"items"`                                                    // Videos on this page
+	NextCursor string         `json:"next_cursor,omitempty" example:"eyJzIjoiY3JlYXRlZF9hdCJ9"` // Cursor of the next page, absent on the last page
+	TotalCount int64          `json:"total_count" example:"42"`                                 // Number of videos matching the filters
+}
+
 // MessageResponse represents a success message response
 type MessageResponse struct {
 	Message string `json:"message" example:"Video deleted successfully"` // Success message
 }
+
+// VideoSearchHit represents a video matching a search
+type VideoSearchHit struct {
+	Video          entity.Video `json:"video"`                                                         // Matching video
+	Score          float64      `json:"score" example:"12.5"`                                          // Relevance, higher is better; only comparable within one search
+	TitleHighlight string       `json:"title_highlight" example:"Introduction to <mark>Golang</mark>"` // HTML-escaped title with matches wrapped in <mark> tags
+	Snippet        string       `json:"snippet" example:"Learn <mark>Golang</mark> basics"`            // HTML-escaped excerpt of the description with matches wrapped in <mark> tags
+}
+
+// VideoSearchResponse represents one page of search hits
+type VideoSearchResponse struct {
+	Items      []VideoSearchHit `json:"items"`                   // Hits on this page, best first
+	TotalCount int64            `json:"total_count" example:"3"` // Number of hits on all pages
+}
+
+// TrashItem represents a deleted video in the trash
+type TrashItem struct {
+	Video     entity.Video `json:"video"`                                     // Deleted video
+	DeletedAt time.Time    `json:"deleted_at" example:"2024-01-01T12:00:00Z"` // When the video was deleted
+	PurgeAt   time.Time    `json:"purge_at" example:"2024-01-31T12:00:00Z"`   // When the video will be deleted for good
+}
+
+// RevisionFieldChange represents a video field that differs between two
+// revisions
+type RevisionFieldChange struct {
+	Field string      `json:"field" example:"title"`                         // title, description, url, author_id or tags
+	From  interface{} `json:"from" swaggertype:"string" example:"Old title"` // Value after the first revision
+	To    interface{} `json:"to" swaggertype:"string" example:"New title"`   // Value after the second revision
+}
+
+// RevisionDiffResponse represents the changes between two revisions
+type RevisionDiffResponse struct {
+	From    int64                 `json:"from" example:"1"` // First revision
+	To      int64                 `json:"to" example:"3"`   // Second revision
+	Changes []RevisionFieldChange `json:"changes"`          // Fields that differ, empty if none
+}
@@ -0,0 +1,31 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKey is a long-lived credential for machine clients. Only a hash of the
+// secret is stored; the prefix is kept in clear so keys can be told apart.
+type APIKey struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:text;primaryKey" example:"123e4567-e89b-12d3-a456-426614174003"` // API key ID
+	UserID     uuid.UUID  `json:"-" gorm:"type:text;index;not null"`                                             // Owner of the key
+	Name       string     `json:"name" gorm:"type:varchar(100);not null" example:"CI ingestion"`                 // Human readable label
+	Prefix     string     `json:"prefix" gorm:"type:varchar(32);uniqueIndex;not null" example:"gk_7hq2m4xk"`     // Public part of the key
+	Hash       string     `json:"-" gorm:"type:varchar(64);not null"`                                            // SHA-256 of the full key
+	Scopes     []string   `json:"scopes" gorm:"serializer:json" example:"videos:read,videos:write"`              // Permissions the key may use
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" swaggerignore:"true"`                                   // Last successful authentication
+	ExpiresAt  *time.Time `json:"expires_at,omitempty" example:"2030-01-01T00:00:00Z"`                           // Expiry, if any
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" swaggerignore:"true"`                                     // When the key was revoked
+	CreatedAt  time.Time  `json:"created_at" swaggerignore:"true"`                                               // Creation time
+}
+
+// BeforeCreate hook to generate UUID before creating an APIKey
+func (k *APIKey) BeforeCreate(tx *gorm.DB) error {
+	if k.ID == uuid.Nil {
+		k.ID = uuid.New()
+	}
+	return nil
+}
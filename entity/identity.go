@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// UserIdentity links a local user to an account at an external identity
+// provider, identified by the provider's issuer and subject.
+type UserIdentity struct {
+	ID        uuid.UUID `json:"id" gorm:"type:text;primaryKey"`
+	UserID    uuid.UUID `json:"user_id" gorm:"type:text;index;not null"`
+	Issuer    string    `json:"issuer" gorm:"type:varchar(255);uniqueIndex:idx_identity_subject;not null"`
+	Subject   string    `json:"subject" gorm:"type:varchar(255);uniqueIndex:idx_identity_subject;not null"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// BeforeCreate hook to generate UUID before creating a UserIdentity
+func (i *UserIdentity) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
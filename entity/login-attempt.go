@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// LoginAttempt counts recent failed logins for a key such as a username or
+// a client IP address
+type LoginAttempt struct {
+	Key           string     `gorm:"column:attempt_key;type:varchar(255);primaryKey"` // e.g. user:jdoe or ip:203.0.113.7
+	Failures      int        `gorm:"not null"`
+	LastFailureAt time.Time  `gorm:"not null"`
+	LockedUntil   *time.Time // Logins are refused until then
+}
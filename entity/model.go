@@ -10,4 +10,7 @@ type Model struct {
 	CreatedAt time.Time      `json:"created_at,omitempty" xml:"created_at" form:"created_at" swaggerignore:"true"`
 	UpdatedAt time.Time      `json:"updated_at,omitempty" xml:"updated_at" form:"updated_at" swaggerignore:"true"`
 	DeletedAt gorm.DeletedAt `json:"-" xml:"-" form:"-" gorm:"index"`
+	// Version starts at 1 and is increased by each update of videos,
+	// authors and playlists, which refuse updates based on an older version
+	Version int64 `json:"version" xml:"version" form:"-" gorm:"not null;default:1" swaggerignore:"true"`
 }
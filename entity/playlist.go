@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Playlist visibilities
+const (
+	// VisibilityPublic playlists are listed on their owner's profile
+	VisibilityPublic = "public"
+	// VisibilityUnlisted playlists can be opened by anyone who has the ID
+	VisibilityUnlisted = "unlisted"
+	// VisibilityPrivate playlists are only visible to their owner
+	VisibilityPrivate = "private"
+)
+
+// Playlist is an ordered collection of videos curated by a user
+type Playlist struct {
+	ID          uuid.UUID      `json:"id" gorm:"type:text;primaryKey" example:"123e4567-e89b-12d3-a456-426614174004"`           // Playlist ID
+	OwnerID     uuid.UUID      `json:"owner_id" gorm:"type:text;index;not null" example:"123e4567-e89b-12d3-a456-426614174002"` // User who created the playlist
+	Title       string         `json:"title" gorm:"type:varchar(100);not null" example:"Go fundamentals"`                       // Playlist title
+	Description string         `json:"description" gorm:"type:varchar(500)" example:"Start here"`                               // Playlist description
+	Visibility  string         `json:"visibility" gorm:"type:varchar(16);not null" example:"public"`                            // public, unlisted or private
+	Items       []PlaylistItem `json:"items"`                                                                                   // Entries in playlist order
+	Model
+}
+
+// BeforeCreate hook to generate UUID before creating a Playlist
+func (p *Playlist) BeforeCreate(tx *gorm.DB) error {
+	if p.ID == uuid.Nil {
+		p.ID = uuid.New()
+	}
+	return nil
+}
+
+// PlaylistItem places a video in a playlist. Items are ordered by Position,
+// which leaves gaps between neighbours so that moving an item only
+// rewrites that item.
+type PlaylistItem struct {
+	ID         uuid.UUID `json:"id" gorm:"type:text;primaryKey" example:"123e4567-e89b-12d3-a456-426614174005"` // Item ID
+	PlaylistID uuid.UUID `json:"-" gorm:"type:text;not null;index:idx_playlist_item_position,priority:1"`       // Playlist the item belongs to
+	VideoID    uuid.UUID `json:"-" gorm:"type:text;not null;index"`                                             // Video in the playlist
+	Video      Video     `json:"video"`                                                                         // Video in the playlist
+	Position   int64     `json:"-" gorm:"not null;index:idx_playlist_item_position,priority:2"`                 // Sort key within the playlist
+	CreatedAt  time.Time `json:"added_at" swaggerignore:"true"`                                                 // When the video was added
+}
+
+// BeforeCreate hook to generate UUID before creating a PlaylistItem
+func (i *PlaylistItem) BeforeCreate(tx *gorm.DB) error {
+	if i.ID == uuid.Nil {
+		i.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// RecoveryCode is a single-use code that replaces a TOTP code when the
+// user has lost their authenticator. Only a bcrypt hash is stored.
+type RecoveryCode struct {
+	ID        uuid.UUID `gorm:"type:text;primaryKey"`
+	UserID    uuid.UUID `gorm:"type:text;index;not null"`
+	Hash      string    `gorm:"type:varchar(255);not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time
+}
+
+// BeforeCreate hook to generate UUID before creating a RecoveryCode
+func (c *RecoveryCode) BeforeCreate(tx *gorm.DB) error {
+	if c.ID == uuid.Nil {
+		c.ID = uuid.New()
+	}
+	return nil
+}
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// Revision actions
+const (
+	// RevisionCreate records the creation of a video
+	RevisionCreate = "create"
+	// RevisionUpdate records an update or patch of a video
+	RevisionUpdate = "update"
+	// RevisionDelete records a video being moved to the trash
+	RevisionDelete = "delete"
+	// RevisionRestore records a video being restored from the trash
+	RevisionRestore = "restore"
+	// RevisionRollback records a video being reset to an earlier revision
+	RevisionRollback = "rollback"
+	// RevisionPurge records a video being removed from the trash for good.
+	// It is the last revision of the video.
+	RevisionPurge = "purge"
+)
+
+// ErrRevisionImmutable is returned when a revision would be changed
+var ErrRevisionImmutable = errors.New("revisions cannot be changed")
+
+// VideoSnapshot is the metadata of a video at one revision
+type VideoSnapshot struct {
+	Title       string    `json:"title" example:"Introduction to Golang"`                   // Video title
+	Description string    `json:"description" example:"Learn Golang basics"`                // Video description
+	URL         string    `json:"url" example:"https://www.youtube.com/watch?v=abc"`        // Video URL
+	AuthorID    uuid.UUID `json:"author_id" example:"123e4567-e89b-12d3-a456-426614174000"` // ID of the video's author
+	Tags        []string  `json:"tags" example:"golang,tutorial"`                           // Tag names in alphabetical order
+}
+
+// NewVideoSnapshot returns the metadata of the video
+func NewVideoSnapshot(video *Video) *VideoSnapshot {
+	tags := TagNames(video.Tags)
+	slices.Sort(tags)
+	return &VideoSnapshot{
+		Title:       video.Title,
+		Description: video.Description,
+		URL:         video.URL,
+		AuthorID:    video.AuthorID,
+		Tags:        tags,
+	}
+}
+
+// VideoRevision records one change of a video: who made it, when, and the
+// video's metadata before and after. Revisions are numbered by the version
+// the change gave the video and are never changed once written.
+type VideoRevision struct {
+	VideoID       uuid.UUID      `json:"video_id" gorm:"type:text;primaryKey" example:"123e4567-e89b-12d3-a456-426614174001"` // Video the revision belongs to
+	Number        int64          `json:"number" gorm:"primaryKey;autoIncrement:false" example:"3"`                            // The video's version after the change
+	Action        string         `json:"action" gorm:"type:varchar(16);not null" example:"update"`                            // create, update, delete, restore, rollback or purge
+	ActorID       *uuid.UUID     `json:"actor_id,omitempty" gorm:"type:text" example:"123e4567-e89b-12d3-a456-426614174002"`  // User who made the change
+	ActorName     string         `json:"actor_name" gorm:"type:varchar(100)" example:"alice"`                                 // Username of the user who made the change
+	ChangedFields []string       `json:"changed_fields" gorm:"serializer:json" example:"title,tags"`                          // Metadata fields the change modified
+	Before        *VideoSnapshot `json:"before" gorm:"serializer:json"`                                                       // Metadata before the change, null for creations
+	After         *VideoSnapshot `json:"after" gorm:"serializer:json"`                                                        // Metadata after the change
+	RestoredFrom  *int64         `json:"restored_from,omitempty" example:"1"`                                                 // Revision a rollback returned to
+	CreatedAt     time.Time      `json:"created_at" example:"2024-01-01T00:00:00Z"`                                           // When the change was made
+}
+
+// BeforeUpdate keeps revisions immutable
+func (r *VideoRevision) BeforeUpdate(tx *gorm.DB) error {
+	return ErrRevisionImmutable
+}
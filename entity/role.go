@@ -0,0 +1,36 @@
+package entity
+
+import "github.com/muzammil-cyber/golang-gin/auth"
+
+// Built-in roles
+const (
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+	RoleViewer = "viewer"
+)
+
+// Role is a named set of permissions that can be granted to users
+type Role struct {
+	Name        string   `json:"name" gorm:"type:varchar(50);primaryKey" example:"member"`                              // Role name
+	Description string   `json:"description" gorm:"type:varchar(255)" example:"Can create videos and manage their own"` // What the role is for
+	Permissions []string `json:"permissions" gorm:"serializer:json" example:"videos:read,videos:write,videos:delete"`   // Granted permissions
+}
+
+// DefaultRoles are created on startup and kept in sync with this definition
+var DefaultRoles = []Role{
+	{
+		Name:        RoleAdmin,
+		Description: "Full access, including user administration",
+		Permissions: []string{auth.PermVideosRead, auth.PermVideosWrite, auth.PermVideosDelete, auth.PermVideosAdmin, auth.PermUsersAdmin},
+	},
+	{
+		Name:        RoleMember,
+		Description: "Can create videos and manage their own",
+		Permissions: []string{auth.PermVideosRead, auth.PermVideosWrite, auth.PermVideosDelete},
+	},
+	{
+		Name:        RoleViewer,
+		Description: "Read-only access to videos",
+		Permissions: []string{auth.PermVideosRead},
+	},
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "encoding/json"
+
+// Tag is a label on videos, such as a topic or category. Tags are created
+// on first use and are shown as plain strings in JSON.
+type Tag struct {
+	Name string `gorm:"type:varchar(50);primaryKey"` // Lowercase tag name
+}
+
+func (t Tag) MarshalJSON() ([]byte, error) {
+	return json.Marshal(t.Name)
+}
+
+func (t *Tag) UnmarshalJSON(data []byte) error {
+	return json.Unmarshal(data, &t.Name)
+}
+
+// TagNames returns the names of tags
+func TagNames(tags []Tag) []string {
+	names := make([]string, len(tags))
+	for i, tag := range tags {
+		names[i] = tag.Name
+	}
+	return names
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// User represents a local account that can authenticate against the API
+type User struct {
+	ID              uuid.UUID  `json:"id,omitempty" gorm:"type:text;primaryKey" example:"123e4567-e89b-12d3-a456-426614174002"` // User ID
+	Username        string     `json:"username" gorm:"type:varchar(50);uniqueIndex;not null" example:"admin"`                   // Unique username
+	Email           *string    `json:"email,omitempty" gorm:"type:varchar(255);uniqueIndex" example:"admin@example.com"`        // Unique email, empty for bootstrapped accounts
+	EmailVerifiedAt *time.Time `json:"email_verified_at,omitempty" swaggerignore:"true"`                                        // When the email address was confirmed
+	PasswordHash    string     `json:"-" gorm:"type:varchar(255);not null"`                                                     // bcrypt hash of the password
+	Roles           []Role     `json:"roles" gorm:"many2many:user_roles"`                                                       // Granted roles
+	TOTPSecret      string     `json:"-" gorm:"type:varchar(64)"`                                                               // Base32 TOTP secret, set once enrollment starts
+	TOTPLastStep    int64      `json:"-"`                                                                                       // Last accepted TOTP period, so codes are single-use
+	MFAEnabledAt    *time.Time `json:"mfa_enabled_at,omitempty" swaggerignore:"true"`                                           // When two-factor authentication was turned on
+	Model
+}
+
+// BeforeCreate hook to generate UUID before creating a User
+func (u *User) BeforeCreate(tx *gorm.DB) error {
+	if u.ID == uuid.Nil {
+		u.ID = uuid.New()
+	}
+	return nil
+}
+
+// RoleNames returns the names of the user's roles
+func (u *User) RoleNames() []string {
+	names := make([]string, len(u.Roles))
+	for i, role := range u.Roles {
+		names[i] = role.Name
+	}
+	return names
+}
+
+// Permissions returns the union of the permissions of the user's roles
+func (u *User) Permissions() []string {
+	seen := map[string]bool{}
+	permissions := []string{}
+	for _, role := range u.Roles {
+		for _, permission := range role.Permissions {
+			if !seen[permission] {
+				seen[permission] = true
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions
+}
+
+// MFAEnabled reports whether the user has completed TOTP enrollment
+func (u *User) MFAEnabled() bool {
+	return u.MFAEnabledAt != nil
+}
@@ -10,7 +10,7 @@ type Person struct {
 	ID    uuid.UUID `json:"id,omitempty" xml:"id" form:"id" gorm:"type:text;primaryKey" example:"123e4567-e89b-12d3-a456-426614174000" swaggerignore:"true"` // Person ID (auto-generated, omit in create requests)
 	Name  string    `json:"name" xml:"name" form:"name" binding:"required,min=2,max=50" example:"John Doe"`                                                  // Person name (2-50 characters)
 	Age   int       `json:"age" xml:"age" form:"age" binding:"gte=0,lte=120" example:"30"`                                                                   // Person age (0-120)
-	Email string    `json:"email" xml:"email" form:"email" binding:"required,email" example:"john.doe@example.com"`                                          // Person email
+	Email string    `json:"email" xml:"email" form:"email" binding:"required,email" gorm:"type:varchar(255);uniqueIndex" example:"john.doe@example.com"`     // Person email, unique and stored in lowercase
 	Model
 }
 
@@ -24,12 +24,14 @@ func (p *Person) BeforeCreate(tx *gorm.DB) error {
 
 // Video represents a video entity
 type Video struct {
-	ID          uuid.UUID `json:"id,omitempty" xml:"id" form:"id" gorm:"type:text;primaryKey" example:"123e4567-e89b-12d3-a456-426614174001" swaggerignore:"true"` // Video ID (auto-generated, omit in create requests)
-	Title       string    `json:"title" xml:"title" form:"title" binding:"min=3,max=100" gorm:"type:varchar(100)" example:"Introduction to Golang"`                // Video title (3-100 characters)
-	Description string    `json:"description" xml:"description" form:"description" binding:"max=500" gorm:"type:varchar(500)" example:"Learn Golang basics"`       // Video description (max 500 characters)
-	URL         string    `json:"url" xml:"url" form:"url" binding:"required,url" gorm:"type:varchar(255)" example:"https://www.youtube.com/watch?v=abc"`          // Video URL
-	Author      Person    `json:"author" xml:"author" form:"author" binding:"required" gorm:"foreignKey:AuthorID;references:ID"`                                   // Video author
-	AuthorID    uuid.UUID `json:"-" xml:"-" form:"-" gorm:"type:text"`                                                                                             // Author ID (foreign key)
+	ID          uuid.UUID  `json:"id,omitempty" xml:"id" form:"id" gorm:"type:text;primaryKey" example:"123e4567-e89b-12d3-a456-426614174001" swaggerignore:"true"` // Video ID (auto-generated, omit in create requests)
+	Title       string     `json:"title" xml:"title" form:"title" binding:"min=3,max=100" gorm:"type:varchar(100)" example:"Introduction to Golang"`                // Video title (3-100 characters)
+	Description string     `json:"description" xml:"description" form:"description" binding:"max=500" gorm:"type:varchar(500)" example:"Learn Golang basics"`       // Video description (max 500 characters)
+	URL         string     `json:"url" xml:"url" form:"url" binding:"required,url" gorm:"type:varchar(255)" example:"https://www.youtube.com/watch?v=abc"`          // Video URL
+	Author      Person     `json:"author" xml:"author" form:"author" binding:"required" gorm:"foreignKey:AuthorID;references:ID"`                                   // Video author
+	AuthorID    uuid.UUID  `json:"-" xml:"-" form:"-" gorm:"type:text"`                                                                                             // Author ID (foreign key)
+	OwnerID     *uuid.UUID `json:"owner_id,omitempty" xml:"owner_id" form:"-" gorm:"type:text;index" swaggerignore:"true"`                                          // ID of the user who created the video
+	Tags        []Tag      `json:"tags" xml:"tags" form:"-" gorm:"many2many:video_tags" swaggertype:"array,string" example:"golang,tutorial"`                       // Tags; omit in updates to keep the current ones
 	Model
 }
 
@@ -0,0 +1,27 @@
+package graph
+
+import (
+	"context"
+	"errors"
+
+	"github.com/muzammil-cyber/golang-gin/auth"
+)
+
+var (
+	ErrUnauthenticated = errors.New("authentication required")
+	ErrForbidden       = errors.New("insufficient permissions")
+)
+
+// requirePermission is the resolver counterpart of
+// middleware.RequirePermission. It returns the caller if they hold every
+// given permission.
+func requirePermission(ctx context.Context, permissions ...string) (*auth.Principal, error) {
+	principal := auth.FromContext(ctx)
+	if principal == nil {
+		return nil, ErrUnauthenticated
+	}
+	if !principal.HasPermission(permissions...) {
+		return nil, ErrForbidden
+	}
+	return principal, nil
+}
@@ -0,0 +1,184 @@
+package graph
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/graph/model"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+var videoOrderFields = map[model.VideoOrderField]string{
+	model.VideoOrderFieldCreatedAt: repository.VideoSortCreatedAt,
+	model.VideoOrderFieldUpdatedAt: repository.VideoSortUpdatedAt,
+	model.VideoOrderFieldTitle:     repository.VideoSortTitle,
+}
+
+// Helper function to convert entity.Video to model.Video
+func videoEntityToModel(v *entity.Video) *model.Video {
+	return &model.Video{
+		ID:          v.ID.String(),
+		Title:       v.Title,
+		Description: v.Description,
+		URL:         v.URL,
+		Author:      personEntityToModel(&v.Author),
+		Tags:        entity.TagNames(v.Tags),
+		CreatedAt:   v.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   v.UpdatedAt.Format(time.RFC3339),
+		Version:     int32(v.Version),
+	}
+}
+
+// personEntityToModel converts an author; its videos are resolved on demand
+func personEntityToModel(p *entity.Person) *model.Person {
+	return &model.Person{
+		ID:        p.ID.String(),
+		Name:      p.Name,
+		Age:       int32(p.Age),
+		Email:     p.Email,
+		CreatedAt: p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: p.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+// videoQueryFromArgs translates Relay connection arguments into a
+// repository query
+func videoQueryFromArgs(first *int32, after *string, last *int32, before *string, filter *model.VideoFilter, orderBy *model.VideoOrder) (repository.VideoQuery, error) {
+	var query repository.VideoQuery
+	if first != nil && last != nil {
+		return query, errors.New("first and last cannot be combined")
+	}
+	for _, count := range []*int32{first, last} {
+		if count != nil && (*count < 1 || *count > repository.MaxVideoPageSize) {
+			return query, fmt.Errorf("first and last must be between 1 and %d", repository.MaxVideoPageSize)
+		}
+	}
+	if first != nil {
+		query.Limit = int(*first)
+	}
+	if last != nil {
+		query.Limit = int(*last)
+		query.FromEnd = true
+	}
+	if after != nil {
+		query.After = *after
+	}
+	if before != nil {
+		query.Before = *before
+	}
+	if orderBy != nil {
+		query.Sort = videoOrderFields[orderBy.Field]
+		query.Desc = orderBy.Direction == model.OrderDirectionDesc
+	}
+
+	if filter != nil {
+		if filter.AuthorID != nil {
+			query.AuthorID = *filter.AuthorID
+		}
+		if filter.TitleContains != nil {
+			query.TitleContains = *filter.TitleContains
+		}
+		query.Tags = filter.Tags
+		query.MatchAllTags = filter.TagMatch != nil && *filter.TagMatch == model.TagMatchAll
+		var err error
+		if query.CreatedAfter, err = parseTimeArg("createdAfter", filter.CreatedAfter); err != nil {
+			return query, err
+		}
+		if query.CreatedBefore, err = parseTimeArg("createdBefore", filter.CreatedBefore); err != nil {
+			return query, err
+		}
+	}
+	return query, nil
+}
+
+func parseTimeArg(name string, value *string) (*time.Time, error) {
+	if value == nil {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, *value)
+	if err != nil {
+		return nil, fmt.Errorf("%s must be an RFC 3339 time: %w", name, err)
+	}
+	return &t, nil
+}
+
+// videoPageToConnection converts a page into a Relay connection
+func videoPageToConnection(query repository.VideoQuery, page repository.VideoPage) *model.VideoConnection {
+	connection := &model.VideoConnection{
+		Edges: make([]*model.VideoEdge, len(page.Videos)),
+		PageInfo: &model.PageInfo{
+			HasNextPage:     page.HasNext,
+			HasPreviousPage: page.HasPrevious,
+		},
+		TotalCount: int32(page.Total),
+	}
+	for i, video := range page.Videos {
+		connection.Edges[i] = &model.VideoEdge{
+			Cursor: query.Cursor(video),
+			Node:   videoEntityToModel(&video),
+		}
+	}
+	if len(connection.Edges) > 0 {
+		connection.PageInfo.StartCursor = &connection.Edges[0].Cursor
+		connection.PageInfo.EndCursor = &connection.Edges[len(connection.Edges)-1].Cursor
+	}
+	return connection
+}
+
+// searchArgs validates the paging arguments of searchVideos
+func searchArgs(first *int32, offset *int32) (int, int, error) {
+	limit, skip := 0, 0
+	if first != nil {
+		if *first < 1 || *first > repository.MaxVideoPageSize {
+			return 0, 0, fmt.Errorf("first must be between 1 and %d", repository.MaxVideoPageSize)
+		}
+		limit = int(*first)
+	}
+	if offset != nil {
+		if *offset < 0 {
+			return 0, 0, errors.New("offset must not be negative")
+		}
+		skip = int(*offset)
+	}
+	return limit, skip, nil
+}
+
+// searchResultsToModel converts search hits into GraphQL results
+func searchResultsToModel(results service.VideoSearchResults) []*model.VideoSearchResult {
+	converted := make([]*model.VideoSearchResult, len(results.Hits))
+	for i, hit := range results.Hits {
+		converted[i] = &model.VideoSearchResult{
+			Video:          videoEntityToModel(&hit.Video),
+			Score:          hit.Score,
+			TitleHighlight: hit.TitleHighlight,
+			Snippet:        hit.Snippet,
+		}
+	}
+	return converted
+}
+
+// playlistEntityToModel converts a playlist and its items
+func playlistEntityToModel(p *entity.Playlist) *model.Playlist {
+	items := make([]*model.PlaylistItem, len(p.Items))
+	for i, item := range p.Items {
+		items[i] = &model.PlaylistItem{
+			ID:      item.ID.String(),
+			Video:   videoEntityToModel(&item.Video),
+			AddedAt: item.CreatedAt.Format(time.RFC3339),
+		}
+	}
+	return &model.Playlist{
+		ID:          p.ID.String(),
+		OwnerID:     p.OwnerID.String(),
+		Title:       p.Title,
+		Description: p.Description,
+		Visibility:  model.PlaylistVisibility(strings.ToUpper(p.Visibility)),
+		Items:       items,
+		CreatedAt:   p.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   p.UpdatedAt.Format(time.RFC3339),
+	}
+}
@@ -2,23 +2,50 @@
 
 package model
 
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type CreatePlaylistInput struct {
+	Title       string  `json:"title"`
+	Description *string `json:"description,omitempty"`
+	// Defaults to PRIVATE
+	Visibility *PlaylistVisibility `json:"visibility,omitempty"`
+}
+
 type CreateVideoInput struct {
-	Title       string       `json:"title"`
-	Description string       `json:"description"`
-	URL         string       `json:"url"`
-	Author      *PersonInput `json:"author"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	URL         string `json:"url"`
+	// An existing author; takes precedence over author
+	AuthorID *string `json:"authorId,omitempty"`
+	// An author to create, or to update if one with the same email exists
+	Author *PersonInput `json:"author,omitempty"`
+	Tags   []string     `json:"tags,omitempty"`
 }
 
 type Mutation struct {
 }
 
+type PageInfo struct {
+	HasNextPage     bool    `json:"hasNextPage"`
+	HasPreviousPage bool    `json:"hasPreviousPage"`
+	StartCursor     *string `json:"startCursor,omitempty"`
+	EndCursor       *string `json:"endCursor,omitempty"`
+}
+
 type Person struct {
-	ID        string `json:"id"`
-	Name      string `json:"name"`
-	Age       int32  `json:"age"`
-	Email     string `json:"email"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt"`
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Age   int32  `json:"age"`
+	Email string `json:"email"`
+	// Videos by the author, newest first
+	Videos    []*Video `json:"videos"`
+	CreatedAt string   `json:"createdAt"`
+	UpdatedAt string   `json:"updatedAt"`
 }
 
 type PersonInput struct {
@@ -27,21 +54,315 @@ type PersonInput struct {
 	Email string `json:"email"`
 }
 
+type Playlist struct {
+	ID          string             `json:"id"`
+	OwnerID     string             `json:"ownerId"`
+	Title       string             `json:"title"`
+	Description string             `json:"description"`
+	Visibility  PlaylistVisibility `json:"visibility"`
+	// Entries in playlist order; empty in playlist listings
+	Items     []*PlaylistItem `json:"items"`
+	CreatedAt string          `json:"createdAt"`
+	UpdatedAt string          `json:"updatedAt"`
+}
+
+type PlaylistItem struct {
+	ID      string `json:"id"`
+	Video   *Video `json:"video"`
+	AddedAt string `json:"addedAt"`
+}
+
 type Query struct {
 }
 
+type UpdatePlaylistInput struct {
+	Title       *string             `json:"title,omitempty"`
+	Description *string             `json:"description,omitempty"`
+	Visibility  *PlaylistVisibility `json:"visibility,omitempty"`
+}
+
 type UpdateVideoInput struct {
 	Title       *string `json:"title,omitempty"`
 	Description *string `json:"description,omitempty"`
 	URL         *string `json:"url,omitempty"`
+	// Replaces the tags when given
+	Tags []string `json:"tags,omitempty"`
 }
 
 type Video struct {
-	ID          string  `json:"id"`
-	Title       string  `json:"title"`
-	Description string  `json:"description"`
-	URL         string  `json:"url"`
-	Author      *Person `json:"author"`
-	CreatedAt   string  `json:"createdAt"`
-	UpdatedAt   string  `json:"updatedAt"`
+	ID          string   `json:"id"`
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	URL         string   `json:"url"`
+	Author      *Person  `json:"author"`
+	Tags        []string `json:"tags"`
+	CreatedAt   string   `json:"createdAt"`
+	UpdatedAt   string   `json:"updatedAt"`
+	// Increases with every update; pass it to updateVideo to detect concurrent edits
+	Version int32 `json:"version"`
+}
+
+type VideoConnection struct {
+	Edges      []*VideoEdge `json:"edges"`
+	PageInfo   *PageInfo    `json:"pageInfo"`
+	TotalCount int32        `json:"totalCount"`
+}
+
+type VideoEdge struct {
+	Cursor string `json:"cursor"`
+	Node   *Video `json:"node"`
+}
+
+type VideoFilter struct {
+	AuthorID      *string `json:"authorId,omitempty"`
+	TitleContains *string `json:"titleContains,omitempty"`
+	// RFC 3339 time
+	CreatedAfter *string `json:"createdAfter,omitempty"`
+	// RFC 3339 time
+	CreatedBefore *string  `json:"createdBefore,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	// Whether videos need any (the default) or all of the tags
+	TagMatch *TagMatch `json:"tagMatch,omitempty"`
+}
+
+type VideoOrder struct {
+	Field     VideoOrderField `json:"field"`
+	Direction OrderDirection  `json:"direction"`
+}
+
+type VideoSearchResult struct {
+	Video *Video `json:"video"`
+	// Relevance, higher is better; only comparable within one search
+	Score float64 `json:"score"`
+	// HTML-escaped title with matches wrapped in <mark> tags
+	TitleHighlight string `json:"titleHighlight"`
+	// HTML-escaped excerpt of the description with matches wrapped in <mark> tags
+	Snippet string `json:"snippet"`
+}
+
+type OrderDirection string
+
+const (
+	OrderDirectionAsc  OrderDirection = "ASC"
+	OrderDirectionDesc OrderDirection = "DESC"
+)
+
+var AllOrderDirection = []OrderDirection{
+	OrderDirectionAsc,
+	OrderDirectionDesc,
+}
+
+func (e OrderDirection) IsValid() bool {
+	switch e {
+	case OrderDirectionAsc, OrderDirectionDesc:
+		return true
+	}
+	return false
+}
+
+func (e OrderDirection) String() string {
+	return string(e)
+}
+
+func (e *OrderDirection) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = OrderDirection(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid OrderDirection", str)
+	}
+	return nil
+}
+
+func (e OrderDirection) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *OrderDirection) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e OrderDirection) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type PlaylistVisibility string
+
+const (
+	// Listed on the owner's playlists
+	PlaylistVisibilityPublic PlaylistVisibility = "PUBLIC"
+	// Visible to anyone with the ID
+	PlaylistVisibilityUnlisted PlaylistVisibility = "UNLISTED"
+	// Only visible to the owner
+	PlaylistVisibilityPrivate PlaylistVisibility = "PRIVATE"
+)
+
+var AllPlaylistVisibility = []PlaylistVisibility{
+	PlaylistVisibilityPublic,
+	PlaylistVisibilityUnlisted,
+	PlaylistVisibilityPrivate,
+}
+
+func (e PlaylistVisibility) IsValid() bool {
+	switch e {
+	case PlaylistVisibilityPublic, PlaylistVisibilityUnlisted, PlaylistVisibilityPrivate:
+		return true
+	}
+	return false
+}
+
+func (e PlaylistVisibility) String() string {
+	return string(e)
+}
+
+func (e *PlaylistVisibility) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = PlaylistVisibility(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid PlaylistVisibility", str)
+	}
+	return nil
+}
+
+func (e PlaylistVisibility) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *PlaylistVisibility) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e PlaylistVisibility) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "ANY"
+	TagMatchAll TagMatch = "ALL"
+)
+
+var AllTagMatch = []TagMatch{
+	TagMatchAny,
+	TagMatchAll,
+}
+
+func (e TagMatch) IsValid() bool {
+	switch e {
+	case TagMatchAny, TagMatchAll:
+		return true
+	}
+	return false
+}
+
+func (e TagMatch) String() string {
+	return string(e)
+}
+
+func (e *TagMatch) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = TagMatch(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid TagMatch", str)
+	}
+	return nil
+}
+
+func (e TagMatch) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *TagMatch) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e TagMatch) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
+}
+
+type VideoOrderField string
+
+const (
+	VideoOrderFieldCreatedAt VideoOrderField = "CREATED_AT"
+	VideoOrderFieldUpdatedAt VideoOrderField = "UPDATED_AT"
+	VideoOrderFieldTitle     VideoOrderField = "TITLE"
+)
+
+var AllVideoOrderField = []VideoOrderField{
+	VideoOrderFieldCreatedAt,
+	VideoOrderFieldUpdatedAt,
+	VideoOrderFieldTitle,
+}
+
+func (e VideoOrderField) IsValid() bool {
+	switch e {
+	case VideoOrderFieldCreatedAt, VideoOrderFieldUpdatedAt, VideoOrderFieldTitle:
+		return true
+	}
+	return false
+}
+
+func (e VideoOrderField) String() string {
+	return string(e)
+}
+
+func (e *VideoOrderField) UnmarshalGQL(v any) error {
+	str, ok := v.(string)
+	if !ok {
+		return fmt.Errorf("enums must be strings")
+	}
+
+	*e = VideoOrderField(str)
+	if !e.IsValid() {
+		return fmt.Errorf("%s is not a valid VideoOrderField", str)
+	}
+	return nil
+}
+
+func (e VideoOrderField) MarshalGQL(w io.Writer) {
+	fmt.Fprint(w, strconv.Quote(e.String()))
+}
+
+func (e *VideoOrderField) UnmarshalJSON(b []byte) error {
+	s, err := strconv.Unquote(string(b))
+	if err != nil {
+		return err
+	}
+	return e.UnmarshalGQL(s)
+}
+
+func (e VideoOrderField) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	e.MarshalGQL(&buf)
+	return buf.Bytes(), nil
 }
@@ -10,6 +10,9 @@ import (
 // here.
 
 type Resolver struct {
-	VideoService service.VideoService
-	JWTService   service.JWTService
+	VideoService    service.VideoService
+	SearchService   service.SearchService
+	PlaylistService service.PlaylistService
+	AuthorService   service.AuthorService
+	JWTService      service.JWTService
 }
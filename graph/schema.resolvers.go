@@ -7,31 +7,46 @@ package graph
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"time"
+	"strings"
 
+	"github.com/gin-gonic/gin/binding"
 	"github.com/google/uuid"
+	"github.com/muzammil-cyber/golang-gin/auth"
 	"github.com/muzammil-cyber/golang-gin/dto"
 	"github.com/muzammil-cyber/golang-gin/entity"
 	"github.com/muzammil-cyber/golang-gin/graph/model"
+	"github.com/muzammil-cyber/golang-gin/service"
 )
 
 // CreateVideo is the resolver for the createVideo field.
 func (r *mutationResolver) CreateVideo(ctx context.Context, input model.CreateVideoInput) (*model.Video, error) {
+	principal, err := requirePermission(ctx, auth.PermVideosWrite)
+	if err != nil {
+		return nil, err
+	}
+
 	// Convert GraphQL input to DTO
 	videoRequest := dto.VideoCreateRequest{
 		Title:       input.Title,
 		Description: input.Description,
 		URL:         input.URL,
-		Author: entity.Person{
+		Tags:        input.Tags,
+	}
+	if input.AuthorID != nil {
+		videoRequest.AuthorID = *input.AuthorID
+	}
+	if input.Author != nil {
+		videoRequest.Author = &entity.Person{
 			Name:  input.Author.Name,
 			Age:   int(input.Author.Age),
 			Email: input.Author.Email,
-		},
+		}
 	}
 
 	// Call service
-	createdVideo, err := r.VideoService.Save(videoRequest)
+	createdVideo, err := r.VideoService.Save(videoRequest, principal)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create video: %w", err)
 	}
@@ -41,7 +56,12 @@ func (r *mutationResolver) CreateVideo(ctx context.Context, input model.CreateVi
 }
 
 // UpdateVideo is the resolver for the updateVideo field.
-func (r *mutationResolver) UpdateVideo(ctx context.Context, id string, input model.UpdateVideoInput) (*model.Video, error) {
+func (r *mutationResolver) UpdateVideo(ctx context.Context, id string, input model.UpdateVideoInput, version *int32) (*model.Video, error) {
+	principal, err := requirePermission(ctx, auth.PermVideosWrite)
+	if err != nil {
+		return nil, err
+	}
+
 	// Parse UUID
 	videoID, err := uuid.Parse(id)
 	if err != nil {
@@ -64,11 +84,22 @@ func (r *mutationResolver) UpdateVideo(ctx context.Context, id string, input mod
 	if input.URL != nil {
 		existingVideo.URL = *input.URL
 	}
+	if input.Tags != nil {
+		existingVideo.Tags = make([]entity.Tag, len(input.Tags))
+		for i, name := range input.Tags {
+			existingVideo.Tags[i] = entity.Tag{Name: name}
+		}
+	}
 
 	existingVideo.ID = videoID
+	// Without a version, only edits made since the video was read above are
+	// detected
+	if version != nil {
+		existingVideo.Version = int64(*version)
+	}
 
 	// Call service
-	updatedVideo, err := r.VideoService.Update(*existingVideo)
+	updatedVideo, err := r.VideoService.Update(*existingVideo, principal)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update video: %w", err)
 	}
@@ -78,15 +109,145 @@ func (r *mutationResolver) UpdateVideo(ctx context.Context, id string, input mod
 
 // DeleteVideo is the resolver for the deleteVideo field.
 func (r *mutationResolver) DeleteVideo(ctx context.Context, id string) (bool, error) {
-	err := r.VideoService.Delete(id)
+	principal, err := requirePermission(ctx, auth.PermVideosDelete)
+	if err != nil {
+		return false, err
+	}
+
+	err = r.VideoService.Delete(id, service.AnyVersion, principal)
 	if err != nil {
 		return false, fmt.Errorf("failed to delete video: %w", err)
 	}
 	return true, nil
 }
 
+// CreatePlaylist is the resolver for the createPlaylist field.
+func (r *mutationResolver) CreatePlaylist(ctx context.Context, input model.CreatePlaylistInput) (*model.Playlist, error) {
+	principal, err := requirePermission(ctx, auth.PermVideosWrite)
+	if err != nil {
+		return nil, err
+	}
+	request := dto.PlaylistCreateRequest{Title: input.Title}
+	if input.Description != nil {
+		request.Description = *input.Description
+	}
+	if input.Visibility != nil {
+		request.Visibility = strings.ToLower(string(*input.Visibility))
+	}
+	if err := binding.Validator.ValidateStruct(request); err != nil {
+		return nil, err
+	}
+	playlist, err := r.PlaylistService.Create(request, principal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create playlist: %w", err)
+	}
+	return playlistEntityToModel(playlist), nil
+}
+
+// UpdatePlaylist is the resolver for the updatePlaylist field.
+func (r *mutationResolver) UpdatePlaylist(ctx context.Context, id string, input model.UpdatePlaylistInput) (*model.Playlist, error) {
+	principal, err := requirePermission(ctx, auth.PermVideosWrite)
+	if err != nil {
+		return nil, err
+	}
+	request := dto.PlaylistUpdateRequest{Title: input.Title, Description: input.Description}
+	if input.Visibility != nil {
+		visibility := strings.ToLower(string(*input.Visibility))
+		request.Visibility = &visibility
+	}
+	if err := binding.Validator.ValidateStruct(request); err != nil {
+		return nil, err
+	}
+	playlist, err := r.PlaylistService.Update(id, request, principal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update playlist: %w", err)
+	}
+	return playlistEntityToModel(playlist), nil
+}
+
+// DeletePlaylist is the resolver for the deletePlaylist field.
+func (r *mutationResolver) DeletePlaylist(ctx context.Context, id string) (bool, error) {
+	principal, err := requirePermission(ctx, auth.PermVideosWrite)
+	if err != nil {
+		return false, err
+	}
+	if err := r.PlaylistService.Delete(id, principal); err != nil {
+		return false, fmt.Errorf("failed to delete playlist: %w", err)
+	}
+	return true, nil
+}
+
+// AddPlaylistItem is the resolver for the addPlaylistItem field.
+func (r *mutationResolver) AddPlaylistItem(ctx context.Context, playlistID string, videoID string, position *int32) (*model.Playlist, error) {
+	principal, err := requirePermission(ctx, auth.PermVideosWrite)
+	if err != nil {
+		return nil, err
+	}
+	var index *int
+	if position != nil {
+		if *position < 0 {
+			return nil, errors.New("position must not be negative")
+		}
+		value := int(*position)
+		index = &value
+	}
+	playlist, err := r.PlaylistService.AddItem(playlistID, videoID, index, principal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add video to playlist: %w", err)
+	}
+	return playlistEntityToModel(playlist), nil
+}
+
+// MovePlaylistItem is the resolver for the movePlaylistItem field.
+func (r *mutationResolver) MovePlaylistItem(ctx context.Context, playlistID string, itemID string, position int32) (*model.Playlist, error) {
+	principal, err := requirePermission(ctx, auth.PermVideosWrite)
+	if err != nil {
+		return nil, err
+	}
+	if position < 0 {
+		return nil, errors.New("position must not be negative")
+	}
+	playlist, err := r.PlaylistService.MoveItem(playlistID, itemID, int(position), principal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to move playlist item: %w", err)
+	}
+	return playlistEntityToModel(playlist), nil
+}
+
+// RemovePlaylistItem is the resolver for the removePlaylistItem field.
+func (r *mutationResolver) RemovePlaylistItem(ctx context.Context, playlistID string, itemID string) (*model.Playlist, error) {
+	principal, err := requirePermission(ctx, auth.PermVideosWrite)
+	if err != nil {
+		return nil, err
+	}
+	playlist, err := r.PlaylistService.RemoveItem(playlistID, itemID, principal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to remove playlist item: %w", err)
+	}
+	return playlistEntityToModel(playlist), nil
+}
+
+// Videos is the resolver for the videos field.
+func (r *personResolver) Videos(ctx context.Context, obj *model.Person) ([]*model.Video, error) {
+	if _, err := requirePermission(ctx, auth.PermVideosRead); err != nil {
+		return nil, err
+	}
+	videos, err := r.VideoService.GetByAuthor(obj.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch videos: %w", err)
+	}
+	result := make([]*model.Video, len(videos))
+	for i := range videos {
+		result[i] = videoEntityToModel(&videos[i])
+	}
+	return result, nil
+}
+
 // Videos is the resolver for the videos field.
 func (r *queryResolver) Videos(ctx context.Context) ([]*model.Video, error) {
+	if _, err := requirePermission(ctx, auth.PermVideosRead); err != nil {
+		return nil, err
+	}
 	videos, err := r.VideoService.GetAll()
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch videos: %w", err)
@@ -103,6 +264,9 @@ func (r *queryResolver) Videos(ctx context.Context) ([]*model.Video, error) {
 
 // Video is the resolver for the video field.
 func (r *queryResolver) Video(ctx context.Context, id string) (*model.Video, error) {
+	if _, err := requirePermission(ctx, auth.PermVideosRead); err != nil {
+		return nil, err
+	}
 	video, err := r.VideoService.GetByID(id)
 	if err != nil {
 		return nil, fmt.Errorf("video not found: %w", err)
@@ -110,46 +274,115 @@ func (r *queryResolver) Video(ctx context.Context, id string) (*model.Video, err
 	return videoEntityToModel(video), nil
 }
 
-// Helper function to convert entity.Video to model.Video
-func videoEntityToModel(v *entity.Video) *model.Video {
-	return &model.Video{
-		ID:          v.ID.String(),
-		Title:       v.Title,
-		Description: v.Description,
-		URL:         v.URL,
-		Author: &model.Person{
-			ID:        v.Author.ID.String(),
-			Name:      v.Author.Name,
-			Age:       int32(v.Author.Age),
-			Email:     v.Author.Email,
-			CreatedAt: v.Author.CreatedAt.Format(time.RFC3339),
-			UpdatedAt: v.Author.UpdatedAt.Format(time.RFC3339),
-		},
-		CreatedAt: v.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: v.UpdatedAt.Format(time.RFC3339),
+// VideosConnection is the resolver for the videosConnection field.
+func (r *queryResolver) VideosConnection(ctx context.Context, first *int32, after *string, last *int32, before *string, filter *model.VideoFilter, orderBy *model.VideoOrder) (*model.VideoConnection, error) {
+	if _, err := requirePermission(ctx, auth.PermVideosRead); err != nil {
+		return nil, err
 	}
+	query, err := videoQueryFromArgs(first, after, last, before, filter, orderBy)
+	if err != nil {
+		return nil, err
+	}
+	page, err := r.VideoService.List(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch videos: %w", err)
+	}
+	return videoPageToConnection(query, page), nil
+}
+
+// SearchVideos is the resolver for the searchVideos field.
+func (r *queryResolver) SearchVideos(ctx context.Context, query string, first *int32, offset *int32) ([]*model.VideoSearchResult, error) {
+	if _, err := requirePermission(ctx, auth.PermVideosRead); err != nil {
+		return nil, err
+	}
+	limit, skip, err := searchArgs(first, offset)
+	if err != nil {
+		return nil, err
+	}
+	results, err := r.SearchService.SearchVideos(query, limit, skip)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search videos: %w", err)
+	}
+	return searchResultsToModel(results), nil
+}
+
+// Playlist is the resolver for the playlist field.
+func (r *queryResolver) Playlist(ctx context.Context, id string) (*model.Playlist, error) {
+	principal, err := requirePermission(ctx, auth.PermVideosRead)
+	if err != nil {
+		return nil, err
+	}
+	playlist, err := r.PlaylistService.GetByID(id, principal)
+	if errors.Is(err, service.ErrPlaylistNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch playlist: %w", err)
+	}
+	return playlistEntityToModel(playlist), nil
+}
+
+// Playlists is the resolver for the playlists field.
+func (r *queryResolver) Playlists(ctx context.Context, ownerID *string) ([]*model.Playlist, error) {
+	principal, err := requirePermission(ctx, auth.PermVideosRead)
+	if err != nil {
+		return nil, err
+	}
+	owner := principal.UserID
+	if ownerID != nil {
+		owner = *ownerID
+	}
+	playlists, err := r.PlaylistService.GetByOwner(owner, principal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch playlists: %w", err)
+	}
+	result := make([]*model.Playlist, len(playlists))
+	for i := range playlists {
+		result[i] = playlistEntityToModel(&playlists[i])
+	}
+	return result, nil
+}
+
+// Authors is the resolver for the authors field.
+func (r *queryResolver) Authors(ctx context.Context) ([]*model.Person, error) {
+	if _, err := requirePermission(ctx, auth.PermVideosRead); err != nil {
+		return nil, err
+	}
+	authors, err := r.AuthorService.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch authors: %w", err)
+	}
+	result := make([]*model.Person, len(authors))
+	for i := range authors {
+		result[i] = personEntityToModel(&authors[i])
+	}
+	return result, nil
+}
+
+// Author is the resolver for the author field.
+func (r *queryResolver) Author(ctx context.Context, id string) (*model.Person, error) {
+	if _, err := requirePermission(ctx, auth.PermVideosRead); err != nil {
+		return nil, err
+	}
+	author, err := r.AuthorService.GetByID(id)
+	if errors.Is(err, service.ErrAuthorNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch author: %w", err)
+	}
+	return personEntityToModel(author), nil
 }
 
 // Mutation returns MutationResolver implementation.
 func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
 
+// Person returns PersonResolver implementation.
+func (r *Resolver) Person() PersonResolver { return &personResolver{r} }
+
 // Query returns QueryResolver implementation.
 func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
 
 type mutationResolver struct{ *Resolver }
+type personResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
-
-// !!! WARNING !!!
-// The code below was going to be deleted when updating resolvers. It has been copied here so you have
-// one last chance to move it out of harms way if you want. There are two reasons this happens:
-//  - When renaming or deleting a resolver the old code will be put in here. You can safely delete
-//    it when you're done.
-//  - You have helper methods in this file. Move them out to keep these resolver files clean.
-/*
-	func (r *mutationResolver) CreateTodo(ctx context.Context, input model.NewTodo) (*model.Todo, error) {
-	panic(fmt.Errorf("not implemented: CreateTodo - createTodo"))
-}
-func (r *queryResolver) Todos(ctx context.Context) ([]*model.Todo, error) {
-	panic(fmt.Errorf("not implemented: Todos - todos"))
-}
-*/
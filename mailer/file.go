@@ -0,0 +1,25 @@
+package mailer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+type fileMailer struct {
+	dir string
+}
+
+// NewFileMailer writes each message as an .eml file into dir
+func NewFileMailer(dir string) Mailer {
+	return &fileMailer{dir: dir}
+}
+
+func (m *fileMailer) Send(msg Message) error {
+	if err := os.MkdirAll(m.dir, os.ModePerm); err != nil {
+		return fmt.Errorf("failed to create mail directory: %w", err)
+	}
+	name := fmt.Sprintf("%d-%s.eml", time.Now().UnixNano(), filepath.Base(msg.To))
+	return os.WriteFile(filepath.Join(m.dir, name), format("", msg), 0o600)
+}
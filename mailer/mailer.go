@@ -0,0 +1,36 @@
+package mailer
+
+// Message is a plain-text email
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+type Mailer interface {
+	Send(msg Message) error
+}
+
+// Config describes how emails are sent
+type Config struct {
+	// Driver is "smtp" (default), "file" or "memory"
+	Driver string
+	// Dir is where the file mailer writes emails
+	Dir      string
+	SMTPAddr string
+	From     string
+	Username string
+	Password string
+}
+
+// New picks a mailer by the driver of config
+func New(config Config) Mailer {
+	switch config.Driver {
+	case "file":
+		return NewFileMailer(config.Dir)
+	case "memory":
+		return NewMemoryMailer()
+	default:
+		return NewSMTPMailer(config.SMTPAddr, config.From, config.Username, config.Password)
+	}
+}
@@ -0,0 +1,53 @@
+package mailer
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/smtp"
+	"time"
+)
+
+type smtpMailer struct {
+	addr string
+	from string
+	auth smtp.Auth
+}
+
+// NewSMTPMailer sends mail through the SMTP server at addr. Authentication
+// is only used when a username is given, which keeps local stand-ins such
+// as MailHog working without credentials.
+func NewSMTPMailer(addr string, from string, username string, password string) Mailer {
+	var auth smtp.Auth
+	if username != "" {
+		host, _, _ := net.SplitHostPort(addr)
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &smtpMailer{
+		addr: addr,
+		from: from,
+		auth: auth,
+	}
+}
+
+func (m *smtpMailer) Send(msg Message) error {
+	if err := smtp.SendMail(m.addr, m.auth, m.from, []string{msg.To}, format(m.from, msg)); err != nil {
+		return fmt.Errorf("failed to send mail: %w", err)
+	}
+	return nil
+}
+
+// format renders msg as an RFC 5322 message
+func format(from string, msg Message) []byte {
+	var buf bytes.Buffer
+	if from != "" {
+		fmt.Fprintf(&buf, "From: %s\r\n", from)
+	}
+	fmt.Fprintf(&buf, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&buf, "Subject: %s\r\n", msg.Subject)
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	buf.WriteString(msg.Body)
+	return buf.Bytes()
+}
@@ -1,45 +1,196 @@
 package main
 
 import (
+	"context"
+	"flag"
+	"fmt"
 	"io"
 	"log"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/muzammil-cyber/golang-gin/controller"
-	"github.com/muzammil-cyber/golang-gin/dto"
-	"github.com/muzammil-cyber/golang-gin/middleware"
-	"github.com/muzammil-cyber/golang-gin/repository"
-	"github.com/muzammil-cyber/golang-gin/service"
-	gindump "github.com/tpkeeper/gin-dump"
-
-	_ "github.com/muzammil-cyber/golang-gin/docs"
-
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
-
-	"github.com/99designs/gqlgen/graphql/handler"
-	"github.com/99designs/gqlgen/graphql/handler/extension"
-	"github.com/99designs/gqlgen/graphql/handler/lru"
-	"github.com/99designs/gqlgen/graphql/handler/transport"
-	"github.com/99designs/gqlgen/graphql/playground"
-	"github.com/muzammil-cyber/golang-gin/graph"
-	"github.com/vektah/gqlparser/v2/ast"
+	"github.com/muzammil-cyber/golang-gin/app"
+	"github.com/muzammil-cyber/golang-gin/config"
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/database/factory"
+	"github.com/muzammil-cyber/golang-gin/migrations"
+	"go.yaml.in/yaml/v3"
 )
 
-var (
-	videoRepository repository.VideoRepository = repository.NewVideoRepository()
-	videoService    service.VideoService       = service.New(videoRepository)
-	videoController controller.VideoController = controller.New(videoService)
-	jwtService      service.JWTService         = service.NewJWTService()
-	loginService    service.LoginService       = service.NewLoginService()
-	loginController controller.LoginController = controller.NewLoginController(loginService, jwtService)
-)
+// openDatabase connects to the database URL, or the SQLite file, of cfg
+func openDatabase(cfg *config.Config) database.Database {
+	db, err := factory.FromConfig(cfg.Database)
+	if err != nil {
+		log.Fatalf("failed to connect to the database: %v", err)
+	}
+	return db
+}
+
+func newMigrator(db database.Database) migrations.Migrator {
+	migrator, err := migrations.NewMigrator(db)
+	if err != nil {
+		log.Fatalf("failed to load migrations: %v", err)
+	}
+	return migrator
+}
+
+// prepareDatabase refuses to start on a database with pending migrations,
+// unless migrateOnStart is set, in which case it applies them first, and on
+// a database with migrations that need an SQLite module this build lacks
+func prepareDatabase(db database.Database, migrateOnStart bool) {
+	migrator := newMigrator(db)
+	if migrateOnStart {
+		applied, err := migrator.Up()
+		for _, migration := range applied {
+			log.Printf("applied migration %04d_%s", migration.Version, migration.Name)
+		}
+		if err != nil {
+			log.Fatalf("failed to migrate the database: %v", err)
+		}
+	}
+	pending, err := migrator.Pending()
+	if err != nil {
+		log.Fatalf("failed to check migrations: %v", err)
+	}
+	if len(pending) > 0 {
+		log.Fatalf("the database has %d pending migrations, starting with %04d_%s; run the migrate up command or set MIGRATE_ON_START=true",
+			len(pending), pending[0].Version, pending[0].Name)
+	}
+	// The triggers of such migrations would fail every write to the tables
+	// they are on
+	unsupported, err := migrator.Unsupported()
+	if err != nil {
+		log.Fatalf("failed to check migrations: %v", err)
+	}
+	if len(unsupported) > 0 {
+		log.Fatalf("migration %04d_%s needs SQLite with %s, which this build lacks; build with -tags sqlite_%s",
+			unsupported[0].Version, unsupported[0].Name, unsupported[0].Requires, unsupported[0].Requires)
+	}
+}
 
-func setupLogOutput() {
-	// Log to a file.
-	f, _ := os.Create("gin.log")
+// runMigrate runs the migrate command with its arguments and returns the
+// exit code:
+//
+//	migrate up          apply the pending migrations
+//	migrate down [N]    revert the last N migrations, 1 by default
+//	migrate status      list the migrations and when they were applied
+//	migrate create NAME add empty migration files under migrations/
+func runMigrate(cfg *config.Config, args []string) int {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, "usage: migrate up | down [N] | status | create NAME")
+		return 2
+	}
+	switch args[0] {
+	case "up":
+		applied, err := newMigrator(openDatabase(cfg)).Up()
+		for _, migration := range applied {
+			fmt.Printf("applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migrations")
+		}
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil || n <= 0 {
+				fmt.Fprintln(os.Stderr, "the number of migrations to revert must be positive")
+				return 2
+			}
+			steps = n
+		}
+		reverted, err := newMigrator(openDatabase(cfg)).Down(steps)
+		for _, migration := range reverted {
+			fmt.Printf("reverted %04d_%s\n", migration.Version, migration.Name)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	case "status":
+		statuses, err := newMigrator(openDatabase(cfg)).Status()
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = "applied " + status.AppliedAt.Format(time.RFC3339)
+			}
+			if status.Skipped {
+				appliedAt = fmt.Sprintf("skipped %s, SQLite lacks %s", status.AppliedAt.Format(time.RFC3339), status.Requires)
+			}
+			fmt.Printf("%04d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+	case "create":
+		if len(args) < 2 {
+			fmt.Fprintln(os.Stderr, "usage: migrate create NAME")
+			return 2
+		}
+		paths, err := migrations.Create("migrations", strings.Join(args[1:], "_"))
+		for _, path := range paths {
+			fmt.Println("created", path)
+		}
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			return 1
+		}
+	default:
+		fmt.Fprintf(os.Stderr, "unknown migrate command %q\n", args[0])
+		return 2
+	}
+	return 0
+}
+
+// runConfig runs the config command and returns the exit code:
+//
+//	config print [--redacted]  print the effective configuration as YAML
+//	                           and check that it is valid
+func runConfig(cfg *config.Config, args []string) int {
+	if len(args) == 0 || args[0] != "print" {
+		fmt.Fprintln(os.Stderr, "usage: config print [--redacted]")
+		return 2
+	}
+	flags := flag.NewFlagSet("config print", flag.ContinueOnError)
+	redact := flags.Bool("redacted", false, "replace secrets with REDACTED")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	printed := *cfg
+	if *redact {
+		printed = cfg.Redacted()
+	}
+	out, err := yaml.Marshal(printed)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		return 1
+	}
+	fmt.Print(string(out))
+	if err := cfg.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "invalid configuration:\n%v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// setupLogOutput copies the request log to gin.log and returns the file
+func setupLogOutput() *os.File {
+	f, err := os.Create("gin.log")
+	if err != nil {
+		log.Fatalf("failed to open the log file: %v", err)
+	}
 	gin.DefaultWriter = io.MultiWriter(f, os.Stdout)
+	return f
 }
 
 // @title Video Management API
@@ -62,101 +213,54 @@ func setupLogOutput() {
 // @name Authorization
 // @description Type "Bearer" followed by a space and JWT token. Example: "Bearer eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9..."
 
-func main() {
-	setupLogOutput()
-	server := gin.New()
-
-	server.Use(gin.Recovery(), middleware.Logger(),
-		gindump.Dump())
-
-	// server.Static("/static", "./templates/static")
-	// server.LoadHTMLGlob("templates/*.html")
-
-	// swagger
-	server.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+// @securityDefinitions.apikey ApiKeyAuth
+// @in header
+// @name X-API-Key
+// @description API key created through /api/keys. Accepted by every /api endpoint except key management.
 
-	// Public API routes (no JWT required)
-	server.POST("/auth/login", func(ctx *gin.Context) {
-		token := loginController.Login(ctx)
-		if token != "" {
-			ctx.JSON(200, dto.LoginResponse{Token: token})
+func main() {
+	cfg, args, err := config.Load(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	if len(args) > 0 {
+		switch args[0] {
+		case "migrate":
+			os.Exit(runMigrate(cfg, args[1:]))
+		case "config":
+			os.Exit(runConfig(cfg, args[1:]))
+		default:
+			fmt.Fprintf(os.Stderr, "unknown command %q; the commands are migrate and config\n", args[0])
+			os.Exit(2)
 		}
-	})
-
-	// Protected API routes (JWT required)
-	apiRoutes := server.Group("/api", middleware.JWTAuthMiddleware(jwtService))
-	{
-		apiRoutes.POST("/videos",
-			videoController.Save)
-
-		apiRoutes.GET("/videos", func(ctx *gin.Context) {
-			videos := videoController.GetAll(ctx)
-			ctx.JSON(200, videos)
-		})
-		apiRoutes.GET("/videos/:id", func(ctx *gin.Context) {
-			video := videoController.GetByID(ctx)
-			ctx.JSON(200, video)
-		})
-		apiRoutes.PUT("/videos/:id", func(ctx *gin.Context) {
-			updatedVideo := videoController.Update(ctx)
-			ctx.JSON(200, updatedVideo)
-		})
-		apiRoutes.DELETE("/videos/:id", func(ctx *gin.Context) {
-			err := videoController.Delete(ctx)
-			if err != nil {
-				ctx.JSON(500, dto.ErrorResponse{
-					Error: err.Error(),
-				})
-				return
-			}
-			ctx.JSON(200, dto.MessageResponse{
-				Message: "Video deleted successfully",
-			})
-		})
 	}
-
-	viewRoutes := server.Group("/view")
-	{
-		viewRoutes.GET("/", videoController.ShowAll)
+	if err := cfg.Validate(); err != nil {
+		log.Fatalf("invalid configuration:\n%v", err)
 	}
-
-	port := os.Getenv("PORT")
-
-	if port == "" {
-		// Elastic Beanstalk sets the default port to 5000
-		port = "5000"
+	db := openDatabase(cfg)
+	prepareDatabase(db, cfg.Database.MigrateOnStart)
+	logFile := setupLogOutput()
+	application, err := app.New(*cfg, db)
+	if err != nil {
+		log.Fatal(err)
 	}
-
-	srv := handler.New(graph.NewExecutableSchema(graph.Config{
-		Resolvers: &graph.Resolver{
-			VideoService: videoService,
-			JWTService:   jwtService,
-		},
-	}))
-
-	srv.AddTransport(transport.Options{})
-	srv.AddTransport(transport.GET{})
-	srv.AddTransport(transport.POST{})
-
-	srv.SetQueryCache(lru.New[*ast.QueryDocument](1000))
-
-	srv.Use(extension.Introspection{})
-	srv.Use(extension.AutomaticPersistedQuery{
-		Cache: lru.New[string](100),
+	application.OnShutdown("database", func(context.Context) error {
+		return db.Close()
 	})
-
-	server.GET("/", gin.WrapH(playground.Handler("GraphQL playground", "/query")))
-
-	// GraphQL endpoint for mutations
-	server.POST("/query", func(ctx *gin.Context) {
-		srv.ServeHTTP(ctx.Writer, ctx.Request)
+	application.OnShutdown("log file", func(context.Context) error {
+		gin.DefaultWriter = os.Stdout
+		return logFile.Close()
 	})
 
-	// GraphQL queries (GET) can be public or protected based on your needs
-	server.GET("/query", func(ctx *gin.Context) {
-		srv.ServeHTTP(ctx.Writer, ctx.Request)
-	})
-
-	log.Printf("connect to http://localhost:%s/ for GraphQL playground", port)
-	server.Run(":" + port)
+	// Shut down on SIGINT or SIGTERM; a second signal exits at once
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+	if err := application.Run(ctx); err != nil {
+		log.Fatal(err)
+	}
+	log.Print("shut down")
 }
@@ -0,0 +1,33 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+// APIKeyHeader carries API keys of machine clients
+const APIKeyHeader = "X-API-Key"
+
+// AuthMiddleware accepts either an API key in the X-API-Key header or a JWT
+// access token in the Authorization header.
+func AuthMiddleware(jwtService service.JWTService, tokenService service.TokenService, apiKeyService service.APIKeyService) gin.HandlerFunc {
+	jwtAuth := JWTAuthMiddleware(jwtService, tokenService)
+	return func(c *gin.Context) {
+		key := c.GetHeader(APIKeyHeader)
+		if key == "" {
+			jwtAuth(c)
+			return
+		}
+
+		principal, err := apiKeyService.Authenticate(key)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid, expired or revoked API key"})
+			return
+		}
+		setPrincipal(c, principal)
+		c.Set("api_key_id", principal.APIKeyID)
+		c.Next()
+	}
+}
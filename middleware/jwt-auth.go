@@ -2,37 +2,84 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
-	"github.com/golang-jwt/jwt/v5"
+	"github.com/muzammil-cyber/golang-gin/auth"
 	"github.com/muzammil-cyber/golang-gin/service"
 )
 
-func JWTAuthMiddleware(jwtService service.JWTService) gin.HandlerFunc {
+// JWTAuthMiddleware rejects requests without a valid, unrevoked access token
+func JWTAuthMiddleware(jwtService service.JWTService, tokenService service.TokenService) gin.HandlerFunc {
 	return func(c *gin.Context) {
-		const BEARER_SCHEMA = "Bearer "
-		authHeader := c.GetHeader("Authorization")
-		if authHeader == "" {
+		if c.GetHeader("Authorization") == "" {
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header is missing"})
 			return
 		}
+		if authenticate(c, jwtService, tokenService) {
+			c.Next()
+		}
+	}
+}
 
-		tokenString := authHeader[len(BEARER_SCHEMA):]
-		token, err := service.NewJWTService().ValidateToken(tokenString)
-
-		if err != nil || !token.Valid {
-			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+// OptionalJWTAuthMiddleware authenticates the request if it carries an
+// access token and lets anonymous requests through, leaving the decision
+// to the handler.
+func OptionalJWTAuthMiddleware(jwtService service.JWTService, tokenService service.TokenService) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
 			return
 		}
+		if authenticate(c, jwtService, tokenService) {
+			c.Next()
+		}
+	}
+}
 
-		claims := token.Claims.(jwt.MapClaims)
-		c.Set("username", claims["username"])
-		c.Set("is_admin", claims["is_admin"])
-		c.Set("issuer", claims["iss"])
-		c.Set("expires_at", claims["exp"])
-		c.Set("issued_at", claims["iat"])
+// authenticate validates the bearer token and stores its claims on the
+// context. It aborts the request and returns false if the token is unusable.
+func authenticate(c *gin.Context, jwtService service.JWTService, tokenService service.TokenService) bool {
+	const BEARER_SCHEMA = "Bearer "
+	authHeader := c.GetHeader("Authorization")
+	if !strings.HasPrefix(authHeader, BEARER_SCHEMA) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization header must use the Bearer scheme"})
+		return false
+	}
+
+	tokenString := authHeader[len(BEARER_SCHEMA):]
+	token, err := jwtService.ValidateToken(tokenString)
+
+	if err != nil || !token.Valid {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token"})
+		return false
+	}
 
-		c.Next()
+	claims := token.Claims.(*service.AccessClaims)
+	if claims.ID != "" && tokenService.IsRevoked(claims.ID) {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Token has been revoked"})
+		return false
+	}
 
+	setPrincipal(c, claims.Principal())
+	c.Set("jti", claims.ID)
+	c.Set("issuer", claims.Issuer)
+	if claims.ExpiresAt != nil {
+		c.Set("expires_at", claims.ExpiresAt.Time)
+	}
+	if claims.IssuedAt != nil {
+		c.Set("issued_at", claims.IssuedAt.Time)
 	}
+	return true
+}
+
+// setPrincipal exposes the caller to gin handlers and, through the request
+// context, to the GraphQL resolvers.
+func setPrincipal(c *gin.Context, principal *auth.Principal) {
+	c.Set("principal", principal)
+	c.Set("user_id", principal.UserID)
+	c.Set("username", principal.Username)
+	c.Set("roles", principal.Roles)
+	c.Set("permissions", principal.Permissions)
+	c.Request = c.Request.WithContext(auth.NewContext(c.Request.Context(), principal))
 }
@@ -0,0 +1,422 @@
+// Package migrations versions the database schema. Every migration is a
+// pair of SQL files per dialect, NNNN_name.up.sql and NNNN_name.down.sql,
+// embedded in the binary. The versions applied to a database are recorded
+// in its schema_migrations table.
+//
+// An SQLite migration that needs a module SQLite may be built without
+// starts with a line like "-- requires: fts5". Builds without the module
+// record it as skipped without running it, and it is pending again for
+// builds that have the module.
+package migrations
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"gorm.io/gorm"
+)
+
+//go:embed sqlite postgres mysql
+var files embed.FS
+
+// Dialects are the directories holding the migrations of each database
+var Dialects = []string{"sqlite", "postgres", "mysql"}
+
+// baselineVersion is the migration holding the schema that AutoMigrate
+// created before migrations were versioned
+const baselineVersion = 1
+
+var (
+	ErrUnsupportedDialect = errors.New("no migrations for this database dialect")
+	ErrUnknownVersion     = errors.New("the database has a migration this build does not know")
+)
+
+var fileNamePattern = regexp.MustCompile(`^(\d+)_([a-z0-9_]+)\.(up|down)\.sql$`)
+
+var requiresPattern = regexp.MustCompile(`(?m)^--\s*requires:\s*([a-z0-9_]+)\s*$`)
+
+// Migration is one versioned change of the schema
+type Migration struct {
+	Version int64
+	Name    string
+	// Requires is the SQLite module the migration needs, if any
+	Requires string
+	up       string
+	down     string
+}
+
+// MigrationStatus is a migration and when it was applied, if it was
+type MigrationStatus struct {
+	Migration
+	AppliedAt *time.Time
+	// Skipped is set when the migration was recorded without running
+	// because SQLite lacked the module it requires
+	Skipped bool
+}
+
+// schemaMigration is a row of schema_migrations
+type schemaMigration struct {
+	Version   int64  `gorm:"primaryKey;autoIncrement:false"`
+	Name      string `gorm:"type:varchar(255);not null"`
+	AppliedAt time.Time
+	Skipped   bool `gorm:"not null;default:false"`
+}
+
+func (schemaMigration) TableName() string {
+	return "schema_migrations"
+}
+
+type Migrator interface {
+	// Up applies the pending migrations in order and returns them
+	Up() ([]Migration, error)
+	// Down reverts the last steps applied migrations, newest first, and
+	// returns them
+	Down(steps int) ([]Migration, error)
+	// Status lists every migration, oldest first, with when it was applied
+	Status() ([]MigrationStatus, error)
+	// Pending lists the migrations that have not been applied, and those
+	// that were skipped but can run now, oldest first
+	Pending() ([]Migration, error)
+	// Unsupported lists the applied migrations that need an SQLite module
+	// this build lacks. The schema they created cannot be written to.
+	Unsupported() ([]Migration, error)
+}
+
+type migrator struct {
+	db         *gorm.DB
+	database   database.Database
+	migrations []Migration
+}
+
+// NewMigrator loads the migrations for the dialect of db
+func NewMigrator(db database.Database) (Migrator, error) {
+	dialect := db.GetDB().Dialector.Name()
+	if !isDialect(dialect) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedDialect, dialect)
+	}
+	migrations, err := load(files, dialect)
+	if err != nil {
+		return nil, err
+	}
+	return &migrator{
+		db:         db.GetDB(),
+		database:   db,
+		migrations: migrations,
+	}, nil
+}
+
+func (m *migrator) Up() ([]Migration, error) {
+	if err := m.prepare(); err != nil {
+		return nil, err
+	}
+	pending, err := m.Pending()
+	if err != nil {
+		return nil, err
+	}
+	for i, migration := range pending {
+		skip, err := m.lacksModule(migration)
+		if err != nil {
+			return pending[:i], err
+		}
+		err = m.db.Transaction(func(tx *gorm.DB) error {
+			if skip {
+				log.Printf("migration %04d_%s skipped: SQLite was built without %s", migration.Version, migration.Name, migration.Requires)
+			} else if err := execute(tx, migration.up); err != nil {
+				return err
+			}
+			// A migration that was skipped before is recorded anew
+			if err := tx.Delete(&schemaMigration{}, migration.Version).Error; err != nil {
+				return err
+			}
+			return tx.Create(&schemaMigration{Version: migration.Version, Name: migration.Name, AppliedAt: time.Now(), Skipped: skip}).Error
+		})
+		if err != nil {
+			return pending[:i], fmt.Errorf("migration %04d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+	}
+	return pending, nil
+}
+
+func (m *migrator) Down(steps int) ([]Migration, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	var reverted []Migration
+	for i := len(applied) - 1; i >= 0 && len(reverted) < steps; i-- {
+		migration, ok := m.find(applied[i].Version)
+		if !ok {
+			return reverted, fmt.Errorf("%w: %d", ErrUnknownVersion, applied[i].Version)
+		}
+		skipped := applied[i].Skipped
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			// A skipped migration has nothing to revert
+			if !skipped {
+				if err := execute(tx, migration.down); err != nil {
+					return err
+				}
+			}
+			return tx.Delete(&schemaMigration{}, migration.Version).Error
+		})
+		if err != nil {
+			return reverted, fmt.Errorf("reverting migration %04d_%s failed: %w", migration.Version, migration.Name, err)
+		}
+		reverted = append(reverted, migration)
+	}
+	return reverted, nil
+}
+
+func (m *migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied()
+	if err != nil {
+		return nil, err
+	}
+	rows := make(map[int64]schemaMigration, len(applied))
+	for _, row := range applied {
+		if _, ok := m.find(row.Version); !ok {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownVersion, row.Version)
+		}
+		rows[row.Version] = row
+	}
+	statuses := make([]MigrationStatus, len(m.migrations))
+	for i, migration := range m.migrations {
+		statuses[i].Migration = migration
+		if row, ok := rows[migration.Version]; ok {
+			statuses[i].AppliedAt = &row.AppliedAt
+			statuses[i].Skipped = row.Skipped
+		}
+	}
+	return statuses, nil
+}
+
+func (m *migrator) Pending() ([]Migration, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+	var pending []Migration
+	for _, status := range statuses {
+		if status.AppliedAt == nil {
+			pending = append(pending, status.Migration)
+			continue
+		}
+		if status.Skipped {
+			lacks, err := m.lacksModule(status.Migration)
+			if err != nil {
+				return nil, err
+			}
+			if !lacks {
+				pending = append(pending, status.Migration)
+			}
+		}
+	}
+	return pending, nil
+}
+
+func (m *migrator) Unsupported() ([]Migration, error) {
+	statuses, err := m.Status()
+	if err != nil {
+		return nil, err
+	}
+	var unsupported []Migration
+	for _, status := range statuses {
+		if status.AppliedAt == nil || status.Skipped {
+			continue
+		}
+		lacks, err := m.lacksModule(status.Migration)
+		if err != nil {
+			return nil, err
+		}
+		if lacks {
+			unsupported = append(unsupported, status.Migration)
+		}
+	}
+	return unsupported, nil
+}
+
+// applied returns the rows of schema_migrations, oldest first. A database
+// without the table has no migrations applied.
+func (m *migrator) applied() ([]schemaMigration, error) {
+	var rows []schemaMigration
+	if !m.db.Migrator().HasTable(&schemaMigration{}) {
+		return rows, nil
+	}
+	err := m.db.Order("version").Find(&rows).Error
+	return rows, err
+}
+
+// prepare creates schema_migrations. A database whose tables were created
+// by AutoMigrate, before migrations were versioned, is brought up to the
+// baseline schema and recorded at the baseline version.
+func (m *migrator) prepare() error {
+	if m.db.Migrator().HasTable(&schemaMigration{}) {
+		// Tables from before migrations could be skipped lack the column
+		if !m.db.Migrator().HasColumn(&schemaMigration{}, "Skipped") {
+			return m.db.Migrator().AddColumn(&schemaMigration{}, "Skipped")
+		}
+		return nil
+	}
+	legacy := m.db.Migrator().HasTable("videos")
+	if err := m.db.Migrator().CreateTable(&schemaMigration{}); err != nil {
+		return fmt.Errorf("failed to create schema_migrations: %w", err)
+	}
+	if !legacy {
+		return nil
+	}
+	if err := repository.UpgradeLegacySchema(m.database); err != nil {
+		return err
+	}
+	baseline, _ := m.find(baselineVersion)
+	return m.db.Create(&schemaMigration{Version: baseline.Version, Name: baseline.Name, AppliedAt: time.Now()}).Error
+}
+
+// lacksModule reports whether the migration requires an SQLite module this
+// build does not have
+func (m *migrator) lacksModule(migration Migration) (bool, error) {
+	if migration.Requires == "" {
+		return false, nil
+	}
+	var available bool
+	err := m.db.Raw("SELECT sqlite_compileoption_used(?)", "ENABLE_"+strings.ToUpper(migration.Requires)).Scan(&available).Error
+	return !available, err
+}
+
+func (m *migrator) find(version int64) (Migration, bool) {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return migration, true
+		}
+	}
+	return Migration{}, false
+}
+
+// Create writes empty up and down files for a new migration of every
+// dialect under dir, numbered after the newest migration there, and returns
+// their paths
+func Create(dir, name string) ([]string, error) {
+	name = strings.Trim(regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(name), "_"), "_")
+	if name == "" {
+		return nil, errors.New("the migration needs a name")
+	}
+	var version int64
+	for _, dialect := range Dialects {
+		migrations, err := load(os.DirFS(dir), dialect)
+		if err != nil {
+			return nil, err
+		}
+		if n := len(migrations); n > 0 && migrations[n-1].Version > version {
+			version = migrations[n-1].Version
+		}
+	}
+	version++
+
+	var paths []string
+	for _, dialect := range Dialects {
+		for _, direction := range []string{"up", "down"} {
+			path := filepath.Join(dir, dialect, fmt.Sprintf("%04d_%s.%s.sql", version, name, direction))
+			content := fmt.Sprintf("-- %s: %s %s\n", dialect, name, direction)
+			if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+				return paths, err
+			}
+			paths = append(paths, path)
+		}
+	}
+	return paths, nil
+}
+
+// load reads the migrations of a dialect, oldest first
+func load(fsys fs.FS, dialect string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dialect)
+	if err != nil {
+		return nil, err
+	}
+	byVersion := map[int64]*Migration{}
+	for _, entry := range entries {
+		match := fileNamePattern.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			continue
+		}
+		version, _ := strconv.ParseInt(match[1], 10, 64)
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("%s migration %d has two names: %s and %s", dialect, version, migration.Name, match[2])
+		}
+		content, err := fs.ReadFile(fsys, dialect+"/"+entry.Name())
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			migration.up = string(content)
+			if requires := requiresPattern.FindStringSubmatch(migration.up); requires != nil {
+				migration.Requires = requires[1]
+			}
+		} else {
+			migration.down = string(content)
+		}
+	}
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		migrations = append(migrations, *migration)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// execute runs the statements of a migration file. Statements end with a
+// semicolon at the end of a line, except for triggers, whose body between
+// a line ending with BEGIN and a line reading END; is one statement. Lines
+// starting with -- are comments. MySQL commits DDL statements as they
+// run, so a migration that fails there may be left partly applied.
+func execute(tx *gorm.DB, script string) error {
+	var statement strings.Builder
+	inTrigger := false
+	for _, line := range strings.Split(script, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "--") {
+			continue
+		}
+		statement.WriteString(line)
+		statement.WriteString("\n")
+		upper := strings.ToUpper(trimmed)
+		if strings.HasSuffix(upper, "BEGIN") {
+			inTrigger = true
+		} else if upper == "END;" {
+			inTrigger = false
+		}
+		if !inTrigger && strings.HasSuffix(trimmed, ";") {
+			sql := strings.TrimSuffix(strings.TrimSpace(statement.String()), ";")
+			if err := tx.Exec(sql).Error; err != nil {
+				return err
+			}
+			statement.Reset()
+		}
+	}
+	if strings.TrimSpace(statement.String()) != "" {
+		return tx.Exec(statement.String()).Error
+	}
+	return nil
+}
+
+func isDialect(name string) bool {
+	for _, dialect := range Dialects {
+		if dialect == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package migrations_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/database/sqlite"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/migrations"
+)
+
+var _ = Describe("Migrations", func() {
+	var db database.Database
+
+	BeforeEach(func() {
+		dir, err := os.MkdirTemp("", "migrations")
+		Expect(err).To(BeNil())
+		DeferCleanup(os.RemoveAll, dir)
+		db, err = sqlite.NewSQLiteDBAt(filepath.Join(dir, "sqlite.db"))
+		Expect(err).To(BeNil())
+	})
+
+	It("should apply and revert migrations", func() {
+		migrator, err := migrations.NewMigrator(db)
+		Expect(err).To(BeNil())
+		pending, err := migrator.Pending()
+		Expect(err).To(BeNil())
+		Expect(pending).NotTo(BeEmpty())
+
+		applied, err := migrator.Up()
+		Expect(err).To(BeNil())
+		Expect(applied).To(Equal(pending))
+		Expect(db.GetDB().Migrator().HasTable(&entity.Video{})).To(BeTrue())
+		statuses, err := migrator.Status()
+		Expect(err).To(BeNil())
+		for _, status := range statuses {
+			Expect(status.AppliedAt).NotTo(BeNil())
+		}
+		applied, err = migrator.Up()
+		Expect(err).To(BeNil())
+		Expect(applied).To(BeEmpty())
+
+		reverted, err := migrator.Down(len(pending))
+		Expect(err).To(BeNil())
+		Expect(reverted).To(HaveLen(len(pending)))
+		Expect(reverted[0].Version).To(Equal(pending[len(pending)-1].Version))
+		Expect(db.GetDB().Migrator().HasTable(&entity.Video{})).To(BeFalse())
+		pending, err = migrator.Pending()
+		Expect(err).To(BeNil())
+		Expect(pending).To(HaveLen(len(reverted)))
+	})
+
+	It("should baseline a database created by AutoMigrate", func() {
+		// A database from before migrations were versioned, with an old
+		// schema and data to keep
+		Expect(db.GetDB().Exec("CREATE TABLE videos (id text PRIMARY KEY, title varchar(100))").Error).To(Succeed())
+		Expect(db.GetDB().Exec("INSERT INTO videos (id, title) VALUES ('legacy', 'Legacy video')").Error).To(Succeed())
+
+		migrator, err := migrations.NewMigrator(db)
+		Expect(err).To(BeNil())
+		_, err = migrator.Up()
+		Expect(err).To(BeNil())
+		pending, err := migrator.Pending()
+		Expect(err).To(BeNil())
+		Expect(pending).To(BeEmpty())
+
+		Expect(db.GetDB().Migrator().HasColumn(&entity.Video{}, "Version")).To(BeTrue())
+		var title string
+		Expect(db.GetDB().Raw("SELECT title FROM videos WHERE id = 'legacy'").Scan(&title).Error).To(Succeed())
+		Expect(title).To(Equal("Legacy video"))
+	})
+
+	It("should create the FTS5 index only when SQLite has FTS5", func() {
+		Expect(db.GetDB().Exec("CREATE TABLE videos (id text PRIMARY KEY, title varchar(100), description text)").Error).To(Succeed())
+		Expect(db.GetDB().Exec("INSERT INTO videos (id, title) VALUES ('legacy', 'Legacy video')").Error).To(Succeed())
+
+		migrator, err := migrations.NewMigrator(db)
+		Expect(err).To(BeNil())
+		_, err = migrator.Up()
+		Expect(err).To(BeNil())
+
+		var fts5 bool
+		Expect(db.GetDB().Raw("SELECT sqlite_compileoption_used('ENABLE_FTS5')").Scan(&fts5).Error).To(Succeed())
+		Expect(db.GetDB().Migrator().HasTable("videos_fts")).To(Equal(fts5))
+		pending, err := migrator.Pending()
+		Expect(err).To(BeNil())
+		Expect(pending).To(BeEmpty())
+		unsupported, err := migrator.Unsupported()
+		Expect(err).To(BeNil())
+		Expect(unsupported).To(BeEmpty())
+
+		if !fts5 {
+			// The migration is recorded as skipped, and a database with the
+			// index could not be written to by this build
+			statuses, err := migrator.Status()
+			Expect(err).To(BeNil())
+			Expect(statuses[2].Name).To(Equal("videos_fts"))
+			Expect(statuses[2].Skipped).To(BeTrue())
+			Expect(db.GetDB().Exec("UPDATE schema_migrations SET skipped = false WHERE version = 3").Error).To(Succeed())
+			unsupported, err = migrator.Unsupported()
+			Expect(err).To(BeNil())
+			Expect(unsupported).To(HaveLen(1))
+			Expect(unsupported[0].Requires).To(Equal("fts5"))
+			return
+		}
+		// The video from before the index is indexed, and the triggers
+		// keep the index in sync
+		Expect(db.GetDB().Exec("UPDATE videos SET title = 'Renamed video' WHERE id = 'legacy'").Error).To(Succeed())
+		var titles []string
+		Expect(db.GetDB().Raw("SELECT title FROM videos_fts WHERE video_id = 'legacy'").Scan(&titles).Error).To(Succeed())
+		Expect(titles).To(Equal([]string{"Renamed video"}))
+
+		// A migration skipped by a build without FTS5 runs again
+		_, err = migrator.Down(1)
+		Expect(err).To(BeNil())
+		Expect(db.GetDB().Migrator().HasTable("videos_fts")).To(BeFalse())
+		Expect(db.GetDB().Exec("INSERT INTO schema_migrations (version, name, applied_at, skipped) VALUES (3, 'videos_fts', CURRENT_TIMESTAMP, true)").Error).To(Succeed())
+		pending, err = migrator.Pending()
+		Expect(err).To(BeNil())
+		Expect(pending).To(HaveLen(1))
+		_, err = migrator.Up()
+		Expect(err).To(BeNil())
+		Expect(db.GetDB().Migrator().HasTable("videos_fts")).To(BeTrue())
+
+		_, err = migrator.Down(1)
+		Expect(err).To(BeNil())
+		Expect(db.GetDB().Migrator().HasTable("videos_fts")).To(BeFalse())
+	})
+})
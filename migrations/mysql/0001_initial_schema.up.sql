@@ -0,0 +1,196 @@
+CREATE TABLE `people` (
+    `id` varchar(255),
+    `name` longtext,
+    `age` bigint,
+    `email` varchar(255),
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    `version` bigint NOT NULL DEFAULT 1,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_people_email` (`email`),
+    INDEX `idx_people_deleted_at` (`deleted_at`)
+);
+
+CREATE TABLE `tags` (
+    `name` varchar(50),
+    PRIMARY KEY (`name`)
+);
+
+CREATE TABLE `videos` (
+    `id` varchar(255),
+    `title` varchar(100),
+    `description` varchar(500),
+    `url` varchar(255),
+    `author_id` varchar(255),
+    `owner_id` varchar(255),
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    `version` bigint NOT NULL DEFAULT 1,
+    PRIMARY KEY (`id`),
+    INDEX `idx_videos_owner_id` (`owner_id`),
+    INDEX `idx_videos_deleted_at` (`deleted_at`),
+    CONSTRAINT `fk_videos_author` FOREIGN KEY (`author_id`) REFERENCES `people`(`id`)
+);
+
+CREATE TABLE `video_tags` (
+    `video_id` varchar(255),
+    `tag_name` varchar(50),
+    PRIMARY KEY (`video_id`,`tag_name`),
+    CONSTRAINT `fk_video_tags_video` FOREIGN KEY (`video_id`) REFERENCES `videos`(`id`),
+    CONSTRAINT `fk_video_tags_tag` FOREIGN KEY (`tag_name`) REFERENCES `tags`(`name`)
+);
+
+CREATE TABLE `video_revisions` (
+    `video_id` varchar(255),
+    `number` bigint,
+    `action` varchar(16) NOT NULL,
+    `actor_id` varchar(255),
+    `actor_name` varchar(100),
+    `changed_fields` longtext,
+    `before` longtext,
+    `after` longtext,
+    `restored_from` bigint,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`video_id`,`number`)
+);
+
+CREATE TABLE `playlists` (
+    `id` varchar(255),
+    `owner_id` varchar(255) NOT NULL,
+    `title` varchar(100) NOT NULL,
+    `description` varchar(500),
+    `visibility` varchar(16) NOT NULL,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    `version` bigint NOT NULL DEFAULT 1,
+    PRIMARY KEY (`id`),
+    INDEX `idx_playlists_owner_id` (`owner_id`),
+    INDEX `idx_playlists_deleted_at` (`deleted_at`)
+);
+
+CREATE TABLE `playlist_items` (
+    `id` varchar(255),
+    `playlist_id` varchar(255) NOT NULL,
+    `video_id` varchar(255) NOT NULL,
+    `position` bigint NOT NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_playlist_item_position` (`playlist_id`,`position`),
+    INDEX `idx_playlist_items_video_id` (`video_id`),
+    CONSTRAINT `fk_playlist_items_video` FOREIGN KEY (`video_id`) REFERENCES `videos`(`id`),
+    CONSTRAINT `fk_playlists_items` FOREIGN KEY (`playlist_id`) REFERENCES `playlists`(`id`)
+);
+
+CREATE TABLE `roles` (
+    `name` varchar(50),
+    `description` varchar(255),
+    `permissions` longtext,
+    PRIMARY KEY (`name`)
+);
+
+CREATE TABLE `users` (
+    `id` varchar(255),
+    `username` varchar(50) NOT NULL,
+    `email` varchar(255),
+    `email_verified_at` datetime(3) NULL,
+    `password_hash` varchar(255) NOT NULL,
+    `totp_secret` varchar(64),
+    `totp_last_step` bigint,
+    `mfa_enabled_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    `updated_at` datetime(3) NULL,
+    `deleted_at` datetime(3) NULL,
+    `version` bigint NOT NULL DEFAULT 1,
+    PRIMARY KEY (`id`),
+    UNIQUE INDEX `idx_users_username` (`username`),
+    UNIQUE INDEX `idx_users_email` (`email`),
+    INDEX `idx_users_deleted_at` (`deleted_at`)
+);
+
+CREATE TABLE `user_roles` (
+    `user_id` varchar(255),
+    `role_name` varchar(50),
+    PRIMARY KEY (`user_id`,`role_name`),
+    CONSTRAINT `fk_user_roles_user` FOREIGN KEY (`user_id`) REFERENCES `users`(`id`),
+    CONSTRAINT `fk_user_roles_role` FOREIGN KEY (`role_name`) REFERENCES `roles`(`name`)
+);
+
+CREATE TABLE `action_tokens` (
+    `id` varchar(255),
+    `user_id` varchar(255) NOT NULL,
+    `purpose` varchar(32) NOT NULL,
+    `expires_at` datetime(3) NOT NULL,
+    `used_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_action_tokens_user_id` (`user_id`)
+);
+
+CREATE TABLE `refresh_tokens` (
+    `id` varchar(255),
+    `family_id` varchar(255) NOT NULL,
+    `user_id` varchar(255) NOT NULL,
+    `expires_at` datetime(3) NOT NULL,
+    `replaced_by_id` varchar(255),
+    `revoked_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_refresh_tokens_family_id` (`family_id`),
+    INDEX `idx_refresh_tokens_user_id` (`user_id`)
+);
+
+CREATE TABLE `revoked_tokens` (
+    `jti` varchar(255),
+    `expires_at` datetime(3) NOT NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`jti`),
+    INDEX `idx_revoked_tokens_expires_at` (`expires_at`)
+);
+
+CREATE TABLE `api_keys` (
+    `id` varchar(255),
+    `user_id` varchar(255) NOT NULL,
+    `name` varchar(100) NOT NULL,
+    `prefix` varchar(32) NOT NULL,
+    `hash` varchar(64) NOT NULL,
+    `scopes` longtext,
+    `last_used_at` datetime(3) NULL,
+    `expires_at` datetime(3) NULL,
+    `revoked_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_api_keys_user_id` (`user_id`),
+    UNIQUE INDEX `idx_api_keys_prefix` (`prefix`)
+);
+
+CREATE TABLE `user_identities` (
+    `id` varchar(255),
+    `user_id` varchar(255) NOT NULL,
+    `issuer` varchar(255) NOT NULL,
+    `subject` varchar(255) NOT NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_user_identities_user_id` (`user_id`),
+    UNIQUE INDEX `idx_identity_subject` (`issuer`,`subject`)
+);
+
+CREATE TABLE `recovery_codes` (
+    `id` varchar(255),
+    `user_id` varchar(255) NOT NULL,
+    `hash` varchar(255) NOT NULL,
+    `used_at` datetime(3) NULL,
+    `created_at` datetime(3) NULL,
+    PRIMARY KEY (`id`),
+    INDEX `idx_recovery_codes_user_id` (`user_id`)
+);
+
+CREATE TABLE `login_attempts` (
+    `attempt_key` varchar(255),
+    `failures` bigint NOT NULL,
+    `last_failure_at` datetime(3) NOT NULL,
+    `locked_until` datetime(3) NULL,
+    PRIMARY KEY (`attempt_key`)
+);
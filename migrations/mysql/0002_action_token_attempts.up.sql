@@ -0,0 +1 @@
+ALTER TABLE `action_tokens` ADD COLUMN `attempts` bigint NOT NULL DEFAULT 0;
@@ -0,0 +1,2 @@
+-- mysql: videos_fts down
+-- Full-text search uses an SQLite FTS5 index; MySQL searches with LIKE.
@@ -0,0 +1,2 @@
+-- mysql: videos_fts up
+-- Full-text search uses an SQLite FTS5 index; MySQL searches with LIKE.
@@ -0,0 +1,196 @@
+CREATE TABLE "people" (
+    "id" text,
+    "name" text,
+    "age" bigint,
+    "email" varchar(255),
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_people_deleted_at" ON "people" ("deleted_at");
+CREATE UNIQUE INDEX "idx_people_email" ON "people" ("email");
+
+CREATE TABLE "tags" (
+    "name" varchar(50),
+    PRIMARY KEY ("name")
+);
+
+CREATE TABLE "videos" (
+    "id" text,
+    "title" varchar(100),
+    "description" varchar(500),
+    "url" varchar(255),
+    "author_id" text,
+    "owner_id" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_videos_author" FOREIGN KEY ("author_id") REFERENCES "people"("id")
+);
+CREATE INDEX "idx_videos_deleted_at" ON "videos" ("deleted_at");
+CREATE INDEX "idx_videos_owner_id" ON "videos" ("owner_id");
+
+CREATE TABLE "video_tags" (
+    "video_id" text,
+    "tag_name" varchar(50),
+    PRIMARY KEY ("video_id","tag_name"),
+    CONSTRAINT "fk_video_tags_video" FOREIGN KEY ("video_id") REFERENCES "videos"("id"),
+    CONSTRAINT "fk_video_tags_tag" FOREIGN KEY ("tag_name") REFERENCES "tags"("name")
+);
+
+CREATE TABLE "video_revisions" (
+    "video_id" text,
+    "number" bigint,
+    "action" varchar(16) NOT NULL,
+    "actor_id" text,
+    "actor_name" varchar(100),
+    "changed_fields" text,
+    "before" text,
+    "after" text,
+    "restored_from" bigint,
+    "created_at" timestamptz,
+    PRIMARY KEY ("video_id","number")
+);
+
+CREATE TABLE "playlists" (
+    "id" text,
+    "owner_id" text NOT NULL,
+    "title" varchar(100) NOT NULL,
+    "description" varchar(500),
+    "visibility" varchar(16) NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_playlists_deleted_at" ON "playlists" ("deleted_at");
+CREATE INDEX "idx_playlists_owner_id" ON "playlists" ("owner_id");
+
+CREATE TABLE "playlist_items" (
+    "id" text,
+    "playlist_id" text NOT NULL,
+    "video_id" text NOT NULL,
+    "position" bigint NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_playlist_items_video" FOREIGN KEY ("video_id") REFERENCES "videos"("id"),
+    CONSTRAINT "fk_playlists_items" FOREIGN KEY ("playlist_id") REFERENCES "playlists"("id")
+);
+CREATE INDEX "idx_playlist_items_video_id" ON "playlist_items" ("video_id");
+CREATE INDEX "idx_playlist_item_position" ON "playlist_items" ("playlist_id","position");
+
+CREATE TABLE "roles" (
+    "name" varchar(50),
+    "description" varchar(255),
+    "permissions" text,
+    PRIMARY KEY ("name")
+);
+
+CREATE TABLE "users" (
+    "id" text,
+    "username" varchar(50) NOT NULL,
+    "email" varchar(255),
+    "email_verified_at" timestamptz,
+    "password_hash" varchar(255) NOT NULL,
+    "totp_secret" varchar(64),
+    "totp_last_step" bigint,
+    "mfa_enabled_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    "deleted_at" timestamptz,
+    "version" bigint NOT NULL DEFAULT 1,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_users_deleted_at" ON "users" ("deleted_at");
+CREATE UNIQUE INDEX "idx_users_email" ON "users" ("email");
+CREATE UNIQUE INDEX "idx_users_username" ON "users" ("username");
+
+CREATE TABLE "user_roles" (
+    "user_id" text,
+    "role_name" varchar(50),
+    PRIMARY KEY ("user_id","role_name"),
+    CONSTRAINT "fk_user_roles_user" FOREIGN KEY ("user_id") REFERENCES "users"("id"),
+    CONSTRAINT "fk_user_roles_role" FOREIGN KEY ("role_name") REFERENCES "roles"("name")
+);
+
+CREATE TABLE "action_tokens" (
+    "id" text,
+    "user_id" text NOT NULL,
+    "purpose" varchar(32) NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "used_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_action_tokens_user_id" ON "action_tokens" ("user_id");
+
+CREATE TABLE "refresh_tokens" (
+    "id" text,
+    "family_id" text NOT NULL,
+    "user_id" text NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "replaced_by_id" text,
+    "revoked_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_refresh_tokens_user_id" ON "refresh_tokens" ("user_id");
+CREATE INDEX "idx_refresh_tokens_family_id" ON "refresh_tokens" ("family_id");
+
+CREATE TABLE "revoked_tokens" (
+    "jti" text,
+    "expires_at" timestamptz NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("jti")
+);
+CREATE INDEX "idx_revoked_tokens_expires_at" ON "revoked_tokens" ("expires_at");
+
+CREATE TABLE "api_keys" (
+    "id" text,
+    "user_id" text NOT NULL,
+    "name" varchar(100) NOT NULL,
+    "prefix" varchar(32) NOT NULL,
+    "hash" varchar(64) NOT NULL,
+    "scopes" text,
+    "last_used_at" timestamptz,
+    "expires_at" timestamptz,
+    "revoked_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_api_keys_prefix" ON "api_keys" ("prefix");
+CREATE INDEX "idx_api_keys_user_id" ON "api_keys" ("user_id");
+
+CREATE TABLE "user_identities" (
+    "id" text,
+    "user_id" text NOT NULL,
+    "issuer" varchar(255) NOT NULL,
+    "subject" varchar(255) NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX "idx_identity_subject" ON "user_identities" ("issuer","subject");
+CREATE INDEX "idx_user_identities_user_id" ON "user_identities" ("user_id");
+
+CREATE TABLE "recovery_codes" (
+    "id" text,
+    "user_id" text NOT NULL,
+    "hash" varchar(255) NOT NULL,
+    "used_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX "idx_recovery_codes_user_id" ON "recovery_codes" ("user_id");
+
+CREATE TABLE "login_attempts" (
+    "attempt_key" varchar(255),
+    "failures" bigint NOT NULL,
+    "last_failure_at" timestamptz NOT NULL,
+    "locked_until" timestamptz,
+    PRIMARY KEY ("attempt_key")
+);
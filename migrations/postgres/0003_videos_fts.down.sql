@@ -0,0 +1,2 @@
+-- postgres: videos_fts down
+-- Full-text search uses an SQLite FTS5 index; PostgreSQL searches with LIKE.
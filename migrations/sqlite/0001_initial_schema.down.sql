@@ -0,0 +1,17 @@
+DROP TABLE `login_attempts`;
+DROP TABLE `recovery_codes`;
+DROP TABLE `user_identities`;
+DROP TABLE `api_keys`;
+DROP TABLE `revoked_tokens`;
+DROP TABLE `refresh_tokens`;
+DROP TABLE `action_tokens`;
+DROP TABLE `user_roles`;
+DROP TABLE `users`;
+DROP TABLE `roles`;
+DROP TABLE `playlist_items`;
+DROP TABLE `playlists`;
+DROP TABLE `video_revisions`;
+DROP TABLE `video_tags`;
+DROP TABLE `videos`;
+DROP TABLE `tags`;
+DROP TABLE `people`;
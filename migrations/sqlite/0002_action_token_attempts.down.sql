@@ -0,0 +1 @@
+ALTER TABLE `action_tokens` DROP COLUMN `attempts`;
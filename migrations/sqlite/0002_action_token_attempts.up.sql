@@ -0,0 +1 @@
+ALTER TABLE `action_tokens` ADD COLUMN `attempts` integer NOT NULL DEFAULT 0;
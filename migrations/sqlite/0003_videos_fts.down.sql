@@ -0,0 +1,5 @@
+-- requires: fts5
+DROP TRIGGER IF EXISTS `videos_fts_delete`;
+DROP TRIGGER IF EXISTS `videos_fts_update`;
+DROP TRIGGER IF EXISTS `videos_fts_insert`;
+DROP TABLE IF EXISTS `videos_fts`;
@@ -0,0 +1,22 @@
+-- requires: fts5
+-- Full-text index of video titles and descriptions. The index is a
+-- separate FTS5 table that keeps its own copy of the text, so it does not
+-- depend on the rowids of videos, which VACUUM may change. Triggers keep it
+-- in sync with every write to videos, whether it goes through GORM or not.
+CREATE VIRTUAL TABLE IF NOT EXISTS `videos_fts` USING fts5(
+  video_id UNINDEXED, title, description, tokenize = 'unicode61 remove_diacritics 2'
+);
+CREATE TRIGGER IF NOT EXISTS `videos_fts_insert` AFTER INSERT ON `videos` BEGIN
+  INSERT INTO `videos_fts` (video_id, title, description) VALUES (new.id, new.title, new.description);
+END;
+CREATE TRIGGER IF NOT EXISTS `videos_fts_update` AFTER UPDATE OF title, description ON `videos` BEGIN
+  DELETE FROM `videos_fts` WHERE video_id = old.id;
+  INSERT INTO `videos_fts` (video_id, title, description) VALUES (new.id, new.title, new.description);
+END;
+CREATE TRIGGER IF NOT EXISTS `videos_fts_delete` AFTER DELETE ON `videos` BEGIN
+  DELETE FROM `videos_fts` WHERE video_id = old.id;
+END;
+-- Index the videos written before the index existed. Databases from before
+-- migrations were versioned may already have the index.
+INSERT INTO `videos_fts` (video_id, title, description)
+  SELECT id, title, description FROM `videos` WHERE id NOT IN (SELECT video_id FROM `videos_fts`);
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+)
+
+type ActionTokenRepository interface {
+	Save(token *entity.ActionToken) (*entity.ActionToken, error)
+	FindByID(id string) (*entity.ActionToken, error)
+	// MarkUsed consumes the token and reports whether this call was the one
+	// that consumed it, so a token can never be redeemed twice.
+	MarkUsed(id string) (bool, error)
+	// RecordFailure counts a wrong code entered against the token and
+	// consumes it once limit failures are reached. It reports whether this
+	// failure consumed the token.
+	RecordFailure(id string, limit int) (bool, error)
+	// InvalidateForUser consumes every outstanding token of the given purpose.
+	InvalidateForUser(userID string, purpose string) error
+}
+
+type actionTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewActionTokenRepository(db database.Database) ActionTokenRepository {
+	return &actionTokenRepository{
+		db: db.GetDB(),
+	}
+}
+
+func (r *actionTokenRepository) Save(token *entity.ActionToken) (*entity.ActionToken, error) {
+	if err := r.db.Create(token).Error; err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (r *actionTokenRepository) FindByID(id string) (*entity.ActionToken, error) {
+	var token entity.ActionToken
+	if err := r.db.First(&token, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *actionTokenRepository) MarkUsed(id string) (bool, error) {
+	result := r.db.Model(&entity.ActionToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *actionTokenRepository) RecordFailure(id string, limit int) (bool, error) {
+	err := r.db.Model(&entity.ActionToken{}).
+		Where("id = ?", id).
+		Update("attempts", gorm.Expr("attempts + 1")).Error
+	if err != nil {
+		return false, err
+	}
+	result := r.db.Model(&entity.ActionToken{}).
+		Where("id = ? AND attempts >= ? AND used_at IS NULL", id, limit).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *actionTokenRepository) InvalidateForUser(userID string, purpose string) error {
+	return r.db.Model(&entity.ActionToken{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", time.Now()).Error
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+)
+
+type APIKeyRepository interface {
+	Save(key *entity.APIKey) (*entity.APIKey, error)
+	FindByPrefix(prefix string) (*entity.APIKey, error)
+	FindByUser(userID string) ([]entity.APIKey, error)
+	Revoke(userID string, id string) (bool, error)
+	TouchLastUsed(id string, at time.Time) error
+}
+
+type apiKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewAPIKeyRepository(db database.Database) APIKeyRepository {
+	return &apiKeyRepository{
+		db: db.GetDB(),
+	}
+}
+
+func (r *apiKeyRepository) Save(key *entity.APIKey) (*entity.APIKey, error) {
+	if err := r.db.Create(key).Error; err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+func (r *apiKeyRepository) FindByPrefix(prefix string) (*entity.APIKey, error) {
+	var key entity.APIKey
+	if err := r.db.First(&key, "prefix = ?", prefix).Error; err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+func (r *apiKeyRepository) FindByUser(userID string) ([]entity.APIKey, error) {
+	var keys []entity.APIKey
+	if err := r.db.Where("user_id = ?", userID).Order("created_at DESC").Find(&keys).Error; err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// Revoke revokes a key of the given user and reports whether it existed
+func (r *apiKeyRepository) Revoke(userID string, id string) (bool, error) {
+	result := r.db.Model(&entity.APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *apiKeyRepository) TouchLastUsed(id string, at time.Time) error {
+	return r.db.Model(&entity.APIKey{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"strings"
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type AuthorRepository interface {
+	Save(author *entity.Person) error
+	Update(author *entity.Person) error
+	// Upsert creates the author, or updates the name and age of the author
+	// with the same email, and returns the stored author
+	Upsert(author *entity.Person) (*entity.Person, error)
+	FindByID(id string) (*entity.Person, error)
+	FindByEmail(email string) (*entity.Person, error)
+	FindAll() ([]entity.Person, error)
+	// CountVideos returns the number of videos by the author, including
+	// deleted videos that still refer to it
+	CountVideos(id string) (int64, error)
+	Delete(id string) error
+}
+
+type authorRepository struct {
+	db *gorm.DB
+}
+
+func NewAuthorRepository(db database.Database) AuthorRepository {
+	return &authorRepository{
+		db: db.GetDB(),
+	}
+}
+
+func (r *authorRepository) Save(author *entity.Person) error {
+	return r.db.Create(author).Error
+}
+
+// Update fails with ErrVersionConflict if the author was updated after it
+// was read
+func (r *authorRepository) Update(author *entity.Person) error {
+	return updateVersioned(r.db.Model(author).Select("*"), author, &author.Version)
+}
+
+func (r *authorRepository) Upsert(author *entity.Person) (*entity.Person, error) {
+	// An author deleted earlier still holds the email, so it is restored
+	err := r.db.Clauses(clause.OnConflict{
+		Columns: []clause.Column{{Name: "email"}},
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"name":       author.Name,
+			"age":        author.Age,
+			"updated_at": time.Now(),
+			"deleted_at": nil,
+		}),
+	}).Create(author).Error
+	if err != nil {
+		return nil, err
+	}
+	return r.FindByEmail(author.Email)
+}
+
+func (r *authorRepository) FindByID(id string) (*entity.Person, error) {
+	var author entity.Person
+	if err := r.db.First(&author, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+func (r *authorRepository) FindByEmail(email string) (*entity.Person, error) {
+	var author entity.Person
+	if err := r.db.First(&author, "email = ?", email).Error; err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+func (r *authorRepository) FindAll() ([]entity.Person, error) {
+	var authors []entity.Person
+	if err := r.db.Order("name, id").Find(&authors).Error; err != nil {
+		return nil, err
+	}
+	return authors, nil
+}
+
+func (r *authorRepository) CountVideos(id string) (int64, error) {
+	var count int64
+	err := r.db.Unscoped().Model(&entity.Video{}).Where("author_id = ?", id).Count(&count).Error
+	return count, err
+}
+
+// Delete removes the author for good, so that the email can be used again
+func (r *authorRepository) Delete(id string) error {
+	return r.db.Unscoped().Delete(&entity.Person{}, "id = ?", id).Error
+}
+
+// mergeDuplicateAuthors folds authors that share an email into the oldest
+// of them and lowercases all emails. Before emails were unique every video
+// created its own author, so this lets older databases take the unique
+// index.
+func mergeDuplicateAuthors(db *gorm.DB) error {
+	if !db.Migrator().HasTable(&entity.Person{}) || db.Migrator().HasIndex(&entity.Person{}, "Email") {
+		return nil
+	}
+	var people []struct {
+		ID    string
+		Email string
+	}
+	if err := db.Table("people").Select("id, email").Order("created_at, id").Scan(&people).Error; err != nil {
+		return err
+	}
+	return db.Transaction(func(tx *gorm.DB) error {
+		kept := map[string]string{}
+		for _, person := range people {
+			email := strings.ToLower(strings.TrimSpace(person.Email))
+			keep, seen := kept[email]
+			if !seen {
+				kept[email] = person.ID
+				if err := tx.Table("people").Where("id = ?", person.ID).Update("email", email).Error; err != nil {
+					return err
+				}
+				continue
+			}
+			if err := tx.Table("videos").Where("author_id = ?", person.ID).Update("author_id", keep).Error; err != nil {
+				return err
+			}
+			if err := tx.Exec("DELETE FROM people WHERE id = ?", person.ID).Error; err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
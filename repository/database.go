@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm/clause"
+)
+
+// UpgradeLegacySchema brings a database whose schema was created by
+// AutoMigrate, before migrations were versioned, up to the baseline
+// migration. Versioned migrations take over from there.
+func UpgradeLegacySchema(db database.Database) error {
+	gormDB := db.GetDB()
+	if err := mergeDuplicateAuthors(gormDB); err != nil {
+		return fmt.Errorf("failed to merge duplicate authors: %w", err)
+	}
+	err := gormDB.AutoMigrate(
+		&entity.Person{},
+		&entity.Tag{},
+		&entity.Video{},
+		&entity.VideoRevision{},
+		&entity.Playlist{},
+		&entity.PlaylistItem{},
+		&entity.Role{},
+		&entity.User{},
+		&entity.ActionToken{},
+		&entity.RefreshToken{},
+		&entity.RevokedToken{},
+		&entity.APIKey{},
+		&entity.UserIdentity{},
+		&entity.RecoveryCode{},
+		&entity.LoginAttempt{},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to upgrade the legacy schema: %w", err)
+	}
+	return nil
+}
+
+// SeedRoles keeps the built-in roles in sync with their definitions
+func SeedRoles(db database.Database) error {
+	err := db.GetDB().Clauses(clause.OnConflict{UpdateAll: true}).Create(&entity.DefaultRoles).Error
+	if err != nil {
+		return fmt.Errorf("failed to seed roles: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+package repository
+
+import (
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+)
+
+type IdentityRepository interface {
+	Save(identity *entity.UserIdentity) error
+	FindBySubject(issuer string, subject string) (*entity.UserIdentity, error)
+}
+
+type identityRepository struct {
+	db *gorm.DB
+}
+
+func NewIdentityRepository(db database.Database) IdentityRepository {
+	return &identityRepository{
+		db: db.GetDB(),
+	}
+}
+
+func (r *identityRepository) Save(identity *entity.UserIdentity) error {
+	return r.db.Create(identity).Error
+}
+
+func (r *identityRepository) FindBySubject(issuer string, subject string) (*entity.UserIdentity, error) {
+	var identity entity.UserIdentity
+	if err := r.db.First(&identity, "issuer = ? AND subject = ?", issuer, subject).Error; err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"sync"
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// memoryStorePruneSize is the number of keys above which the in-memory
+// store drops stale entries
+const memoryStorePruneSize = 10000
+
+// LoginAttemptStore keeps failed login counters. Find returns
+// gorm.ErrRecordNotFound for keys without recent failures.
+type LoginAttemptStore interface {
+	Find(key string) (*entity.LoginAttempt, error)
+	// RecordFailure counts a failed login and returns the updated record.
+	// The count starts over when the previous failure is older than since.
+	RecordFailure(key string, now time.Time, since time.Time) (*entity.LoginAttempt, error)
+	Lock(key string, until time.Time) error
+	Reset(key string) error
+}
+
+// NewLoginAttemptStore picks a store by kind: "memory" (default) or "db",
+// which shares counters between instances.
+func NewLoginAttemptStore(kind string, db database.Database) LoginAttemptStore {
+	if kind == "db" {
+		return NewDBLoginAttemptStore(db)
+	}
+	return NewMemoryLoginAttemptStore()
+}
+
+type dbLoginAttemptStore struct {
+	db *gorm.DB
+}
+
+func NewDBLoginAttemptStore(db database.Database) LoginAttemptStore {
+	return &dbLoginAttemptStore{
+		db: db.GetDB(),
+	}
+}
+
+func (s *dbLoginAttemptStore) Find(key string) (*entity.LoginAttempt, error) {
+	var attempt entity.LoginAttempt
+	if err := s.db.First(&attempt, "attempt_key = ?", key).Error; err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (s *dbLoginAttemptStore) RecordFailure(key string, now time.Time, since time.Time) (*entity.LoginAttempt, error) {
+	var attempt entity.LoginAttempt
+	err := s.db.Transaction(func(tx *gorm.DB) error {
+		// Count atomically so concurrent failures on several instances add up
+		err := tx.Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "attempt_key"}},
+			DoUpdates: clause.Assignments(map[string]interface{}{
+				"failures":        gorm.Expr("CASE WHEN login_attempts.last_failure_at < ? THEN 1 ELSE login_attempts.failures + 1 END", since),
+				"last_failure_at": now,
+			}),
+		}).Create(&entity.LoginAttempt{Key: key, Failures: 1, LastFailureAt: now}).Error
+		if err != nil {
+			return err
+		}
+		return tx.First(&attempt, "attempt_key = ?", key).Error
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (s *dbLoginAttemptStore) Lock(key string, until time.Time) error {
+	return s.db.Model(&entity.LoginAttempt{}).Where("attempt_key = ?", key).Update("locked_until", until).Error
+}
+
+func (s *dbLoginAttemptStore) Reset(key string) error {
+	return s.db.Delete(&entity.LoginAttempt{}, "attempt_key = ?", key).Error
+}
+
+type memoryLoginAttemptStore struct {
+	mu       sync.Mutex
+	attempts map[string]entity.LoginAttempt
+}
+
+// NewMemoryLoginAttemptStore returns a store local to this process
+func NewMemoryLoginAttemptStore() LoginAttemptStore {
+	return &memoryLoginAttemptStore{
+		attempts: map[string]entity.LoginAttempt{},
+	}
+}
+
+func (s *memoryLoginAttemptStore) Find(key string) (*entity.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	attempt, ok := s.attempts[key]
+	if !ok {
+		return nil, gorm.ErrRecordNotFound
+	}
+	return &attempt, nil
+}
+
+func (s *memoryLoginAttemptStore) RecordFailure(key string, now time.Time, since time.Time) (*entity.LoginAttempt, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.attempts) > memoryStorePruneSize {
+		s.prune(now, since)
+	}
+	attempt, ok := s.attempts[key]
+	if !ok || attempt.LastFailureAt.Before(since) {
+		attempt = entity.LoginAttempt{Key: key, LockedUntil: attempt.LockedUntil}
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = now
+	s.attempts[key] = attempt
+	return &attempt, nil
+}
+
+func (s *memoryLoginAttemptStore) Lock(key string, until time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if attempt, ok := s.attempts[key]; ok {
+		attempt.LockedUntil = &until
+		s.attempts[key] = attempt
+	}
+	return nil
+}
+
+func (s *memoryLoginAttemptStore) Reset(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.attempts, key)
+	return nil
+}
+
+// prune drops counters that are neither recent nor locked
+func (s *memoryLoginAttemptStore) prune(now time.Time, since time.Time) {
+	for key, attempt := range s.attempts {
+		if attempt.LastFailureAt.Before(since) && (attempt.LockedUntil == nil || attempt.LockedUntil.Before(now)) {
+			delete(s.attempts, key)
+		}
+	}
+}
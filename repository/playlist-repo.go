@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+)
+
+type PlaylistRepository interface {
+	Save(playlist *entity.Playlist) error
+	// Update saves the playlist's own fields, not its items. It fails with
+	// ErrVersionConflict if the playlist was updated after it was read.
+	Update(playlist *entity.Playlist) error
+	// FindByID returns the playlist with its items in order. Items whose
+	// video was deleted are left out.
+	FindByID(id string) (*entity.Playlist, error)
+	// FindByOwner returns the owner's playlists without their items,
+	// only the public ones if publicOnly is set
+	FindByOwner(ownerID string, publicOnly bool) ([]entity.Playlist, error)
+	Delete(id string) error
+	AddItem(item *entity.PlaylistItem) error
+	// MoveItem changes the position of an item
+	MoveItem(itemID string, position int64) error
+	RemoveItem(playlistID string, itemID string) error
+	// Renumber sets the positions of the items to their index times gap
+	Renumber(items []entity.PlaylistItem, gap int64) error
+}
+
+type playlistRepository struct {
+	db *gorm.DB
+}
+
+func NewPlaylistRepository(db database.Database) PlaylistRepository {
+	return &playlistRepository{
+		db: db.GetDB(),
+	}
+}
+
+func (r *playlistRepository) Save(playlist *entity.Playlist) error {
+	return r.db.Omit("Items").Create(playlist).Error
+}
+
+func (r *playlistRepository) Update(playlist *entity.Playlist) error {
+	return updateVersioned(r.db.Model(playlist).Select("*").Omit("Items"), playlist, &playlist.Version)
+}
+
+func (r *playlistRepository) FindByID(id string) (*entity.Playlist, error) {
+	var playlist entity.Playlist
+	err := r.db.
+		Preload("Items", func(db *gorm.DB) *gorm.DB {
+			return db.Joins("JOIN videos ON videos.id = playlist_items.video_id AND videos.deleted_at IS NULL").
+				Order("playlist_items.position, playlist_items.id")
+		}).
+		Preload("Items.Video.Author").
+		Preload("Items.Video.Tags", func(db *gorm.DB) *gorm.DB {
+			return db.Order("name")
+		}).
+		First(&playlist, "id = ?", id).Error
+	if err != nil {
+		return nil, err
+	}
+	return &playlist, nil
+}
+
+func (r *playlistRepository) FindByOwner(ownerID string, publicOnly bool) ([]entity.Playlist, error) {
+	query := r.db.Where("owner_id = ?", ownerID)
+	if publicOnly {
+		query = query.Where("visibility = ?", entity.VisibilityPublic)
+	}
+	var playlists []entity.Playlist
+	if err := query.Order("created_at DESC").Find(&playlists).Error; err != nil {
+		return nil, err
+	}
+	return playlists, nil
+}
+
+func (r *playlistRepository) Delete(id string) error {
+	return r.db.Delete(&entity.Playlist{}, "id = ?", id).Error
+}
+
+func (r *playlistRepository) AddItem(item *entity.PlaylistItem) error {
+	return r.db.Omit("Video").Create(item).Error
+}
+
+func (r *playlistRepository) MoveItem(itemID string, position int64) error {
+	return r.db.Model(&entity.PlaylistItem{}).Where("id = ?", itemID).Update("position", position).Error
+}
+
+func (r *playlistRepository) RemoveItem(playlistID string, itemID string) error {
+	result := r.db.Where("playlist_id = ?", playlistID).Delete(&entity.PlaylistItem{}, "id = ?", itemID)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (r *playlistRepository) Renumber(items []entity.PlaylistItem, gap int64) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		for i, item := range items {
+			err := tx.Model(&entity.PlaylistItem{}).Where("id = ?", item.ID).Update("position", int64(i+1)*gap).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+)
+
+type RecoveryCodeRepository interface {
+	// Replace discards the user's recovery codes and stores new ones
+	Replace(userID string, codes []entity.RecoveryCode) error
+	FindUnused(userID string) ([]entity.RecoveryCode, error)
+	// MarkUsed consumes the code and reports whether this call consumed it
+	MarkUsed(id string) (bool, error)
+	DeleteForUser(userID string) error
+}
+
+type recoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewRecoveryCodeRepository(db database.Database) RecoveryCodeRepository {
+	return &recoveryCodeRepository{
+		db: db.GetDB(),
+	}
+}
+
+func (r *recoveryCodeRepository) Replace(userID string, codes []entity.RecoveryCode) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+func (r *recoveryCodeRepository) FindUnused(userID string) ([]entity.RecoveryCode, error) {
+	var codes []entity.RecoveryCode
+	if err := r.db.Where("user_id = ? AND used_at IS NULL", userID).Find(&codes).Error; err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (r *recoveryCodeRepository) MarkUsed(id string) (bool, error) {
+	result := r.db.Model(&entity.RecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", time.Now())
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+func (r *recoveryCodeRepository) DeleteForUser(userID string) error {
+	return r.db.Where("user_id = ?", userID).Delete(&entity.RecoveryCode{}).Error
+}
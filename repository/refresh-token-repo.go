@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+)
+
+type RefreshTokenRepository interface {
+	Save(token *entity.RefreshToken) (*entity.RefreshToken, error)
+	FindByID(id string) (*entity.RefreshToken, error)
+	// Rotate marks the token as replaced and stores its replacement. It
+	// reports false if the token was already rotated or revoked.
+	Rotate(id string, replacement *entity.RefreshToken) (bool, error)
+	RevokeFamily(familyID string) error
+	RevokeAllForUser(userID string) error
+}
+
+type refreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db database.Database) RefreshTokenRepository {
+	return &refreshTokenRepository{
+		db: db.GetDB(),
+	}
+}
+
+func (r *refreshTokenRepository) Save(token *entity.RefreshToken) (*entity.RefreshToken, error) {
+	if err := r.db.Create(token).Error; err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (r *refreshTokenRepository) FindByID(id string) (*entity.RefreshToken, error) {
+	var token entity.RefreshToken
+	if err := r.db.First(&token, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &token, nil
+}
+
+func (r *refreshTokenRepository) Rotate(id string, replacement *entity.RefreshToken) (bool, error) {
+	rotated := false
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(replacement).Error; err != nil {
+			return err
+		}
+		result := tx.Model(&entity.RefreshToken{}).
+			Where("id = ? AND replaced_by_id IS NULL AND revoked_at IS NULL", id).
+			Update("replaced_by_id", replacement.ID)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected != 1 {
+			// Lost the race against another rotation; drop the replacement
+			return gorm.ErrRecordNotFound
+		}
+		rotated = true
+		return nil
+	})
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	return rotated, err
+}
+
+func (r *refreshTokenRepository) RevokeFamily(familyID string) error {
+	return r.db.Model(&entity.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", time.Now()).Error
+}
+
+func (r *refreshTokenRepository) RevokeAllForUser(userID string) error {
+	return r.db.Model(&entity.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", time.Now()).Error
+}
@@ -0,0 +1,52 @@
+package repository
+
+import (
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+)
+
+// RevisionRepository reads the revision history of videos. Revisions are
+// written by VideoRepository and TrashRepository along with the change
+// they record.
+type RevisionRepository interface {
+	// FindByVideo returns the revisions of a video, oldest first
+	FindByVideo(videoID string) ([]entity.VideoRevision, error)
+	FindOne(videoID string, number int64) (*entity.VideoRevision, error)
+}
+
+type revisionRepository struct {
+	db *gorm.DB
+}
+
+func NewRevisionRepository(db database.Database) RevisionRepository {
+	return &revisionRepository{
+		db: db.GetDB(),
+	}
+}
+
+func (r *revisionRepository) FindByVideo(videoID string) ([]entity.VideoRevision, error) {
+	var revisions []entity.VideoRevision
+	if err := r.db.Where("video_id = ?", videoID).Order("number").Find(&revisions).Error; err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+func (r *revisionRepository) FindOne(videoID string, number int64) (*entity.VideoRevision, error) {
+	var revision entity.VideoRevision
+	if err := r.db.First(&revision, "video_id = ? AND number = ?", videoID, number).Error; err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// recordRevision saves revision as the change that took its video to
+// version. It does nothing if revision is nil.
+func recordRevision(tx *gorm.DB, version int64, revision *entity.VideoRevision) error {
+	if revision == nil {
+		return nil
+	}
+	revision.Number = version
+	return tx.Create(revision).Error
+}
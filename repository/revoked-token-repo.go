@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type RevokedTokenRepository interface {
+	Revoke(jti string, expiresAt time.Time) error
+	IsRevoked(jti string) (bool, error)
+}
+
+type revokedTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRevokedTokenRepository(db database.Database) RevokedTokenRepository {
+	return &revokedTokenRepository{
+		db: db.GetDB(),
+	}
+}
+
+func (r *revokedTokenRepository) Revoke(jti string, expiresAt time.Time) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		// Entries for tokens that have expired by now are dead weight
+		if err := tx.Where("expires_at < ?", time.Now()).Delete(&entity.RevokedToken{}).Error; err != nil {
+			return err
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).
+			Create(&entity.RevokedToken{JTI: jti, ExpiresAt: expiresAt}).Error
+	})
+}
+
+func (r *revokedTokenRepository) IsRevoked(jti string) (bool, error) {
+	var token entity.RevokedToken
+	err := r.db.First(&token, "jti = ?", jti).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
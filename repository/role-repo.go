@@ -0,0 +1,47 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+)
+
+type RoleRepository interface {
+	FindAll() ([]entity.Role, error)
+	// FindByNames returns the named roles and fails if any of them is unknown
+	FindByNames(names []string) ([]entity.Role, error)
+}
+
+type roleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db database.Database) RoleRepository {
+	return &roleRepository{
+		db: db.GetDB(),
+	}
+}
+
+func (r *roleRepository) FindAll() ([]entity.Role, error) {
+	var roles []entity.Role
+	if err := r.db.Order("name").Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+func (r *roleRepository) FindByNames(names []string) ([]entity.Role, error) {
+	var roles []entity.Role
+	if len(names) == 0 {
+		return roles, nil
+	}
+	if err := r.db.Where("name IN ?", names).Find(&roles).Error; err != nil {
+		return nil, err
+	}
+	if len(roles) != len(names) {
+		return nil, fmt.Errorf("unknown role in %v: %w", names, gorm.ErrRecordNotFound)
+	}
+	return roles, nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"github.com/muzammil-cyber/golang-gin/database"
+	"gorm.io/gorm"
+)
+
+// TagCount is a tag and the number of videos carrying it
+type TagCount struct {
+	Name  string
+	Count int64
+}
+
+type TagRepository interface {
+	// FindAllWithCounts returns the tags in use, most used first
+	FindAllWithCounts() ([]TagCount, error)
+}
+
+type tagRepository struct {
+	db *gorm.DB
+}
+
+func NewTagRepository(db database.Database) TagRepository {
+	return &tagRepository{
+		db: db.GetDB(),
+	}
+}
+
+func (r *tagRepository) FindAllWithCounts() ([]TagCount, error) {
+	var counts []TagCount
+	err := r.db.Table("video_tags").
+		Select("video_tags.tag_name AS name, COUNT(*) AS count").
+		Joins("JOIN videos ON videos.id = video_tags.video_id AND videos.deleted_at IS NULL").
+		Group("video_tags.tag_name").
+		Order("count DESC, name").
+		Scan(&counts).Error
+	if err != nil {
+		return nil, err
+	}
+	return counts, nil
+}
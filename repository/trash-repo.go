@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"errors"
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+)
+
+// TrashRepository reaches the videos that were soft-deleted
+type TrashRepository interface {
+	// FindAll returns deleted videos, most recently deleted first, only
+	// those of the owner unless ownerID is empty
+	FindAll(ownerID string) ([]entity.Video, error)
+	// FindByID returns a deleted video
+	FindByID(id string) (*entity.Video, error)
+	// Restore undeletes a video, increases its version and records the
+	// revision, if any, numbered with the new version
+	Restore(id string, revision *entity.VideoRevision) error
+	// Purge removes a deleted video for good, along with its tags and
+	// playlist items. Its revisions are kept and the revision, if any, is
+	// recorded as the last one.
+	Purge(id string, revision *entity.VideoRevision) error
+	// PurgeDeletedBefore purges the videos deleted before the given time,
+	// recording the revision returned for each, and returns how many there
+	// were
+	PurgeDeletedBefore(before time.Time, revision func(video *entity.Video) *entity.VideoRevision) (int64, error)
+}
+
+type trashRepository struct {
+	db *gorm.DB
+}
+
+func NewTrashRepository(db database.Database) TrashRepository {
+	return &trashRepository{
+		db: db.GetDB(),
+	}
+}
+
+// trashed limits a query to deleted videos
+func (r *trashRepository) trashed() *gorm.DB {
+	return r.db.Unscoped().Where("videos.deleted_at IS NOT NULL")
+}
+
+func (r *trashRepository) FindAll(ownerID string) ([]entity.Video, error) {
+	query := preloadVideo(r.trashed())
+	if ownerID != "" {
+		query = query.Where("owner_id = ?", ownerID)
+	}
+	var videos []entity.Video
+	if err := query.Order("deleted_at DESC, id").Find(&videos).Error; err != nil {
+		return nil, err
+	}
+	return videos, nil
+}
+
+func (r *trashRepository) FindByID(id string) (*entity.Video, error) {
+	var video entity.Video
+	if err := preloadVideo(r.trashed()).First(&video, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &video, nil
+}
+
+func (r *trashRepository) Restore(id string, revision *entity.VideoRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Unscoped().Model(&entity.Video{}).Where("id = ? AND deleted_at IS NOT NULL", id).UpdateColumns(map[string]interface{}{
+			"deleted_at": nil,
+			"updated_at": time.Now(),
+			"version":    gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return gorm.ErrRecordNotFound
+		}
+		var version int64
+		if err := tx.Model(&entity.Video{}).Where("id = ?", id).Pluck("version", &version).Error; err != nil {
+			return err
+		}
+		return recordRevision(tx, version, revision)
+	})
+}
+
+func (r *trashRepository) Purge(id string, revision *entity.VideoRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		return purgeVideo(tx, id, revision)
+	})
+}
+
+func (r *trashRepository) PurgeDeletedBefore(before time.Time, revision func(video *entity.Video) *entity.VideoRevision) (int64, error) {
+	var ids []string
+	err := r.trashed().Model(&entity.Video{}).Where("deleted_at < ?", before).Pluck("id", &ids).Error
+	if err != nil {
+		return 0, err
+	}
+	var purged int64
+	for _, id := range ids {
+		// One transaction per video keeps the database available to requests
+		err := r.db.Transaction(func(tx *gorm.DB) error {
+			var video entity.Video
+			if err := preloadVideo(tx.Unscoped().Where("videos.deleted_at IS NOT NULL")).First(&video, "id = ?", id).Error; err != nil {
+				return err
+			}
+			return purgeVideo(tx, id, revision(&video))
+		})
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			// Restored or purged in the meantime
+			continue
+		}
+		if err != nil {
+			return purged, err
+		}
+		purged++
+	}
+	return purged, nil
+}
+
+// purgeVideo hard-deletes a deleted video and the rows referring to it,
+// except for its revisions, and records the revision numbered after the
+// video's last version. The rows referring to the video go first for
+// databases that enforce foreign keys.
+func purgeVideo(tx *gorm.DB, id string, revision *entity.VideoRevision) error {
+	var version int64
+	if err := tx.Unscoped().Model(&entity.Video{}).Where("id = ?", id).Pluck("version", &version).Error; err != nil {
+		return err
+	}
+	if err := tx.Exec("DELETE FROM video_tags WHERE video_id = ?", id).Error; err != nil {
+		return err
+	}
+	if err := tx.Where("video_id = ?", id).Delete(&entity.PlaylistItem{}).Error; err != nil {
+		return err
+	}
+	result := tx.Unscoped().Where("deleted_at IS NOT NULL").Delete(&entity.Video{}, "id = ?", id)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		// Rolls the deletions back if the video is not in the trash
+		return gorm.ErrRecordNotFound
+	}
+	return recordRevision(tx, version+1, revision)
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+)
+
+type UserRepository interface {
+	Save(user *entity.User) (*entity.User, error)
+	Update(user *entity.User) error
+	FindByID(id string) (*entity.User, error)
+	FindByUsername(username string) (*entity.User, error)
+	FindByEmail(email string) (*entity.User, error)
+	FindAll() ([]entity.User, error)
+	SetRoles(user *entity.User, roles []entity.Role) error
+}
+
+type userRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db database.Database) UserRepository {
+	return &userRepository{
+		db: db.GetDB(),
+	}
+}
+
+func (r *userRepository) Save(user *entity.User) (*entity.User, error) {
+	if err := r.db.Create(user).Error; err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+func (r *userRepository) Update(user *entity.User) error {
+	// Roles are changed through SetRoles only
+	return r.db.Omit("Roles").Save(user).Error
+}
+
+func (r *userRepository) FindByID(id string) (*entity.User, error) {
+	var user entity.User
+	if err := r.db.Preload("Roles").First(&user, "id = ?", id).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByUsername(username string) (*entity.User, error) {
+	var user entity.User
+	if err := r.db.Preload("Roles").First(&user, "username = ?", username).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindByEmail(email string) (*entity.User, error) {
+	var user entity.User
+	if err := r.db.Preload("Roles").First(&user, "email = ?", email).Error; err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+func (r *userRepository) FindAll() ([]entity.User, error) {
+	var users []entity.User
+	if err := r.db.Preload("Roles").Order("username").Find(&users).Error; err != nil {
+		return nil, err
+	}
+	return users, nil
+}
+
+func (r *userRepository) SetRoles(user *entity.User, roles []entity.Role) error {
+	return r.db.Model(user).Association("Roles").Replace(roles)
+}
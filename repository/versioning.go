@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"errors"
+
+	"gorm.io/gorm"
+)
+
+// ErrVersionConflict is returned when a row was updated after it was read
+var ErrVersionConflict = errors.New("the record was changed by someone else")
+
+// updateVersioned runs the update prepared in tx with value if the stored
+// version still equals *version, and increases the version. tx must select
+// the version column.
+func updateVersioned(tx *gorm.DB, value interface{}, version *int64) error {
+	current := *version
+	*version = current + 1
+	result := tx.Where("version = ?", current).Updates(value)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrVersionConflict
+	}
+	if result.Error != nil {
+		*version = current
+	}
+	return result.Error
+}
@@ -0,0 +1,207 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+)
+
+// Sortable video columns
+const (
+	VideoSortCreatedAt = "created_at"
+	VideoSortUpdatedAt = "updated_at"
+	VideoSortTitle     = "title"
+)
+
+const (
+	DefaultVideoPageSize = 20
+	MaxVideoPageSize     = 100
+)
+
+var (
+	ErrInvalidCursor = errors.New("invalid cursor")
+	ErrInvalidSort   = errors.New("unsupported sort field")
+)
+
+// VideoQuery selects a page of videos. The zero value returns the first
+// page of all videos, oldest first.
+type VideoQuery struct {
+	// Limit is the page size, DefaultVideoPageSize when zero
+	Limit int
+	// After and Before are opaque cursors; only videos between them are
+	// returned
+	After  string
+	Before string
+	// FromEnd takes the last Limit videos instead of the first, for paging
+	// backwards from Before
+	FromEnd bool
+	// Sort is one of the VideoSort constants, VideoSortCreatedAt when empty
+	Sort string
+	Desc bool
+
+	AuthorID      string
+	OwnerID       string
+	TitleContains string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// Tags keeps videos with any of the tags, or with all of them if
+	// MatchAllTags is set
+	Tags         []string
+	MatchAllTags bool
+}
+
+// VideoPage is one page of a VideoQuery
+type VideoPage struct {
+	Videos []entity.Video
+	// NextCursor continues after the last video, empty on the last page
+	NextCursor string
+	// HasNext and HasPrevious report whether videos follow the page or
+	// precede it
+	HasNext     bool
+	HasPrevious bool
+	// Total is the number of videos matching the filters on all pages
+	Total int64
+}
+
+// videoCursor is the position of a video in a sort order. It carries the
+// sort so that a cursor cannot be replayed against a different order.
+type videoCursor struct {
+	Sort  string `json:"s"`
+	Desc  bool   `json:"d"`
+	Value string `json:"v"`
+	ID    string `json:"id"`
+}
+
+// Normalize fills in defaults and reports whether the sort and limit are valid
+func (q *VideoQuery) Normalize() error {
+	if q.Sort == "" {
+		q.Sort = VideoSortCreatedAt
+	}
+	switch q.Sort {
+	case VideoSortCreatedAt, VideoSortUpdatedAt, VideoSortTitle:
+	default:
+		return fmt.Errorf("%w %q", ErrInvalidSort, q.Sort)
+	}
+	if q.Limit <= 0 {
+		q.Limit = DefaultVideoPageSize
+	}
+	if q.Limit > MaxVideoPageSize {
+		q.Limit = MaxVideoPageSize
+	}
+	return nil
+}
+
+// Cursor returns the cursor pointing just after video in this query's order
+func (q VideoQuery) Cursor(video entity.Video) string {
+	cursor := videoCursor{Sort: q.sortOrDefault(), Desc: q.Desc, ID: video.ID.String()}
+	switch cursor.Sort {
+	case VideoSortTitle:
+		cursor.Value = video.Title
+	case VideoSortUpdatedAt:
+		cursor.Value = video.UpdatedAt.Format(time.RFC3339Nano)
+	default:
+		cursor.Value = video.CreatedAt.Format(time.RFC3339Nano)
+	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func (q VideoQuery) sortOrDefault() string {
+	if q.Sort == "" {
+		return VideoSortCreatedAt
+	}
+	return q.Sort
+}
+
+// filter applies the filters of the query, but not its cursor
+func (q VideoQuery) filter(db *gorm.DB) *gorm.DB {
+	if q.AuthorID != "" {
+		db = db.Where("author_id = ?", q.AuthorID)
+	}
+	if q.OwnerID != "" {
+		db = db.Where("owner_id = ?", q.OwnerID)
+	}
+	if q.TitleContains != "" {
+		db = db.Where("LOWER(title) LIKE ? ESCAPE '"+likeEscape+"'", "%"+escapeLike(strings.ToLower(q.TitleContains))+"%")
+	}
+	// Times are compared in local time, the zone GORM stores them in
+	if q.CreatedAfter != nil {
+		db = db.Where("created_at > ?", q.CreatedAfter.Local())
+	}
+	if q.CreatedBefore != nil {
+		db = db.Where("created_at < ?", q.CreatedBefore.Local())
+	}
+	if len(q.Tags) > 0 {
+		tagged := db.Session(&gorm.Session{NewDB: true}).Table("video_tags").Select("video_id").Where("tag_name IN ?", q.Tags)
+		if q.MatchAllTags {
+			tagged = tagged.Group("video_id").Having("COUNT(*) = ?", len(q.Tags))
+		}
+		db = db.Where("id IN (?)", tagged)
+	}
+	return db
+}
+
+// seek restricts the query to videos between its cursors
+func (q VideoQuery) seek(db *gorm.DB) (*gorm.DB, error) {
+	db, err := q.seekFrom(db, q.After, false)
+	if err != nil {
+		return nil, err
+	}
+	return q.seekFrom(db, q.Before, true)
+}
+
+// seekFrom restricts the query to videos after the cursor, or before it.
+// Ties on the sort column are broken by ID so that every video appears on
+// exactly one page.
+func (q VideoQuery) seekFrom(db *gorm.DB, encoded string, before bool) (*gorm.DB, error) {
+	if encoded == "" {
+		return db, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor videoCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Sort != q.Sort || cursor.Desc != q.Desc || cursor.ID == "" {
+		return nil, ErrInvalidCursor
+	}
+
+	var value interface{} = cursor.Value
+	if q.Sort != VideoSortTitle {
+		t, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, ErrInvalidCursor
+		}
+		value = t.Local()
+	}
+	op := ">"
+	if q.Desc != before {
+		op = "<"
+	}
+	column := q.Sort
+	return db.Where("(("+column+" "+op+" ?) OR ("+column+" = ? AND id "+op+" ?))", value, value, cursor.ID), nil
+}
+
+// order returns the ORDER BY clause, reversed when reading from the end
+func (q VideoQuery) order() string {
+	dir := " ASC"
+	if q.Desc != q.FromEnd {
+		dir = " DESC"
+	}
+	return q.Sort + dir + ", id" + dir
+}
+
+// likeEscape escapes wildcards in LIKE patterns. It is not a backslash,
+// which MySQL string literals treat specially.
+const likeEscape = "!"
+
+// escapeLike makes s match itself in a LIKE pattern with ESCAPE likeEscape
+func escapeLike(s string) string {
+	return strings.NewReplacer(likeEscape, likeEscape+likeEscape, "%", likeEscape+"%", "_", likeEscape+"_").Replace(s)
+}
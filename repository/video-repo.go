@@ -1,17 +1,32 @@
 package repository
 
 import (
-	"github.com/muzammil-cyber/golang-gin/database/sqlite"
+	"slices"
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/database"
 	"github.com/muzammil-cyber/golang-gin/entity"
 	"gorm.io/gorm"
 )
 
+// VideoRepository stores videos. The methods that change a video record
+// the given revision, if any, in the same transaction, numbered with the
+// video's new version.
 type VideoRepository interface {
-	Save(video *entity.Video) (*entity.Video, error)
-	Update(video *entity.Video) error
+	Save(video *entity.Video, revision *entity.VideoRevision) (*entity.Video, error)
+	// Update, Patch and Delete fail with ErrVersionConflict unless the
+	// stored video has the given version, and increase the version.
+	Update(video *entity.Video, revision *entity.VideoRevision) error
+	// Patch saves the given columns of the video, and its tags if tags is
+	// set. The update time is always bumped.
+	Patch(video *entity.Video, columns []string, tags bool, revision *entity.VideoRevision) error
 	FindByID(id string) (*entity.Video, error)
 	FindAll() ([]entity.Video, error)
-	Delete(id string) error
+	// FindPage returns the page of videos selected by query
+	FindPage(query VideoQuery) (VideoPage, error)
+	FindByOwner(ownerID string) ([]entity.Video, error)
+	FindByAuthor(authorID string) ([]entity.Video, error)
+	Delete(id string, version int64, revision *entity.VideoRevision) error
 }
 
 type videoRepository struct {
@@ -19,41 +34,66 @@ type videoRepository struct {
 	db *gorm.DB
 }
 
-func NewVideoRepository() VideoRepository {
-	sqliteDB, err := sqlite.NewSQLiteDB()
-	if err != nil {
-		panic("Failed to connect to database: " + err.Error())
-	}
-	// Migrate the Video and Person schema
-	err = sqliteDB.GetDB().AutoMigrate(&entity.Person{}, &entity.Video{})
-	if err != nil {
-		panic("Failed to migrate database schema: " + err.Error())
-	}
-
+func NewVideoRepository(db database.Database) VideoRepository {
 	return &videoRepository{
-		db: sqliteDB.GetDB(),
+		db: db.GetDB(),
 	}
 }
 
 // Implement the methods of VideoRepository interface here
-func (r *videoRepository) Save(video *entity.Video) (*entity.Video, error) {
-	if err := r.db.Create(video).Error; err != nil {
-		return nil, err
-	}
+// Save creates the video. Its author must already exist.
+func (r *videoRepository) Save(video *entity.Video, revision *entity.VideoRevision) (*entity.Video, error) {
 	var createdVideo entity.Video
-	if err := r.db.Preload("Author").First(&createdVideo, "id = ?", video.ID).Error; err != nil {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Omit("Author").Create(video).Error; err != nil {
+			return err
+		}
+		if err := preloadVideo(tx).First(&createdVideo, "id = ?", video.ID).Error; err != nil {
+			return err
+		}
+		if revision != nil {
+			revision.VideoID = createdVideo.ID
+		}
+		return recordRevision(tx, createdVideo.Version, revision)
+	})
+	if err != nil {
 		return nil, err
 	}
 	return &createdVideo, nil
 }
 
-func (r *videoRepository) Update(video *entity.Video) error {
-	return r.db.Save(video).Error
+// Update saves the video and replaces its tags with video.Tags. The author
+// is referenced by AuthorID and not saved.
+func (r *videoRepository) Update(video *entity.Video, revision *entity.VideoRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		if err := updateVersioned(tx.Model(video).Select("*").Omit("Tags", "Author"), video, &video.Version); err != nil {
+			return err
+		}
+		if err := tx.Model(video).Association("Tags").Replace(video.Tags); err != nil {
+			return err
+		}
+		return recordRevision(tx, video.Version, revision)
+	})
+}
+
+func (r *videoRepository) Patch(video *entity.Video, columns []string, tags bool, revision *entity.VideoRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		video.UpdatedAt = time.Now()
+		if err := updateVersioned(tx.Model(video).Select(append(columns, "updated_at", "version")), video, &video.Version); err != nil {
+			return err
+		}
+		if tags {
+			if err := tx.Model(video).Association("Tags").Replace(video.Tags); err != nil {
+				return err
+			}
+		}
+		return recordRevision(tx, video.Version, revision)
+	})
 }
 
 func (r *videoRepository) FindByID(id string) (*entity.Video, error) {
 	var video entity.Video
-	if err := r.db.Preload("Author").First(&video, "id = ?", id).Error; err != nil {
+	if err := preloadVideo(r.db).First(&video, "id = ?", id).Error; err != nil {
 		return nil, err
 	}
 	return &video, nil
@@ -61,12 +101,84 @@ func (r *videoRepository) FindByID(id string) (*entity.Video, error) {
 
 func (r *videoRepository) FindAll() ([]entity.Video, error) {
 	var videos []entity.Video
-	if err := r.db.Preload("Author").Find(&videos).Error; err != nil {
+	if err := preloadVideo(r.db).Find(&videos).Error; err != nil {
 		return nil, err
 	}
 	return videos, nil
 }
 
-func (r *videoRepository) Delete(id string) error {
-	return r.db.Delete(&entity.Video{}, "id = ?", id).Error
+func (r *videoRepository) FindPage(query VideoQuery) (VideoPage, error) {
+	if err := query.Normalize(); err != nil {
+		return VideoPage{}, err
+	}
+	var page VideoPage
+	filtered := query.filter(r.db.Model(&entity.Video{}))
+	if err := filtered.Count(&page.Total).Error; err != nil {
+		return VideoPage{}, err
+	}
+
+	seeked, err := query.seek(query.filter(r.db))
+	if err != nil {
+		return VideoPage{}, err
+	}
+	// Fetch one extra row to learn whether there is a next page
+	var videos []entity.Video
+	if err := preloadVideo(seeked).Order(query.order()).Limit(query.Limit + 1).Find(&videos).Error; err != nil {
+		return VideoPage{}, err
+	}
+	more := len(videos) > query.Limit
+	if more {
+		videos = videos[:query.Limit]
+	}
+	if query.FromEnd {
+		slices.Reverse(videos)
+		page.HasPrevious, page.HasNext = more, query.Before != ""
+	} else {
+		page.HasNext, page.HasPrevious = more, query.After != ""
+	}
+	if page.HasNext && len(videos) > 0 {
+		page.NextCursor = query.Cursor(videos[len(videos)-1])
+	}
+	page.Videos = videos
+	return page, nil
+}
+
+func (r *videoRepository) FindByOwner(ownerID string) ([]entity.Video, error) {
+	var videos []entity.Video
+	if err := preloadVideo(r.db).Where("owner_id = ?", ownerID).Find(&videos).Error; err != nil {
+		return nil, err
+	}
+	return videos, nil
+}
+
+func (r *videoRepository) FindByAuthor(authorID string) ([]entity.Video, error) {
+	var videos []entity.Video
+	if err := preloadVideo(r.db).Where("author_id = ?", authorID).Order("created_at DESC").Find(&videos).Error; err != nil {
+		return nil, err
+	}
+	return videos, nil
+}
+
+// Delete moves the video to the trash
+func (r *videoRepository) Delete(id string, version int64, revision *entity.VideoRevision) error {
+	return r.db.Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&entity.Video{}).Where("id = ? AND version = ?", id, version).UpdateColumns(map[string]interface{}{
+			"deleted_at": time.Now(),
+			"version":    gorm.Expr("version + 1"),
+		})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrVersionConflict
+		}
+		return recordRevision(tx, version+1, revision)
+	})
+}
+
+// preloadVideo loads the author and tags along with videos
+func preloadVideo(db *gorm.DB) *gorm.DB {
+	return db.Preload("Author").Preload("Tags", func(db *gorm.DB) *gorm.DB {
+		return db.Order("name")
+	})
 }
@@ -0,0 +1,113 @@
+package repository
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"gorm.io/gorm"
+)
+
+// fts5Rank weighs title matches above description matches; video_id is
+// not indexed
+const fts5Rank = "bm25(videos_fts, 0.0, 10.0, 1.0)"
+
+// FTS5 marks matches with control characters, which survive HTML escaping
+// and are then replaced by the highlight markers
+const (
+	fts5MatchStart = "\x02"
+	fts5MatchEnd   = "\x03"
+)
+
+var fts5Highlighter = strings.NewReplacer(fts5MatchStart, HighlightStart, fts5MatchEnd, HighlightEnd)
+
+type fts5VideoSearcher struct {
+	db *gorm.DB
+}
+
+// NewFTS5VideoSearcher searches the videos_fts index, which migration
+// 0003_videos_fts creates. It fails on other databases, if SQLite was built
+// without FTS5 and if the index is missing.
+func NewFTS5VideoSearcher(db *gorm.DB) (VideoSearcher, error) {
+	if name := db.Dialector.Name(); name != "sqlite" {
+		return nil, fmt.Errorf("FTS5 is not available on %s", name)
+	}
+	if err := db.Exec("SELECT video_id FROM videos_fts LIMIT 0").Error; err != nil {
+		return nil, fmt.Errorf("the FTS5 index cannot be used: %w", err)
+	}
+	return &fts5VideoSearcher{db: db}, nil
+}
+
+func (s *fts5VideoSearcher) Search(query string, limit int, offset int) ([]VideoSearchHit, int64, error) {
+	match := fts5Match(parseSearchQuery(query))
+	if match == "" {
+		return nil, 0, ErrEmptySearch
+	}
+
+	var total int64
+	err := s.db.Raw(`SELECT COUNT(*) FROM videos_fts JOIN videos ON videos.id = videos_fts.video_id
+		WHERE videos_fts MATCH ? AND videos.deleted_at IS NULL`, match).Scan(&total).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var rows []struct {
+		VideoID        string
+		Rank           float64
+		TitleHighlight string
+		Snippet        string
+	}
+	err = s.db.Raw(`SELECT videos_fts.video_id, `+fts5Rank+` AS rank,
+			highlight(videos_fts, 1, ?, ?) AS title_highlight,
+			snippet(videos_fts, 2, ?, ?, '…', 24) AS snippet
+		FROM videos_fts JOIN videos ON videos.id = videos_fts.video_id
+		WHERE videos_fts MATCH ? AND videos.deleted_at IS NULL
+		ORDER BY rank LIMIT ? OFFSET ?`,
+		fts5MatchStart, fts5MatchEnd, fts5MatchStart, fts5MatchEnd, match, limit, offset).Scan(&rows).Error
+	if err != nil {
+		return nil, 0, err
+	}
+
+	ids := make([]string, len(rows))
+	for i, row := range rows {
+		ids[i] = row.VideoID
+	}
+	videos, err := findVideos(s.db, ids)
+	if err != nil {
+		return nil, 0, err
+	}
+	hits := make([]VideoSearchHit, 0, len(rows))
+	for _, row := range rows {
+		video, ok := videos[row.VideoID]
+		if !ok {
+			continue
+		}
+		// bm25 is lower for better matches
+		hits = append(hits, VideoSearchHit{
+			Video:          video,
+			Score:          -row.Rank,
+			TitleHighlight: fts5Highlight(row.TitleHighlight),
+			Snippet:        fts5Highlight(row.Snippet),
+		})
+	}
+	return hits, total, nil
+}
+
+// fts5Highlight escapes text marked by highlight() or snippet() and
+// replaces the match markers
+func fts5Highlight(marked string) string {
+	return fts5Highlighter.Replace(html.EscapeString(marked))
+}
+
+// fts5Match builds an FTS5 query from parsed terms. Every term is quoted,
+// so user input can never be read as FTS5 operators.
+func fts5Match(terms []searchTerm) string {
+	parts := make([]string, len(terms))
+	for i, term := range terms {
+		parts[i] = `"` + strings.Join(term.words, " ") + `"`
+		if term.prefix {
+			parts[i] += "*"
+		}
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"html"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+)
+
+// Weights of a match in the title and in the description
+const (
+	likeTitleWeight       = 2
+	likeDescriptionWeight = 1
+)
+
+// likeVideoSearcher narrows the videos down with LIKE and then matches
+// whole words in Go. It reads every candidate, so it is meant for
+// development databases without FTS5, not for large catalogs.
+type likeVideoSearcher struct {
+	db *gorm.DB
+}
+
+func NewLikeVideoSearcher(db *gorm.DB) VideoSearcher {
+	return &likeVideoSearcher{db: db}
+}
+
+func (s *likeVideoSearcher) Search(query string, limit int, offset int) ([]VideoSearchHit, int64, error) {
+	terms := parseSearchQuery(query)
+	if len(terms) == 0 {
+		return nil, 0, ErrEmptySearch
+	}
+
+	candidates := preloadVideo(s.db)
+	for _, term := range terms {
+		// Words of a phrase may be separated by punctuation, which the
+		// pattern lets through and matchText checks
+		escaped := make([]string, len(term.words))
+		for i, word := range term.words {
+			escaped[i] = escapeLike(word)
+		}
+		pattern := "%" + strings.Join(escaped, "%") + "%"
+		candidates = candidates.Where("(LOWER(title) LIKE ? ESCAPE '"+likeEscape+"' OR LOWER(description) LIKE ? ESCAPE '"+likeEscape+"')", pattern, pattern)
+	}
+	var videos []entity.Video
+	if err := candidates.Find(&videos).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var hits []VideoSearchHit
+	for _, video := range videos {
+		title := matchText(video.Title, terms)
+		description := matchText(video.Description, terms)
+		score := 0
+		matchesAll := true
+		for i := range terms {
+			if title.counts[i]+description.counts[i] == 0 {
+				matchesAll = false
+				break
+			}
+			score += likeTitleWeight*title.counts[i] + likeDescriptionWeight*description.counts[i]
+		}
+		if !matchesAll {
+			continue
+		}
+		hits = append(hits, VideoSearchHit{
+			Video:          video,
+			Score:          float64(score),
+			TitleHighlight: title.highlight(),
+			Snippet:        description.highlight(),
+		})
+	}
+	sort.SliceStable(hits, func(i, j int) bool {
+		if hits[i].Score != hits[j].Score {
+			return hits[i].Score > hits[j].Score
+		}
+		return hits[i].Video.CreatedAt.After(hits[j].Video.CreatedAt)
+	})
+
+	total := int64(len(hits))
+	if offset >= len(hits) {
+		return []VideoSearchHit{}, total, nil
+	}
+	hits = hits[offset:]
+	if limit < len(hits) {
+		hits = hits[:limit]
+	}
+	return hits, total, nil
+}
+
+// textWord is a word of a text and its byte offsets
+type textWord struct {
+	text       string
+	start, end int
+}
+
+// textMatch is the result of matching the terms of a query against a text
+type textMatch struct {
+	text string
+	// counts holds the number of matches of each term
+	counts []int
+	// marked flags the words that are part of a match
+	marked []bool
+	words  []textWord
+}
+
+func matchText(text string, terms []searchTerm) textMatch {
+	words := splitWords(text)
+	m := textMatch{text: text, counts: make([]int, len(terms)), marked: make([]bool, len(words)), words: words}
+	for t, term := range terms {
+		for i := 0; i+len(term.words) <= len(words); i++ {
+			if !term.matchesAt(words, i) {
+				continue
+			}
+			m.counts[t]++
+			for j := i; j < i+len(term.words); j++ {
+				m.marked[j] = true
+			}
+		}
+	}
+	return m
+}
+
+// matchesAt reports whether the term matches the words starting at i
+func (t searchTerm) matchesAt(words []textWord, i int) bool {
+	last := len(t.words) - 1
+	for j, want := range t.words {
+		got := words[i+j].text
+		if j == last && t.prefix {
+			if !strings.HasPrefix(got, want) {
+				return false
+			}
+		} else if got != want {
+			return false
+		}
+	}
+	return true
+}
+
+// highlight HTML-escapes the text and wraps its matched words in
+// highlight markers
+func (m textMatch) highlight() string {
+	var b strings.Builder
+	pos := 0
+	for i, word := range m.words {
+		if !m.marked[i] {
+			continue
+		}
+		b.WriteString(html.EscapeString(m.text[pos:word.start]))
+		b.WriteString(HighlightStart)
+		b.WriteString(html.EscapeString(m.text[word.start:word.end]))
+		b.WriteString(HighlightEnd)
+		pos = word.end
+	}
+	b.WriteString(html.EscapeString(m.text[pos:]))
+	return b.String()
+}
+
+// splitWords splits text into lowercased words the way parseSearchQuery does
+func splitWords(text string) []textWord {
+	var words []textWord
+	start := -1
+	for i, r := range text {
+		isWord := unicode.IsLetter(r) || unicode.IsDigit(r)
+		if isWord && start < 0 {
+			start = i
+		}
+		if !isWord && start >= 0 {
+			words = append(words, textWord{text: strings.ToLower(text[start:i]), start: start, end: i})
+			start = -1
+		}
+	}
+	if start >= 0 {
+		words = append(words, textWord{text: strings.ToLower(text[start:]), start: start, end: len(text)})
+	}
+	return words
+}
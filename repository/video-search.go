@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"errors"
+	"log"
+	"regexp"
+	"strings"
+	"unicode"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"gorm.io/gorm"
+)
+
+// Markers around matched words in highlights and snippets. The rest of
+// the text is HTML-escaped, so highlights can be rendered as HTML.
+const (
+	HighlightStart = "<mark>"
+	HighlightEnd   = "</mark>"
+)
+
+var ErrEmptySearch = errors.New("search query has no searchable words")
+
+// VideoSearchHit is a video matching a search, best matches first
+type VideoSearchHit struct {
+	Video entity.Video
+	// Score is higher for better matches. It is only comparable between
+	// hits of the same search.
+	Score float64
+	// TitleHighlight is the HTML-escaped title with matches wrapped in
+	// <mark> tags
+	TitleHighlight string
+	// Snippet is an HTML-escaped excerpt of the description around the
+	// matches
+	Snippet string
+}
+
+// VideoSearcher finds videos by keywords in their title and description.
+// Queries are words that must all match; "quoted words" match as a
+// phrase and a trailing * matches any word with that prefix.
+type VideoSearcher interface {
+	Search(query string, limit int, offset int) ([]VideoSearchHit, int64, error)
+}
+
+// NewVideoSearcher returns the SQLite FTS5 searcher when the database is
+// SQLite, the SQLite library was built with FTS5 (build tag sqlite_fts5)
+// and the index was created by migrating with such a build, and otherwise
+// a searcher that scans with LIKE.
+func NewVideoSearcher(db database.Database) VideoSearcher {
+	searcher, err := NewFTS5VideoSearcher(db.GetDB())
+	if err == nil {
+		return searcher
+	}
+	log.Printf("full-text search falls back to LIKE scans: %v", err)
+	return NewLikeVideoSearcher(db.GetDB())
+}
+
+// searchTerm is a word or phrase of a search query
+type searchTerm struct {
+	words  []string
+	prefix bool
+}
+
+var searchTokenPattern = regexp.MustCompile(`"[^"]*"\*?|[^\s"]+`)
+
+// parseSearchQuery splits a query into terms, dropping characters that
+// have a meaning in the FTS5 query syntax
+func parseSearchQuery(query string) []searchTerm {
+	var terms []searchTerm
+	for _, token := range searchTokenPattern.FindAllString(query, -1) {
+		prefix := strings.HasSuffix(token, "*")
+		token = strings.TrimSuffix(token, "*")
+		words := strings.FieldsFunc(strings.ToLower(token), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if len(words) == 0 {
+			continue
+		}
+		terms = append(terms, searchTerm{words: words, prefix: prefix})
+	}
+	return terms
+}
+
+// findVideos loads videos with their authors and tags by ID
+func findVideos(db *gorm.DB, ids []string) (map[string]entity.Video, error) {
+	var videos []entity.Video
+	if len(ids) > 0 {
+		if err := preloadVideo(db).Where("id IN ?", ids).Find(&videos).Error; err != nil {
+			return nil, err
+		}
+	}
+	byID := make(map[string]entity.Video, len(videos))
+	for _, video := range videos {
+		byID[video.ID.String()] = video
+	}
+	return byID, nil
+}
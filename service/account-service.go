@@ -0,0 +1,220 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/mailer"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	verificationTokenTTL  = 24 * time.Hour
+	passwordResetTokenTTL = time.Hour
+)
+
+var (
+	ErrEmailTaken   = errors.New("email already registered")
+	ErrInvalidToken = errors.New("invalid or expired token")
+)
+
+type AccountService interface {
+	Register(request dto.RegisterRequest) (*entity.User, error)
+	VerifyEmail(token string) error
+	RequestPasswordReset(email string) error
+	ResetPassword(token string, password string) error
+}
+
+// AccountConfig describes the emails sent to users
+type AccountConfig struct {
+	// BaseURL is where the links in emails point
+	BaseURL string
+}
+
+type accountService struct {
+	users         repository.UserRepository
+	roles         repository.RoleRepository
+	tokens        repository.ActionTokenRepository
+	refreshTokens repository.RefreshTokenRepository
+	jwtService    JWTService
+	mailer        mailer.Mailer
+	baseURL       string
+}
+
+func NewAccountService(config AccountConfig, users repository.UserRepository, roles repository.RoleRepository, tokens repository.ActionTokenRepository, refreshTokens repository.RefreshTokenRepository, jwtService JWTService, mail mailer.Mailer) AccountService {
+	return &accountService{
+		users:         users,
+		roles:         roles,
+		tokens:        tokens,
+		refreshTokens: refreshTokens,
+		jwtService:    jwtService,
+		mailer:        mail,
+		baseURL:       strings.TrimSuffix(config.BaseURL, "/"),
+	}
+}
+
+func (s *accountService) Register(request dto.RegisterRequest) (*entity.User, error) {
+	email := normalizeEmail(request.Email)
+
+	if _, err := s.users.FindByUsername(request.Username); err == nil {
+		return nil, ErrUserExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	if _, err := s.users.FindByEmail(email); err == nil {
+		return nil, ErrEmailTaken
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	// Self-registered accounts start as members
+	roles, err := s.roles.FindByNames([]string{entity.RoleMember})
+	if err != nil {
+		return nil, err
+	}
+	hash, err := utils.HashPassword(request.Password)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.Save(&entity.User{
+		Username:     request.Username,
+		Email:        &email,
+		PasswordHash: hash,
+		Roles:        roles,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// The account exists at this point; a mail failure is recoverable
+	// through the password reset flow, which also verifies the address.
+	if err := s.sendVerification(user); err != nil {
+		log.Printf("failed to send verification email to %s: %v", email, err)
+	}
+	return user, nil
+}
+
+func (s *accountService) VerifyEmail(token string) error {
+	user, err := s.redeemToken(token, entity.PurposeEmailVerification)
+	if err != nil {
+		return err
+	}
+	if user.EmailVerifiedAt == nil {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+		return s.users.Update(user)
+	}
+	return nil
+}
+
+// RequestPasswordReset mails a reset link if the address belongs to an
+// account. Unknown addresses are not reported so callers cannot probe for
+// registered emails.
+func (s *accountService) RequestPasswordReset(email string) error {
+	user, err := s.users.FindByEmail(normalizeEmail(email))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token, err := s.issueToken(user, entity.PurposePasswordReset, passwordResetTokenTTL)
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(mailer.Message{
+		To:      *user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse the token below to choose a new password. It expires in %s.\n\n%s\n\nIf you did not ask for this, you can ignore this email.\n",
+			user.Username, passwordResetTokenTTL, token),
+	})
+}
+
+func (s *accountService) ResetPassword(token string, password string) error {
+	user, err := s.redeemToken(token, entity.PurposePasswordReset)
+	if err != nil {
+		return err
+	}
+
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return err
+	}
+	user.PasswordHash = hash
+	// Receiving the reset email proves ownership of the address
+	if user.EmailVerifiedAt == nil {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+	if err := s.users.Update(user); err != nil {
+		return err
+	}
+	// Sign out every session that may have been opened with the old password
+	return s.refreshTokens.RevokeAllForUser(user.ID.String())
+}
+
+func (s *accountService) sendVerification(user *entity.User) error {
+	token, err := s.issueToken(user, entity.PurposeEmailVerification, verificationTokenTTL)
+	if err != nil {
+		return err
+	}
+	return s.mailer.Send(mailer.Message{
+		To:      *user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf("Hi %s,\n\nConfirm your email address by opening the link below. It expires in %s.\n\n%s/auth/verify?token=%s\n",
+			user.Username, verificationTokenTTL, s.baseURL, token),
+	})
+}
+
+// issueToken stores a new single-use token for the user, replacing any
+// outstanding token with the same purpose, and returns its signed form.
+func (s *accountService) issueToken(user *entity.User, purpose string, ttl time.Duration) (string, error) {
+	if err := s.tokens.InvalidateForUser(user.ID.String(), purpose); err != nil {
+		return "", err
+	}
+	record, err := s.tokens.Save(&entity.ActionToken{
+		UserID:    user.ID,
+		Purpose:   purpose,
+		ExpiresAt: time.Now().Add(ttl),
+	})
+	if err != nil {
+		return "", err
+	}
+	return s.jwtService.GenerateActionToken(user.ID.String(), purpose, record.ID.String(), record.ExpiresAt)
+}
+
+// redeemToken checks the signature and purpose of a token, consumes its
+// stored record and returns the user it was issued to.
+func (s *accountService) redeemToken(token string, purpose string) (*entity.User, error) {
+	claims, err := s.jwtService.ValidateActionToken(token, purpose)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	record, err := s.tokens.FindByID(claims.ID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if record.Purpose != purpose || record.UserID.String() != claims.Subject || time.Now().After(record.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+	consumed, err := s.tokens.MarkUsed(record.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, ErrInvalidToken
+	}
+	return s.users.FindByID(claims.Subject)
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/mailer"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+// tokenFrom extracts the signed token from a mailed link or token line
+func tokenFrom(body string) string {
+	lines := strings.Split(strings.TrimSpace(body), "\n")
+	for _, line := range lines {
+		if i := strings.Index(line, "token="); i >= 0 {
+			return line[i+len("token="):]
+		}
+	}
+	for _, line := range lines {
+		if strings.Count(line, ".") == 2 && !strings.Contains(line, " ") {
+			return line
+		}
+	}
+	return ""
+}
+
+var _ = Describe("AccountService", func() {
+	var (
+		accountService service.AccountService
+		loginService   service.LoginService
+		mail           *mailer.MemoryMailer
+	)
+
+	registration := dto.RegisterRequest{
+		Username: "carol",
+		Email:    "Carol@Example.com",
+		Password: "first-password",
+	}
+
+	BeforeEach(func() {
+		userRepository := repository.NewUserRepository(testDB)
+		mail = mailer.NewMemoryMailer()
+		jwtService, err := service.NewJWTService(service.JWTConfig{DevMode: true})
+		Expect(err).To(BeNil())
+		accountService = service.NewAccountService(service.AccountConfig{BaseURL: "http://localhost:5000"}, userRepository, repository.NewRoleRepository(testDB), repository.NewActionTokenRepository(testDB), repository.NewRefreshTokenRepository(testDB), jwtService, mail)
+		loginService = service.NewLoginService(userRepository)
+	})
+
+	It("should register, verify and reset the password of an account", func() {
+		user, err := accountService.Register(registration)
+		Expect(err).To(BeNil())
+		Expect(*user.Email).To(Equal("carol@example.com"))
+		Expect(user.RoleNames()).To(ConsistOf(entity.RoleMember))
+
+		By("refusing to log in before the email is verified")
+		_, err = loginService.Login("carol", "first-password")
+		Expect(err).To(MatchError(service.ErrEmailNotVerified))
+
+		By("verifying the email with the mailed token")
+		msg, ok := mail.Last("carol@example.com")
+		Expect(ok).To(BeTrue())
+		token := tokenFrom(msg.Body)
+		Expect(accountService.VerifyEmail(token)).To(Succeed())
+		_, err = loginService.Login("carol", "first-password")
+		Expect(err).To(BeNil())
+
+		By("rejecting the verification token a second time")
+		Expect(accountService.VerifyEmail(token)).To(MatchError(service.ErrInvalidToken))
+
+		By("resetting the password with the mailed token")
+		Expect(accountService.RequestPasswordReset("carol@example.com")).To(Succeed())
+		msg, _ = mail.Last("carol@example.com")
+		resetToken := tokenFrom(msg.Body)
+		Expect(accountService.VerifyEmail(resetToken)).To(MatchError(service.ErrInvalidToken))
+		Expect(accountService.ResetPassword(resetToken, "second-password")).To(Succeed())
+		Expect(accountService.ResetPassword(resetToken, "third-password")).To(MatchError(service.ErrInvalidToken))
+
+		_, err = loginService.Login("carol", "first-password")
+		Expect(err).To(MatchError(service.ErrInvalidCredentials))
+		_, err = loginService.Login("carol", "second-password")
+		Expect(err).To(BeNil())
+	})
+
+	It("should reject a duplicate username or email", func() {
+		_, err := accountService.Register(registration)
+		Expect(err).To(MatchError(service.ErrUserExists))
+
+		_, err = accountService.Register(dto.RegisterRequest{Username: "carol2", Email: "carol@example.com", Password: "whatever-pass"})
+		Expect(err).To(MatchError(service.ErrEmailTaken))
+	})
+
+	It("should not reveal whether an email is registered", func() {
+		Expect(accountService.RequestPasswordReset("nobody@example.com")).To(Succeed())
+		Expect(mail.Messages()).To(BeEmpty())
+	})
+
+	It("should only honour the latest password reset token", func() {
+		Expect(accountService.RequestPasswordReset("carol@example.com")).To(Succeed())
+		msg, _ := mail.Last("carol@example.com")
+		first := tokenFrom(msg.Body)
+		Expect(accountService.RequestPasswordReset("carol@example.com")).To(Succeed())
+
+		Expect(accountService.ResetPassword(first, "another-password")).To(MatchError(service.ErrInvalidToken))
+	})
+})
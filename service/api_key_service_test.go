@@ -0,0 +1,111 @@
+package service_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+var _ = Describe("APIKeyService", func() {
+	var (
+		apiKeyService service.APIKeyService
+		apiKeyRepo    repository.APIKeyRepository
+		principal     *auth.Principal
+	)
+
+	BeforeEach(func() {
+		userRepository := repository.NewUserRepository(testDB)
+		userService := service.NewUserService(userRepository, repository.NewRoleRepository(testDB))
+		Expect(userService.EnsureUser("ci-bot", "ci-bot-password", entity.RoleMember)).To(Succeed())
+		user, err := userService.GetByUsername("ci-bot")
+		Expect(err).To(BeNil())
+		principal = &auth.Principal{
+			UserID:      user.ID.String(),
+			Username:    user.Username,
+			Roles:       user.RoleNames(),
+			Permissions: user.Permissions(),
+		}
+
+		apiKeyRepo = repository.NewAPIKeyRepository(testDB)
+		apiKeyService = service.NewAPIKeyService(apiKeyRepo, userRepository)
+	})
+
+	Describe("Create", func() {
+		It("should return the key once and store only its hash", func() {
+			created, err := apiKeyService.Create(principal, dto.APIKeyCreateRequest{Name: "ingest", Scopes: []string{auth.PermVideosRead}})
+			Expect(err).To(BeNil())
+			Expect(created.Key).To(HavePrefix(created.Prefix + "_"))
+
+			stored, err := apiKeyRepo.FindByPrefix(created.Prefix)
+			Expect(err).To(BeNil())
+			Expect(stored.Hash).NotTo(BeEmpty())
+			Expect(stored.Hash).NotTo(ContainSubstring(strings.TrimPrefix(created.Key, created.Prefix+"_")))
+		})
+
+		It("should refuse scopes the caller does not hold", func() {
+			_, err := apiKeyService.Create(principal, dto.APIKeyCreateRequest{Name: "admin", Scopes: []string{auth.PermUsersAdmin}})
+			Expect(err).To(MatchError(service.ErrScopeNotHeld))
+		})
+	})
+
+	Describe("Authenticate", func() {
+		It("should act for the owner limited to the key's scopes", func() {
+			created, err := apiKeyService.Create(principal, dto.APIKeyCreateRequest{Name: "reader", Scopes: []string{auth.PermVideosRead}})
+			Expect(err).To(BeNil())
+
+			caller, err := apiKeyService.Authenticate(created.Key)
+			Expect(err).To(BeNil())
+			Expect(caller.UserID).To(Equal(principal.UserID))
+			Expect(caller.APIKeyID).To(Equal(created.ID.String()))
+			Expect(caller.Permissions).To(ConsistOf(auth.PermVideosRead))
+		})
+
+		It("should record when the key was last used", func() {
+			created, err := apiKeyService.Create(principal, dto.APIKeyCreateRequest{Name: "touch", Scopes: []string{auth.PermVideosRead}})
+			Expect(err).To(BeNil())
+			Expect(created.LastUsedAt).To(BeNil())
+
+			_, err = apiKeyService.Authenticate(created.Key)
+			Expect(err).To(BeNil())
+			stored, err := apiKeyRepo.FindByPrefix(created.Prefix)
+			Expect(err).To(BeNil())
+			Expect(stored.LastUsedAt).NotTo(BeNil())
+		})
+
+		It("should reject a key with a tampered secret", func() {
+			created, err := apiKeyService.Create(principal, dto.APIKeyCreateRequest{Name: "tamper", Scopes: []string{auth.PermVideosRead}})
+			Expect(err).To(BeNil())
+
+			_, err = apiKeyService.Authenticate(created.Key + "x")
+			Expect(err).To(MatchError(service.ErrInvalidAPIKey))
+			_, err = apiKeyService.Authenticate("not-a-key")
+			Expect(err).To(MatchError(service.ErrInvalidAPIKey))
+		})
+
+		It("should reject a revoked key", func() {
+			created, err := apiKeyService.Create(principal, dto.APIKeyCreateRequest{Name: "revoked", Scopes: []string{auth.PermVideosRead}})
+			Expect(err).To(BeNil())
+
+			Expect(apiKeyService.Revoke(principal.UserID, created.ID.String())).To(Succeed())
+			_, err = apiKeyService.Authenticate(created.Key)
+			Expect(err).To(MatchError(service.ErrInvalidAPIKey))
+		})
+	})
+
+	Describe("Revoke", func() {
+		It("should not revoke another user's key", func() {
+			created, err := apiKeyService.Create(principal, dto.APIKeyCreateRequest{Name: "mine", Scopes: []string{auth.PermVideosRead}})
+			Expect(err).To(BeNil())
+
+			err = apiKeyService.Revoke(otherMember.UserID, created.ID.String())
+			Expect(err).To(MatchError(service.ErrAPIKeyNotFound))
+		})
+	})
+})
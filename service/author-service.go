@@ -0,0 +1,107 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAuthorNotFound   = errors.New("author not found")
+	ErrAuthorRequired   = errors.New("an author or author_id is required")
+	ErrAuthorEmailTaken = errors.New("another author has this email")
+	ErrAuthorHasVideos  = errors.New("the author still has videos, possibly in the trash")
+)
+
+type AuthorService interface {
+	// Create fails with ErrAuthorEmailTaken if the email is in use
+	Create(request dto.AuthorRequest) (*entity.Person, error)
+	GetAll() ([]entity.Person, error)
+	GetByID(id string) (*entity.Person, error)
+	Update(id string, request dto.AuthorRequest) (*entity.Person, error)
+	// Delete fails with ErrAuthorHasVideos while videos refer to the author,
+	// including videos in the trash
+	Delete(id string) error
+}
+
+type authorService struct {
+	authors repository.AuthorRepository
+}
+
+func NewAuthorService(authors repository.AuthorRepository) AuthorService {
+	return &authorService{
+		authors: authors,
+	}
+}
+
+func (s *authorService) Create(request dto.AuthorRequest) (*entity.Person, error) {
+	author := entity.Person{Name: request.Name, Age: request.Age, Email: normalizeEmail(request.Email)}
+	if err := s.checkEmailFree(author.Email, ""); err != nil {
+		return nil, err
+	}
+	if err := s.authors.Save(&author); err != nil {
+		return nil, err
+	}
+	return &author, nil
+}
+
+func (s *authorService) GetAll() ([]entity.Person, error) {
+	return s.authors.FindAll()
+}
+
+func (s *authorService) GetByID(id string) (*entity.Person, error) {
+	author, err := s.authors.FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrAuthorNotFound
+	}
+	return author, err
+}
+
+func (s *authorService) Update(id string, request dto.AuthorRequest) (*entity.Person, error) {
+	author, err := s.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	author.Name = request.Name
+	author.Age = request.Age
+	author.Email = normalizeEmail(request.Email)
+	if err := s.checkEmailFree(author.Email, id); err != nil {
+		return nil, err
+	}
+	if err := s.authors.Update(author); err != nil {
+		return nil, err
+	}
+	return author, nil
+}
+
+func (s *authorService) Delete(id string) error {
+	if _, err := s.GetByID(id); err != nil {
+		return err
+	}
+	videos, err := s.authors.CountVideos(id)
+	if err != nil {
+		return err
+	}
+	if videos > 0 {
+		return ErrAuthorHasVideos
+	}
+	return s.authors.Delete(id)
+}
+
+// checkEmailFree fails if an author other than exceptID has the email
+func (s *authorService) checkEmailFree(email string, exceptID string) error {
+	existing, err := s.authors.FindByEmail(email)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if existing.ID.String() != exceptID {
+		return ErrAuthorEmailTaken
+	}
+	return nil
+}
@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+var _ = Describe("AuthorService", func() {
+	var (
+		authorService service.AuthorService
+		videoService  service.VideoService
+		// email of this spec's author
+		email string
+	)
+
+	saveVideo := func(request dto.VideoCreateRequest) entity.Video {
+		request.Title = "Author spec video"
+		request.URL = "https://www.example.com/authors"
+		video, err := videoService.Save(request, videoAdmin)
+		Expect(err).To(BeNil())
+		DeferCleanup(func() {
+			_ = videoService.Delete(video.ID.String(), service.AnyVersion, videoAdmin)
+		})
+		return video
+	}
+
+	BeforeEach(func() {
+		authorRepository := repository.NewAuthorRepository(testDB)
+		authorService = service.NewAuthorService(authorRepository)
+		videoService = service.New(repository.NewVideoRepository(testDB), authorRepository, repository.NewRevisionRepository(testDB))
+		email = uuid.NewString() + "@example.com"
+	})
+
+	It("should reuse the author with the same email", func() {
+		first := saveVideo(dto.VideoCreateRequest{Author: &entity.Person{Name: "First Name", Email: email, Age: 30}})
+		second := saveVideo(dto.VideoCreateRequest{Author: &entity.Person{Name: "Renamed", Email: "  " + email, Age: 32}})
+		Expect(second.AuthorID).To(Equal(first.AuthorID))
+		Expect(second.Author.Name).To(Equal("Renamed"))
+
+		author, err := authorService.GetByID(first.AuthorID.String())
+		Expect(err).To(BeNil())
+		Expect(author.Age).To(Equal(32))
+		videos, err := videoService.GetByAuthor(first.AuthorID.String())
+		Expect(err).To(BeNil())
+		Expect(videos).To(HaveLen(2))
+	})
+
+	It("should compare emails without case", func() {
+		created, err := authorService.Create(dto.AuthorRequest{Name: "Mixed Case", Email: "Mixed." + email})
+		Expect(err).To(BeNil())
+		DeferCleanup(func() { _ = authorService.Delete(created.ID.String()) })
+		Expect(created.Email).To(Equal("mixed." + email))
+
+		video := saveVideo(dto.VideoCreateRequest{Author: &entity.Person{Name: "Mixed Case", Email: "MIXED." + email}})
+		Expect(video.AuthorID).To(Equal(created.ID))
+		_, err = authorService.Create(dto.AuthorRequest{Name: "Copy", Email: "mixed." + email})
+		Expect(err).To(MatchError(service.ErrAuthorEmailTaken))
+	})
+
+	It("should credit an existing author by author_id", func() {
+		author, err := authorService.Create(dto.AuthorRequest{Name: "Existing Author", Email: email})
+		Expect(err).To(BeNil())
+
+		video := saveVideo(dto.VideoCreateRequest{
+			AuthorID: author.ID.String(),
+			Author:   &entity.Person{Name: "Ignored", Email: "ignored." + email},
+		})
+		Expect(video.AuthorID).To(Equal(author.ID))
+		Expect(video.Author.Name).To(Equal("Existing Author"))
+
+		_, err = videoService.Save(dto.VideoCreateRequest{Title: "Missing author", AuthorID: uuid.NewString()}, videoAdmin)
+		Expect(err).To(MatchError(service.ErrAuthorNotFound))
+		_, err = videoService.Save(dto.VideoCreateRequest{Title: "No author"}, videoAdmin)
+		Expect(err).To(MatchError(service.ErrAuthorRequired))
+	})
+
+	It("should refuse to take another author's email", func() {
+		first, err := authorService.Create(dto.AuthorRequest{Name: "First", Email: email})
+		Expect(err).To(BeNil())
+		second, err := authorService.Create(dto.AuthorRequest{Name: "Second", Email: "second." + email})
+		Expect(err).To(BeNil())
+		DeferCleanup(func() {
+			_ = authorService.Delete(first.ID.String())
+			_ = authorService.Delete(second.ID.String())
+		})
+
+		_, err = authorService.Update(second.ID.String(), dto.AuthorRequest{Name: "Second", Email: email})
+		Expect(err).To(MatchError(service.ErrAuthorEmailTaken))
+		updated, err := authorService.Update(second.ID.String(), dto.AuthorRequest{Name: "Second", Age: 40, Email: "second." + email})
+		Expect(err).To(BeNil())
+		Expect(updated.Age).To(Equal(40))
+	})
+
+	It("should only delete authors without videos, deleted ones included", func() {
+		video := saveVideo(dto.VideoCreateRequest{Author: &entity.Person{Name: "Busy Author", Email: email}})
+		Expect(authorService.Delete(video.AuthorID.String())).To(MatchError(service.ErrAuthorHasVideos))
+
+		Expect(videoService.Delete(video.ID.String(), service.AnyVersion, videoAdmin)).To(Succeed())
+		Expect(authorService.Delete(video.AuthorID.String())).To(MatchError(service.ErrAuthorHasVideos))
+	})
+
+	It("should report unknown authors", func() {
+		_, err := authorService.GetByID(uuid.NewString())
+		Expect(err).To(MatchError(service.ErrAuthorNotFound))
+		Expect(authorService.Delete(uuid.NewString())).To(MatchError(service.ErrAuthorNotFound))
+	})
+})
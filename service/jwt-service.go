@@ -1,75 +1,218 @@
 package service
 
 import (
-	"os"
+	"crypto"
+	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
 )
 
 type JWTService interface {
-	GenerateToken(username string, isAdmin bool) string
+	GenerateToken(user *entity.User) string
 	ValidateToken(token string) (*jwt.Token, error)
+	GenerateActionToken(userID string, purpose string, id string, expiresAt time.Time) (string, error)
+	ValidateActionToken(token string, purpose string) (*ActionClaims, error)
+	// JWKS returns the public keys that verify issued tokens. It is empty
+	// when tokens are signed with a shared HMAC secret.
+	JWKS() dto.JSONWebKeySet
+}
+
+// defaultSecretKey is only accepted in development mode
+const defaultSecretKey = "your-secret-key"
+
+var (
+	ErrInvalidTokenPurpose = errors.New("token was issued for a different purpose")
+	ErrUnknownSigningKey   = errors.New("token was signed with an unknown key")
+)
+
+// JWTConfig describes how tokens are signed and verified
+type JWTConfig struct {
+	// SigningMethod is one of HS256, RS256, ES256 or EdDSA
+	SigningMethod string
+	// Secret is the shared HMAC key used with HS256
+	Secret string
+	// PrivateKeyFile is the PEM file holding the key used with RS256, ES256 and EdDSA
+	PrivateKeyFile string
+	// KeyID is the kid of the signing key, defaulting to its JWK thumbprint
+	KeyID string
+	// VerificationKeyFiles lists PEM files of retired keys whose tokens are
+	// still accepted, as "path" or "kid=path"
+	VerificationKeyFiles []string
+	Issuer               string
+	Expiry               time.Duration
+	// DevMode allows HS256 with the built-in default secret
+	DevMode bool
+}
+
+type verificationKey struct {
+	method jwt.SigningMethod
+	key    interface{}
 }
 
 type jwtService struct {
-	secretKey string
-	issuer    string
-	expiry    time.Duration
+	issuer       string
+	expiry       time.Duration
+	method       jwt.SigningMethod
+	keyID        string
+	signingKey   interface{}
+	verification map[string]verificationKey
+	publicKeys   dto.JSONWebKeySet
+	validMethods []string
 }
 
-type jwtCustomClaims struct {
-	Username string `json:"username"`
-	IsAdmin  bool   `json:"is_admin"`
+// AccessClaims are the claims of an access token
+type AccessClaims struct {
+	Username    string   `json:"username"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+	// Purpose is only ever set on action tokens; it is decoded so that
+	// those tokens can be refused where an access token is expected.
+	Purpose string `json:"purpose,omitempty"`
 	jwt.RegisteredClaims
 }
 
-func NewJWTService() JWTService {
+// Principal returns the caller described by the claims
+func (c *AccessClaims) Principal() *auth.Principal {
+	return &auth.Principal{
+		UserID:      c.Subject,
+		Username:    c.Username,
+		Roles:       c.Roles,
+		Permissions: c.Permissions,
+	}
+}
+
+// ActionClaims are the claims of tokens issued for a single purpose, such as
+// the email verification and password reset tokens mailed to users and the
+// refresh tokens handed out at login
+type ActionClaims struct {
+	Purpose string `json:"purpose"`
+	jwt.RegisteredClaims
+}
 
-	secretKey := os.Getenv("JWT_SECRET_KEY")
-	issuer := os.Getenv("JWT_ISSUER")
-	expiry_str := os.Getenv("JWT_EXPIRY_MINS") // in mins default to 15mins
+// NewJWTService configures token signing. DevMode allows the built-in
+// HS256 secret.
+func NewJWTService(config JWTConfig) (JWTService, error) {
+	s := &jwtService{
+		issuer:       config.Issuer,
+		expiry:       config.Expiry,
+		verification: map[string]verificationKey{},
+		publicKeys:   dto.JSONWebKeySet{Keys: []dto.JSONWebKey{}},
+	}
+	if s.issuer == "" {
+		s.issuer = "your-app-name"
+	}
+	if s.expiry <= 0 {
+		s.expiry = 15 * time.Minute
+	}
 
-	// parse expiry_str to time.Duration
-	var expiry time.Duration
-	if expiry_str != "" {
-		expiryMinutes, err := time.ParseDuration(expiry_str + "m")
+	switch config.SigningMethod {
+	case "", jwt.SigningMethodHS256.Alg():
+		secret := config.Secret
+		if secret == "" || secret == defaultSecretKey {
+			if !config.DevMode {
+				return nil, errors.New("JWT_SECRET_KEY must be set to a non-default value unless APP_ENV=development")
+			}
+			secret = defaultSecretKey
+		}
+		s.method = jwt.SigningMethodHS256
+		s.signingKey = []byte(secret)
+		// HMAC tokens carry no kid
+		s.verification[""] = verificationKey{method: s.method, key: s.signingKey}
+	case jwt.SigningMethodRS256.Alg(), jwt.SigningMethodES256.Alg(), jwt.SigningMethodEdDSA.Alg():
+		if config.PrivateKeyFile == "" {
+			return nil, fmt.Errorf("JWT_PRIVATE_KEY_FILE is required for %s", config.SigningMethod)
+		}
+		signer, err := loadPrivateKey(config.PrivateKeyFile)
+		if err != nil {
+			return nil, err
+		}
+		method, err := signingMethodFor(signer.Public())
+		if err != nil {
+			return nil, err
+		}
+		if method.Alg() != config.SigningMethod {
+			return nil, fmt.Errorf("key in %s is for %s, not %s", config.PrivateKeyFile, method.Alg(), config.SigningMethod)
+		}
+		s.method = method
+		s.signingKey = signer
+		s.keyID, err = s.addVerificationKey(config.KeyID, signer.Public())
 		if err != nil {
-			expiry = 15 * time.Minute
-		} else {
-			expiry = expiryMinutes
+			return nil, err
 		}
-	} else {
-		expiry = 15 * time.Minute
+	default:
+		return nil, fmt.Errorf("unsupported JWT signing method %q", config.SigningMethod)
 	}
 
-	if secretKey != "" && issuer != "" {
-		return &jwtService{
-			secretKey: secretKey,
-			issuer:    issuer,
-			expiry:    expiry,
+	for _, entry := range config.VerificationKeyFiles {
+		kid, path, found := strings.Cut(entry, "=")
+		if !found {
+			kid, path = "", entry
+		}
+		key, err := loadPublicKey(path)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := s.addVerificationKey(kid, key); err != nil {
+			return nil, err
 		}
 	}
 
-	return &jwtService{
-		secretKey: "your-secret-key",
-		issuer:    "your-app-name",
-		expiry:    15 * time.Minute,
+	for _, key := range s.verification {
+		s.validMethods = appendUnique(s.validMethods, key.method.Alg())
 	}
+	return s, nil
 }
 
-func (s *jwtService) GenerateToken(username string, isAdmin bool) string {
-	claims := &jwtCustomClaims{
-		username,
-		isAdmin,
-		jwt.RegisteredClaims{
+// addVerificationKey registers a public key under kid, or under its
+// thumbprint if kid is empty, and publishes it in the JWKS
+func (s *jwtService) addVerificationKey(kid string, key crypto.PublicKey) (string, error) {
+	method, err := signingMethodFor(key)
+	if err != nil {
+		return "", err
+	}
+	if kid == "" {
+		if kid, err = thumbprint(key); err != nil {
+			return "", err
+		}
+	}
+	if _, exists := s.verification[kid]; exists {
+		return "", fmt.Errorf("duplicate JWT key ID %q", kid)
+	}
+	jwk, err := toJWK(key)
+	if err != nil {
+		return "", err
+	}
+	jwk.Kid = kid
+	jwk.Use = "sig"
+	jwk.Alg = method.Alg()
+
+	s.verification[kid] = verificationKey{method: method, key: key}
+	s.publicKeys.Keys = append(s.publicKeys.Keys, jwk)
+	return kid, nil
+}
+
+func (s *jwtService) GenerateToken(user *entity.User) string {
+	claims := &AccessClaims{
+		Username:    user.Username,
+		Roles:       user.RoleNames(),
+		Permissions: user.Permissions(),
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
+			Subject:   user.ID.String(),
 			Issuer:    s.issuer,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.expiry)),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	t, err := token.SignedString([]byte(s.secretKey))
+	t, err := s.sign(claims)
 	if err != nil {
 		return ""
 	}
@@ -77,16 +220,80 @@ func (s *jwtService) GenerateToken(username string, isAdmin bool) string {
 }
 
 func (s *jwtService) ValidateToken(tokenString string) (*jwt.Token, error) {
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, jwt.ErrSignatureInvalid
-		}
-		return []byte(s.secretKey), nil
-	})
+	token, err := jwt.ParseWithClaims(tokenString, &AccessClaims{}, s.keyFunc,
+		jwt.WithValidMethods(s.validMethods), jwt.WithIssuer(s.issuer), jwt.WithExpirationRequired())
 
 	if err != nil {
 		return nil, err
 	}
 
+	// Mailed and refresh tokens share the signing key but are not access tokens
+	if token.Claims.(*AccessClaims).Purpose != "" {
+		return nil, ErrInvalidTokenPurpose
+	}
+
 	return token, nil
 }
+
+func (s *jwtService) GenerateActionToken(userID string, purpose string, id string, expiresAt time.Time) (string, error) {
+	claims := &ActionClaims{
+		purpose,
+		jwt.RegisteredClaims{
+			ID:        id,
+			Subject:   userID,
+			Issuer:    s.issuer,
+			IssuedAt:  jwt.NewNumericDate(time.Now()),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+	return s.sign(claims)
+}
+
+func (s *jwtService) ValidateActionToken(tokenString string, purpose string) (*ActionClaims, error) {
+	claims := &ActionClaims{}
+	_, err := jwt.ParseWithClaims(tokenString, claims, s.keyFunc,
+		jwt.WithValidMethods(s.validMethods), jwt.WithIssuer(s.issuer), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	if claims.Purpose != purpose {
+		return nil, ErrInvalidTokenPurpose
+	}
+	return claims, nil
+}
+
+func (s *jwtService) JWKS() dto.JSONWebKeySet {
+	return s.publicKeys
+}
+
+func (s *jwtService) sign(claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.method, claims)
+	if s.keyID != "" {
+		token.Header["kid"] = s.keyID
+	}
+	return token.SignedString(s.signingKey)
+}
+
+// keyFunc picks the verification key named by the kid header and makes sure
+// the token uses the algorithm that belongs to it
+func (s *jwtService) keyFunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+	if kid == "" {
+		kid = s.keyID
+	}
+	key, ok := s.verification[kid]
+	if !ok {
+		return nil, ErrUnknownSigningKey
+	}
+	if token.Method.Alg() != key.method.Alg() {
+		return nil, jwt.ErrSignatureInvalid
+	}
+	return key.key, nil
+}
+
+func appendUnique(values []string, value string) []string {
+	if containsString(values, value) {
+		return values
+	}
+	return append(values, value)
+}
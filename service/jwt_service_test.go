@@ -0,0 +1,174 @@
+package service_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+// writeKey stores a private key as PKCS#8 PEM and returns the file path
+func writeKey(dir string, name string, key crypto.Signer) string {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	Expect(err).To(BeNil())
+	path := filepath.Join(dir, name)
+	Expect(os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)).To(Succeed())
+	return path
+}
+
+var _ = Describe("JWTService", func() {
+	var (
+		dir  string
+		user *entity.User
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		user = &entity.User{ID: uuid.New(), Username: "erin"}
+	})
+
+	It("should refuse the default secret outside development mode", func() {
+		_, err := service.NewJWTService(service.JWTConfig{})
+		Expect(err).NotTo(BeNil())
+		_, err = service.NewJWTService(service.JWTConfig{Secret: "your-secret-key"})
+		Expect(err).NotTo(BeNil())
+		_, err = service.NewJWTService(service.JWTConfig{DevMode: true})
+		Expect(err).To(BeNil())
+	})
+
+	It("should publish no keys for HS256", func() {
+		jwtService, err := service.NewJWTService(service.JWTConfig{Secret: "a-long-and-random-shared-secret"})
+		Expect(err).To(BeNil())
+		Expect(jwtService.JWKS().Keys).To(BeEmpty())
+	})
+
+	DescribeTable("asymmetric signing",
+		func(method string, kty string, newKey func() crypto.Signer) {
+			jwtService, err := service.NewJWTService(service.JWTConfig{
+				SigningMethod:  method,
+				PrivateKeyFile: writeKey(dir, "signing.pem", newKey()),
+			})
+			Expect(err).To(BeNil())
+
+			token, err := jwtService.ValidateToken(jwtService.GenerateToken(user))
+			Expect(err).To(BeNil())
+			Expect(token.Method.Alg()).To(Equal(method))
+
+			keys := jwtService.JWKS().Keys
+			Expect(keys).To(HaveLen(1))
+			Expect(keys[0].Kty).To(Equal(kty))
+			Expect(keys[0].Alg).To(Equal(method))
+			Expect(token.Header["kid"]).To(Equal(keys[0].Kid))
+		},
+		Entry("RS256", "RS256", "RSA", func() crypto.Signer {
+			key, _ := rsa.GenerateKey(rand.Reader, 2048)
+			return key
+		}),
+		Entry("ES256", "ES256", "EC", func() crypto.Signer {
+			key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			return key
+		}),
+		Entry("EdDSA", "EdDSA", "OKP", func() crypto.Signer {
+			_, key, _ := ed25519.GenerateKey(rand.Reader)
+			return key
+		}),
+	)
+
+	It("should reject a key that does not match the signing method", func() {
+		key, _ := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		_, err := service.NewJWTService(service.JWTConfig{
+			SigningMethod:  "RS256",
+			PrivateKeyFile: writeKey(dir, "signing.pem", key),
+		})
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should keep accepting tokens of a rotated key", func() {
+		_, oldKey, _ := ed25519.GenerateKey(rand.Reader)
+		_, newKey, _ := ed25519.GenerateKey(rand.Reader)
+		oldPath := writeKey(dir, "old.pem", oldKey)
+
+		before, err := service.NewJWTService(service.JWTConfig{
+			SigningMethod:  "EdDSA",
+			PrivateKeyFile: oldPath,
+			KeyID:          "2024-01",
+		})
+		Expect(err).To(BeNil())
+		oldToken := before.GenerateToken(user)
+
+		after, err := service.NewJWTService(service.JWTConfig{
+			SigningMethod:        "EdDSA",
+			PrivateKeyFile:       writeKey(dir, "new.pem", newKey),
+			KeyID:                "2024-06",
+			VerificationKeyFiles: []string{"2024-01=" + oldPath},
+		})
+		Expect(err).To(BeNil())
+
+		_, err = after.ValidateToken(oldToken)
+		Expect(err).To(BeNil())
+		Expect(after.JWKS().Keys).To(HaveLen(2))
+
+		token, err := after.ValidateToken(after.GenerateToken(user))
+		Expect(err).To(BeNil())
+		Expect(token.Header["kid"]).To(Equal("2024-06"))
+
+		By("rejecting tokens from keys it does not know")
+		_, err = before.ValidateToken(after.GenerateToken(user))
+		Expect(err).NotTo(BeNil())
+	})
+
+	It("should only accept access tokens of its issuer that expire", func() {
+		secret := "a-long-and-random-shared-secret"
+		jwtService, err := service.NewJWTService(service.JWTConfig{Secret: secret, Issuer: "video-api"})
+		Expect(err).To(BeNil())
+		_, err = jwtService.ValidateToken(jwtService.GenerateToken(user))
+		Expect(err).To(BeNil())
+
+		other, err := service.NewJWTService(service.JWTConfig{Secret: secret, Issuer: "other-api"})
+		Expect(err).To(BeNil())
+		_, err = jwtService.ValidateToken(other.GenerateToken(user))
+		Expect(err).To(MatchError(jwt.ErrTokenInvalidIssuer))
+
+		unexpiring, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &service.AccessClaims{
+			Username:         user.Username,
+			RegisteredClaims: jwt.RegisteredClaims{Subject: user.ID.String(), Issuer: "video-api"},
+		}).SignedString([]byte(secret))
+		Expect(err).To(BeNil())
+		_, err = jwtService.ValidateToken(unexpiring)
+		Expect(err).To(MatchError(jwt.ErrTokenRequiredClaimMissing))
+	})
+
+	It("should reject HS256 tokens when signing asymmetrically", func() {
+		key, _ := rsa.GenerateKey(rand.Reader, 2048)
+		rsaService, err := service.NewJWTService(service.JWTConfig{
+			SigningMethod:  "RS256",
+			PrivateKeyFile: writeKey(dir, "signing.pem", key),
+		})
+		Expect(err).To(BeNil())
+		hmacService, err := service.NewJWTService(service.JWTConfig{DevMode: true})
+		Expect(err).To(BeNil())
+
+		_, err = rsaService.ValidateToken(hmacService.GenerateToken(user))
+		Expect(err).NotTo(BeNil())
+
+		token, err := hmacService.GenerateActionToken(user.ID.String(), entity.PurposeRefresh, uuid.NewString(), time.Now().Add(time.Hour))
+		Expect(err).To(BeNil())
+		_, err = rsaService.ValidateActionToken(token, entity.PurposeRefresh)
+		Expect(err).NotTo(BeNil())
+	})
+})
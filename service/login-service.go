@@ -1,27 +1,49 @@
 package service
 
+import (
+	"errors"
+
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/utils"
+)
+
+var (
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	ErrEmailNotVerified   = errors.New("email address has not been verified")
+)
+
+// dummyPasswordHash is a bcrypt hash at the default cost that no password
+// matches. It is checked when there is no hash to compare against, so that
+// the time a login takes does not tell which accounts exist.
+const dummyPasswordHash = "$2a$10$yfhV.vcSL4gMp9LUvkG1d.HLcn2B8yh/AJ4YA/WeZE54ERE.kBhta"
+
 type LoginService interface {
-	Login(username string, password string) bool
+	Login(username string, password string) (*entity.User, error)
 }
 
 type loginService struct {
-	users map[string]string
+	users repository.UserRepository
 }
 
-func NewLoginService() LoginService {
-	// In a real application, user data would come from a database
-	users := map[string]string{
-		"admin": "password",
-		"user":  "userpass",
-	}
+func NewLoginService(repo repository.UserRepository) LoginService {
 	return &loginService{
-		users: users,
+		users: repo,
 	}
 }
 
-func (s *loginService) Login(username string, password string) bool {
-	if pass, exists := s.users[username]; exists {
-		return pass == password
+func (s *loginService) Login(username string, password string) (*entity.User, error) {
+	user, err := s.users.FindByUsername(username)
+	if err != nil || user.PasswordHash == "" {
+		utils.CheckPassword(dummyPasswordHash, password)
+		return nil, ErrInvalidCredentials
+	}
+	if !utils.CheckPassword(user.PasswordHash, password) {
+		return nil, ErrInvalidCredentials
+	}
+	// Accounts bootstrapped without an email address have nothing to verify
+	if user.Email != nil && user.EmailVerifiedAt == nil {
+		return nil, ErrEmailNotVerified
 	}
-	return false
+	return user, nil
 }
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"gorm.io/gorm"
+)
+
+var ErrLoginLocked = errors.New("too many failed login attempts, try again later")
+
+// LoginThrottleConfig describes when failed logins lock an account or a
+// client out. Once MaxAttempts failures are reached every further failure
+// doubles the lockout, starting at BaseLockout and capped at MaxLockout.
+type LoginThrottleConfig struct {
+	// MaxAttempts is the number of failures per username before lockout
+	MaxAttempts int
+	// MaxAttemptsPerIP is the number of failures per client IP before
+	// lockout, across all usernames
+	MaxAttemptsPerIP int
+	BaseLockout      time.Duration
+	MaxLockout       time.Duration
+	// Window is how long a failure is remembered after the last one
+	Window time.Duration
+}
+
+type LoginThrottle interface {
+	// Check returns how long logins for the username or from the IP are
+	// still refused, or zero if they are allowed
+	Check(username string, ip string) (time.Duration, error)
+	// Failure records a failed login and returns the lockout it caused
+	Failure(username string, ip string) (time.Duration, error)
+	// Success forgets the failures of the username. Failures of the IP are
+	// kept, so logging into one's own account does not reset them.
+	Success(username string) error
+	// Unlock lifts a lockout of the username
+	Unlock(username string) error
+}
+
+type loginThrottle struct {
+	config LoginThrottleConfig
+	store  repository.LoginAttemptStore
+}
+
+func NewLoginThrottle(config LoginThrottleConfig, store repository.LoginAttemptStore) LoginThrottle {
+	return &loginThrottle{
+		config: config,
+		store:  store,
+	}
+}
+
+func (t *loginThrottle) Check(username string, ip string) (time.Duration, error) {
+	now := time.Now()
+	var retryAfter time.Duration
+	for _, key := range []string{usernameKey(username), ipKey(ip)} {
+		attempt, err := t.store.Find(key)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			continue
+		}
+		if err != nil {
+			return 0, err
+		}
+		if attempt.LockedUntil != nil && attempt.LockedUntil.After(now) {
+			retryAfter = max(retryAfter, attempt.LockedUntil.Sub(now))
+		}
+	}
+	return retryAfter, nil
+}
+
+func (t *loginThrottle) Failure(username string, ip string) (time.Duration, error) {
+	now := time.Now()
+	since := now.Add(-t.config.Window)
+	var lockout time.Duration
+	for key, limit := range map[string]int{usernameKey(username): t.config.MaxAttempts, ipKey(ip): t.config.MaxAttemptsPerIP} {
+		attempt, err := t.store.RecordFailure(key, now, since)
+		if err != nil {
+			return 0, err
+		}
+		if d := t.lockout(attempt.Failures, limit); d > 0 {
+			if err := t.store.Lock(key, now.Add(d)); err != nil {
+				return 0, err
+			}
+			lockout = max(lockout, d)
+		}
+	}
+	return lockout, nil
+}
+
+func (t *loginThrottle) Success(username string) error {
+	return t.store.Reset(usernameKey(username))
+}
+
+func (t *loginThrottle) Unlock(username string) error {
+	return t.store.Reset(usernameKey(username))
+}
+
+// lockout returns the lockout after the given number of failures
+func (t *loginThrottle) lockout(failures int, limit int) time.Duration {
+	if limit <= 0 || failures < limit {
+		return 0
+	}
+	d := t.config.BaseLockout
+	for i := limit; i < failures && d < t.config.MaxLockout; i++ {
+		d *= 2
+	}
+	return min(d, t.config.MaxLockout)
+}
+
+func usernameKey(username string) string {
+	return "user:" + strings.ToLower(username)
+}
+
+func ipKey(ip string) string {
+	return "ip:" + ip
+}
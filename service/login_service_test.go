@@ -1,10 +1,13 @@
 package service_test
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
 	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
 	"github.com/muzammil-cyber/golang-gin/service"
 )
 
@@ -19,17 +22,35 @@ var _ = Describe("LoginService", func() {
 	)
 
 	BeforeEach(func() {
-		loginService = service.NewLoginService()
+		userRepository := repository.NewUserRepository(testDB)
+		Expect(service.NewUserService(userRepository, repository.NewRoleRepository(testDB)).EnsureUser(fakeUser.Username, fakeUser.Password)).To(Succeed())
+		loginService = service.NewLoginService(userRepository)
 	})
 	Describe("Login", func() {
 		It("should authenticate valid user credentials", func() {
-			isAuthenticated := loginService.Login(fakeUser.Username, fakeUser.Password)
-			Expect(isAuthenticated).To(BeFalse())
+			user, err := loginService.Login(fakeUser.Username, fakeUser.Password)
+			Expect(err).To(BeNil())
+			Expect(user.Username).To(Equal(fakeUser.Username))
+		})
+
+		It("should reject a wrong password", func() {
+			_, err := loginService.Login(fakeUser.Username, "wrongPassword")
+			Expect(err).To(MatchError(service.ErrInvalidCredentials))
 		})
 
 		It("should reject invalid user credentials", func() {
-			isAuthenticated := loginService.Login("invalidUser", "wrongPassword")
-			Expect(isAuthenticated).To(BeFalse())
+			_, err := loginService.Login("invalidUser", "wrongPassword")
+			Expect(err).To(MatchError(service.ErrInvalidCredentials))
+		})
+
+		It("should take as long for unknown users as for wrong passwords", func() {
+			elapsed := func(username string) time.Duration {
+				start := time.Now()
+				_, err := loginService.Login(username, "wrongPassword")
+				Expect(err).To(MatchError(service.ErrInvalidCredentials))
+				return time.Since(start)
+			}
+			Expect(elapsed("invalidUser")).To(BeNumerically(">", elapsed(fakeUser.Username)/2))
 		})
 	})
 
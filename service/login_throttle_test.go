@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+var _ = Describe("LoginThrottle", func() {
+	config := service.LoginThrottleConfig{
+		MaxAttempts:      3,
+		MaxAttemptsPerIP: 5,
+		BaseLockout:      time.Minute,
+		MaxLockout:       5 * time.Minute,
+		Window:           time.Hour,
+	}
+
+	for name, newStore := range map[string]func() repository.LoginAttemptStore{
+		"memory": repository.NewMemoryLoginAttemptStore,
+		"db":     func() repository.LoginAttemptStore { return repository.NewDBLoginAttemptStore(testDB) },
+	} {
+		Context("with the "+name+" store", func() {
+			var (
+				throttle service.LoginThrottle
+				username string
+			)
+
+			BeforeEach(func() {
+				throttle = service.NewLoginThrottle(config, newStore())
+				// Usernames are unique per spec since the db store is shared
+				username = "victim-" + name + "-" + time.Now().Format("150405.000000")
+			})
+
+			fail := func(username string, ip string, times int) time.Duration {
+				var lockout time.Duration
+				for i := 0; i < times; i++ {
+					var err error
+					lockout, err = throttle.Failure(username, ip)
+					Expect(err).To(BeNil())
+				}
+				return lockout
+			}
+
+			It("should lock a username out after too many failures with growing lockouts", func() {
+				Expect(fail(username, "192.0.2.1", 2)).To(BeZero())
+				Expect(fail(username, "192.0.2.2", 1)).To(Equal(time.Minute))
+				Expect(fail(username, "192.0.2.3", 1)).To(Equal(2 * time.Minute))
+				Expect(fail(username, "192.0.2.4", 5)).To(Equal(5 * time.Minute))
+
+				retryAfter, err := throttle.Check(username, "198.51.100.1")
+				Expect(err).To(BeNil())
+				Expect(retryAfter).To(BeNumerically("~", 5*time.Minute, time.Second))
+			})
+
+			It("should lock an IP out across usernames", func() {
+				ip := "203.0.113." + name
+				for i := 0; i < 4; i++ {
+					Expect(fail(username+string(rune('a'+i)), ip, 1)).To(BeZero())
+				}
+				Expect(fail(username+"e", ip, 1)).To(Equal(time.Minute))
+
+				retryAfter, err := throttle.Check("someone-else", ip)
+				Expect(err).To(BeNil())
+				Expect(retryAfter).To(BeNumerically(">", 0))
+			})
+
+			It("should forget failures of a username after a successful login", func() {
+				fail(username, "192.0.2.10", 2)
+				Expect(throttle.Success(username)).To(Succeed())
+				Expect(fail(username, "192.0.2.10", 2)).To(BeZero())
+			})
+
+			It("should let an admin unlock a username", func() {
+				fail(username, "192.0.2.20", 3)
+				Expect(throttle.Unlock(username)).To(Succeed())
+
+				retryAfter, err := throttle.Check(username, "198.51.100.2")
+				Expect(err).To(BeNil())
+				Expect(retryAfter).To(BeZero())
+			})
+		})
+	}
+
+	It("should allow logins again once the lockout has passed", func() {
+		throttle := service.NewLoginThrottle(service.LoginThrottleConfig{
+			MaxAttempts: 1, MaxAttemptsPerIP: 100, BaseLockout: 50 * time.Millisecond, MaxLockout: time.Second, Window: time.Hour,
+		}, repository.NewMemoryLoginAttemptStore())
+		lockout, err := throttle.Failure("sleepy", "192.0.2.30")
+		Expect(err).To(BeNil())
+		Expect(lockout).To(Equal(50 * time.Millisecond))
+
+		Eventually(func() time.Duration {
+			retryAfter, _ := throttle.Check("sleepy", "192.0.2.30")
+			return retryAfter
+		}).WithTimeout(time.Second).Should(BeZero())
+	})
+})
@@ -0,0 +1,322 @@
+package service
+
+import (
+	"crypto/rand"
+	"encoding/base32"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/utils"
+	"gorm.io/gorm"
+)
+
+const (
+	mfaChallengeTTL = 5 * time.Minute
+	// mfaChallengeMaxAttempts is the number of wrong codes after which a
+	// challenge is void and the password has to be entered again
+	mfaChallengeMaxAttempts = 5
+	recoveryCodeCount       = 10
+)
+
+var (
+	ErrMFANotEnrolled    = errors.New("two-factor authentication has not been set up")
+	ErrMFAAlreadyEnabled = errors.New("two-factor authentication is already enabled")
+	ErrInvalidMFACode    = errors.New("invalid two-factor authentication code")
+)
+
+type MFAService interface {
+	// Required reports whether the user has to pass a second factor to log in
+	Required(user *entity.User) bool
+	// Challenge returns a short-lived token standing for a correct password,
+	// to be exchanged together with a code at /auth/mfa/verify
+	Challenge(user *entity.User) (string, error)
+	// ChallengedUser returns the user a challenge was issued to, without
+	// checking a code or consuming the challenge
+	ChallengedUser(token string) (*entity.User, error)
+	// Enroll generates a new TOTP secret. It is not enforced until Activate.
+	Enroll(userID string) (*dto.MFAEnrollResponse, error)
+	// EnrollWithChallenge enrolls the user a challenge was issued to, for
+	// users required to use MFA before they have set it up
+	EnrollWithChallenge(token string) (*dto.MFAEnrollResponse, error)
+	// Activate turns MFA on once the user proves their authenticator works
+	// and returns the recovery codes
+	Activate(userID string, code string) ([]string, error)
+	// Disable turns MFA off after checking a TOTP or recovery code
+	Disable(userID string, code string) error
+	// Verify checks a code against a challenge and consumes the challenge.
+	// A user that is still enrolling is activated, and their recovery codes
+	// are returned. A challenge is consumed after too many wrong codes.
+	Verify(token string, code string) (*entity.User, []string, error)
+}
+
+// MFAConfig describes two-factor authentication
+type MFAConfig struct {
+	// Issuer is the name shown in authenticator apps
+	Issuer string
+	// RequiredRoles are the roles that must use MFA
+	RequiredRoles []string
+}
+
+type mfaService struct {
+	users         repository.UserRepository
+	tokens        repository.ActionTokenRepository
+	recoveryCodes repository.RecoveryCodeRepository
+	jwtService    JWTService
+	issuer        string
+	requiredRoles []string
+}
+
+func NewMFAService(config MFAConfig, users repository.UserRepository, tokens repository.ActionTokenRepository, recoveryCodes repository.RecoveryCodeRepository, jwtService JWTService) MFAService {
+	return &mfaService{
+		users:         users,
+		tokens:        tokens,
+		recoveryCodes: recoveryCodes,
+		jwtService:    jwtService,
+		issuer:        config.Issuer,
+		requiredRoles: config.RequiredRoles,
+	}
+}
+
+func (s *mfaService) Required(user *entity.User) bool {
+	if user.MFAEnabled() {
+		return true
+	}
+	for _, role := range user.RoleNames() {
+		if containsString(s.requiredRoles, role) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *mfaService) Challenge(user *entity.User) (string, error) {
+	if err := s.tokens.InvalidateForUser(user.ID.String(), entity.PurposeMFAChallenge); err != nil {
+		return "", err
+	}
+	record, err := s.tokens.Save(&entity.ActionToken{
+		UserID:    user.ID,
+		Purpose:   entity.PurposeMFAChallenge,
+		ExpiresAt: time.Now().Add(mfaChallengeTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+	return s.jwtService.GenerateActionToken(user.ID.String(), entity.PurposeMFAChallenge, record.ID.String(), record.ExpiresAt)
+}
+
+func (s *mfaService) ChallengedUser(token string) (*entity.User, error) {
+	record, err := s.challenge(token)
+	if err != nil {
+		return nil, err
+	}
+	return s.findUser(record.UserID.String())
+}
+
+func (s *mfaService) Enroll(userID string) (*dto.MFAEnrollResponse, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+	user.TOTPSecret = secret
+	user.TOTPLastStep = 0
+	if err := s.users.Update(user); err != nil {
+		return nil, err
+	}
+	return &dto.MFAEnrollResponse{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(s.issuer, user.Username, secret),
+	}, nil
+}
+
+func (s *mfaService) EnrollWithChallenge(token string) (*dto.MFAEnrollResponse, error) {
+	record, err := s.challenge(token)
+	if err != nil {
+		return nil, err
+	}
+	return s.Enroll(record.UserID.String())
+}
+
+func (s *mfaService) Activate(userID string, code string) ([]string, error) {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.activate(user, code)
+}
+
+func (s *mfaService) Disable(userID string, code string) error {
+	user, err := s.findUser(userID)
+	if err != nil {
+		return err
+	}
+	if !user.MFAEnabled() {
+		return ErrMFANotEnrolled
+	}
+	if err := s.checkCode(user, code); err != nil {
+		return err
+	}
+	user.TOTPSecret = ""
+	user.TOTPLastStep = 0
+	user.MFAEnabledAt = nil
+	if err := s.users.Update(user); err != nil {
+		return err
+	}
+	return s.recoveryCodes.DeleteForUser(userID)
+}
+
+func (s *mfaService) Verify(token string, code string) (*entity.User, []string, error) {
+	record, err := s.challenge(token)
+	if err != nil {
+		return nil, nil, err
+	}
+	user, err := s.findUser(record.UserID.String())
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var recoveryCodes []string
+	if user.MFAEnabled() {
+		err = s.checkCode(user, code)
+	} else {
+		recoveryCodes, err = s.activate(user, code)
+	}
+	if errors.Is(err, ErrInvalidMFACode) {
+		if _, recordErr := s.tokens.RecordFailure(record.ID.String(), mfaChallengeMaxAttempts); recordErr != nil {
+			return nil, nil, recordErr
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	consumed, err := s.tokens.MarkUsed(record.ID.String())
+	if err != nil {
+		return nil, nil, err
+	}
+	if !consumed {
+		return nil, nil, ErrInvalidToken
+	}
+	return user, recoveryCodes, nil
+}
+
+// challenge returns the stored record of a challenge token without
+// consuming it, so a mistyped code can be retried a few times
+func (s *mfaService) challenge(token string) (*entity.ActionToken, error) {
+	claims, err := s.jwtService.ValidateActionToken(token, entity.PurposeMFAChallenge)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	record, err := s.tokens.FindByID(claims.ID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if record.Purpose != entity.PurposeMFAChallenge || record.UserID.String() != claims.Subject ||
+		record.UsedAt != nil || time.Now().After(record.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+	return record, nil
+}
+
+func (s *mfaService) activate(user *entity.User, code string) ([]string, error) {
+	if user.MFAEnabled() {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrMFANotEnrolled
+	}
+	if err := s.checkTOTP(user, code); err != nil {
+		return nil, err
+	}
+	codes, err := s.newRecoveryCodes(user)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	user.MFAEnabledAt = &now
+	if err := s.users.Update(user); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// checkCode accepts either a TOTP code or an unused recovery code
+func (s *mfaService) checkCode(user *entity.User, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == 6 {
+		return s.checkTOTP(user, code)
+	}
+	normalized := normalizeRecoveryCode(code)
+	unused, err := s.recoveryCodes.FindUnused(user.ID.String())
+	if err != nil {
+		return err
+	}
+	for _, recovery := range unused {
+		if utils.CheckPassword(recovery.Hash, normalized) {
+			consumed, err := s.recoveryCodes.MarkUsed(recovery.ID.String())
+			if err != nil {
+				return err
+			}
+			if consumed {
+				return nil
+			}
+		}
+	}
+	return ErrInvalidMFACode
+}
+
+func (s *mfaService) checkTOTP(user *entity.User, code string) error {
+	step, ok := utils.ValidateTOTP(user.TOTPSecret, code, time.Now(), user.TOTPLastStep)
+	if !ok {
+		return ErrInvalidMFACode
+	}
+	user.TOTPLastStep = step
+	return s.users.Update(user)
+}
+
+// newRecoveryCodes replaces the user's recovery codes and returns the new
+// codes in clear, formatted as xxxxx-xxxxx
+func (s *mfaService) newRecoveryCodes(user *entity.User) ([]string, error) {
+	encoding := base32.StdEncoding.WithPadding(base32.NoPadding)
+	codes := make([]string, recoveryCodeCount)
+	records := make([]entity.RecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 7)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := strings.ToLower(encoding.EncodeToString(b))[:10]
+		hash, err := utils.HashPassword(code)
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code[:5] + "-" + code[5:]
+		records[i] = entity.RecoveryCode{UserID: user.ID, Hash: hash}
+	}
+	if err := s.recoveryCodes.Replace(user.ID.String(), records); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+func (s *mfaService) findUser(userID string) (*entity.User, error) {
+	user, err := s.users.FindByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,168 @@
+package service_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+	"github.com/muzammil-cyber/golang-gin/utils"
+)
+
+var _ = Describe("MFAService", func() {
+	var (
+		mfaService     service.MFAService
+		userRepository repository.UserRepository
+		user           *entity.User
+	)
+
+	// codeAt returns the TOTP code of the user's secret for a time offset,
+	// letting tests use a fresh period whenever a code was already accepted
+	codeAt := func(offset time.Duration) string {
+		stored, err := userRepository.FindByID(user.ID.String())
+		Expect(err).To(BeNil())
+		code, err := utils.TOTPCode(stored.TOTPSecret, time.Now().Add(offset))
+		Expect(err).To(BeNil())
+		return code
+	}
+
+	BeforeEach(func() {
+		userRepository = repository.NewUserRepository(testDB)
+		userService := service.NewUserService(userRepository, repository.NewRoleRepository(testDB))
+		var err error
+		user, err = userService.Create("mfa-"+time.Now().Format("150405.000000"), "mfa-password", entity.RoleMember)
+		Expect(err).To(BeNil())
+
+		jwtService, err := service.NewJWTService(service.JWTConfig{DevMode: true})
+		Expect(err).To(BeNil())
+		mfaService = service.NewMFAService(service.MFAConfig{
+			Issuer:        "Video Management API",
+			RequiredRoles: []string{entity.RoleAdmin},
+		}, userRepository, repository.NewActionTokenRepository(testDB), repository.NewRecoveryCodeRepository(testDB), jwtService)
+	})
+
+	Describe("Required", func() {
+		It("should only require MFA from enrolled users and configured roles", func() {
+			Expect(mfaService.Required(user)).To(BeFalse())
+			Expect(mfaService.Required(&entity.User{Roles: []entity.Role{{Name: entity.RoleAdmin}}})).To(BeTrue())
+		})
+	})
+
+	Describe("Enroll and Activate", func() {
+		It("should return a provisioning URI and turn MFA on with a valid code", func() {
+			enrollment, err := mfaService.Enroll(user.ID.String())
+			Expect(err).To(BeNil())
+			Expect(enrollment.ProvisioningURI).To(HavePrefix("otpauth://totp/"))
+			Expect(enrollment.ProvisioningURI).To(ContainSubstring("secret=" + enrollment.Secret))
+
+			_, err = mfaService.Activate(user.ID.String(), "000000")
+			Expect(err).To(HaveOccurred())
+
+			recoveryCodes, err := mfaService.Activate(user.ID.String(), codeAt(0))
+			Expect(err).To(BeNil())
+			Expect(recoveryCodes).To(HaveLen(10))
+
+			stored, err := userRepository.FindByID(user.ID.String())
+			Expect(err).To(BeNil())
+			Expect(stored.MFAEnabled()).To(BeTrue())
+			Expect(mfaService.Required(stored)).To(BeTrue())
+		})
+	})
+
+	Describe("Verify", func() {
+		var recoveryCodes []string
+
+		BeforeEach(func() {
+			_, err := mfaService.Enroll(user.ID.String())
+			Expect(err).To(BeNil())
+			recoveryCodes, err = mfaService.Activate(user.ID.String(), codeAt(-30*time.Second))
+			Expect(err).To(BeNil())
+		})
+
+		It("should exchange a challenge and a TOTP code once", func() {
+			challenge, err := mfaService.Challenge(user)
+			Expect(err).To(BeNil())
+
+			_, _, err = mfaService.Verify(challenge, "not-a-code")
+			Expect(err).To(MatchError(service.ErrInvalidMFACode))
+
+			verified, _, err := mfaService.Verify(challenge, codeAt(0))
+			Expect(err).To(BeNil())
+			Expect(verified.ID).To(Equal(user.ID))
+
+			_, _, err = mfaService.Verify(challenge, codeAt(30*time.Second))
+			Expect(err).To(MatchError(service.ErrInvalidToken))
+		})
+
+		It("should void a challenge after five wrong codes", func() {
+			challenge, err := mfaService.Challenge(user)
+			Expect(err).To(BeNil())
+			for i := 0; i < 5; i++ {
+				_, _, err = mfaService.Verify(challenge, "000000")
+				Expect(err).To(MatchError(service.ErrInvalidMFACode))
+			}
+
+			_, err = mfaService.ChallengedUser(challenge)
+			Expect(err).To(MatchError(service.ErrInvalidToken))
+			_, _, err = mfaService.Verify(challenge, codeAt(0))
+			Expect(err).To(MatchError(service.ErrInvalidToken))
+		})
+
+		It("should not accept the same TOTP code twice", func() {
+			code := codeAt(0)
+			first, err := mfaService.Challenge(user)
+			Expect(err).To(BeNil())
+			_, _, err = mfaService.Verify(first, code)
+			Expect(err).To(BeNil())
+
+			second, err := mfaService.Challenge(user)
+			Expect(err).To(BeNil())
+			_, _, err = mfaService.Verify(second, code)
+			Expect(err).To(MatchError(service.ErrInvalidMFACode))
+		})
+
+		It("should accept each recovery code once", func() {
+			first, err := mfaService.Challenge(user)
+			Expect(err).To(BeNil())
+			_, _, err = mfaService.Verify(first, recoveryCodes[0])
+			Expect(err).To(BeNil())
+
+			second, err := mfaService.Challenge(user)
+			Expect(err).To(BeNil())
+			_, _, err = mfaService.Verify(second, recoveryCodes[0])
+			Expect(err).To(MatchError(service.ErrInvalidMFACode))
+		})
+	})
+
+	Describe("EnrollWithChallenge", func() {
+		It("should let a user required to use MFA enroll during login", func() {
+			challenge, err := mfaService.Challenge(user)
+			Expect(err).To(BeNil())
+			_, err = mfaService.EnrollWithChallenge(challenge)
+			Expect(err).To(BeNil())
+
+			verified, recoveryCodes, err := mfaService.Verify(challenge, codeAt(0))
+			Expect(err).To(BeNil())
+			Expect(verified.MFAEnabled()).To(BeTrue())
+			Expect(recoveryCodes).To(HaveLen(10))
+		})
+	})
+
+	Describe("Disable", func() {
+		It("should turn MFA off with a valid code", func() {
+			_, err := mfaService.Enroll(user.ID.String())
+			Expect(err).To(BeNil())
+			_, err = mfaService.Activate(user.ID.String(), codeAt(-30*time.Second))
+			Expect(err).To(BeNil())
+
+			Expect(mfaService.Disable(user.ID.String(), codeAt(0))).To(Succeed())
+			stored, err := userRepository.FindByID(user.ID.String())
+			Expect(err).To(BeNil())
+			Expect(stored.MFAEnabled()).To(BeFalse())
+			Expect(stored.TOTPSecret).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,424 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"golang.org/x/oauth2"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrOIDCDisabled      = errors.New("OIDC login is not configured")
+	ErrOIDCInvalidState  = errors.New("OIDC login state is missing or does not match")
+	ErrOIDCLoginRejected = errors.New("OIDC login was rejected")
+	// ErrOIDCLinkRequired is returned on the first login of a provider
+	// account whose email belongs to a local account with a password or
+	// MFA. Linking it would let the provider bypass the local credentials.
+	ErrOIDCLinkRequired = errors.New("an account with this email address already exists; sign in and link the identity provider from your account")
+	// ErrOIDCIdentityLinked is returned when the provider account is already
+	// linked to another local user
+	ErrOIDCIdentityLinked = errors.New("the identity provider account is linked to another user")
+)
+
+// oidcLinkTTL bounds how long a signed in user has to complete linking at
+// the provider
+const oidcLinkTTL = 10 * time.Minute
+
+// OIDCConfig describes the identity provider used for single sign-on
+type OIDCConfig struct {
+	// IssuerURL is the provider's issuer; its discovery document is fetched
+	// from IssuerURL/.well-known/openid-configuration
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL must point at /auth/oidc/callback of this server
+	RedirectURL string
+	Scopes      []string
+	// RolesClaim names the ID token claim that carries the user's groups
+	RolesClaim string
+	// RoleMapping maps values of RolesClaim to local roles. When set, the
+	// provider is authoritative and roles are synchronised on every login.
+	RoleMapping map[string]string
+	// DefaultRole is granted to users without any mapped role
+	DefaultRole string
+}
+
+// OIDCFlow is the state of a login in progress. Everything but AuthURL has
+// to be kept by the client until the provider redirects back.
+type OIDCFlow struct {
+	AuthURL  string
+	State    string
+	Nonce    string
+	Verifier string
+	// LinkToken is set when a signed in user links the provider account
+	// to their own account instead of logging in
+	LinkToken string
+}
+
+type OIDCService interface {
+	// Begin starts an authorization code login with PKCE
+	Begin() (*OIDCFlow, error)
+	// BeginLink starts a login that links the provider account to the
+	// signed in user once completed
+	BeginLink(userID string) (*OIDCFlow, error)
+	// Complete redeems the code the provider redirected back with and
+	// returns the local user. On first login a user is created, or a user
+	// without password or MFA is linked by verified email; other accounts
+	// have to be linked with BeginLink.
+	Complete(ctx context.Context, flow OIDCFlow, state string, code string) (*entity.User, error)
+}
+
+type oidcService struct {
+	config     OIDCConfig
+	users      repository.UserRepository
+	roles      repository.RoleRepository
+	identities repository.IdentityRepository
+	tokens     repository.ActionTokenRepository
+	jwtService JWTService
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+// idTokenClaims are the standard claims used to find or create a local user
+type idTokenClaims struct {
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	PreferredUsername string `json:"preferred_username"`
+}
+
+// NewOIDCService configures single sign-on. Without an issuer URL the
+// service is disabled.
+func NewOIDCService(config OIDCConfig, users repository.UserRepository, roles repository.RoleRepository, identities repository.IdentityRepository, tokens repository.ActionTokenRepository, jwtService JWTService) (OIDCService, error) {
+	if config.IssuerURL != "" && config.ClientID == "" {
+		return nil, errors.New("OIDC client ID is required when an issuer is configured")
+	}
+	if len(config.Scopes) == 0 {
+		config.Scopes = []string{"profile", "email"}
+	}
+	if !containsString(config.Scopes, oidc.ScopeOpenID) {
+		config.Scopes = append([]string{oidc.ScopeOpenID}, config.Scopes...)
+	}
+	if config.RolesClaim == "" {
+		config.RolesClaim = "groups"
+	}
+	if config.DefaultRole == "" {
+		config.DefaultRole = entity.RoleMember
+	}
+	return &oidcService{
+		config:     config,
+		users:      users,
+		roles:      roles,
+		identities: identities,
+		tokens:     tokens,
+		jwtService: jwtService,
+	}, nil
+}
+
+func (s *oidcService) Begin() (*OIDCFlow, error) {
+	if s.config.IssuerURL == "" {
+		return nil, ErrOIDCDisabled
+	}
+	oauthConfig, _, err := s.oauthConfig(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	state, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	nonce, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	verifier := oauth2.GenerateVerifier()
+	return &OIDCFlow{
+		AuthURL:  oauthConfig.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)),
+		State:    state,
+		Nonce:    nonce,
+		Verifier: verifier,
+	}, nil
+}
+
+func (s *oidcService) BeginLink(userID string) (*OIDCFlow, error) {
+	flow, err := s.Begin()
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.FindByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.tokens.InvalidateForUser(user.ID.String(), entity.PurposeOIDCLink); err != nil {
+		return nil, err
+	}
+	record, err := s.tokens.Save(&entity.ActionToken{
+		UserID:    user.ID,
+		Purpose:   entity.PurposeOIDCLink,
+		ExpiresAt: time.Now().Add(oidcLinkTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+	flow.LinkToken, err = s.jwtService.GenerateActionToken(user.ID.String(), entity.PurposeOIDCLink, record.ID.String(), record.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+	return flow, nil
+}
+
+func (s *oidcService) Complete(ctx context.Context, flow OIDCFlow, state string, code string) (*entity.User, error) {
+	if s.config.IssuerURL == "" {
+		return nil, ErrOIDCDisabled
+	}
+	if flow.State == "" || subtle.ConstantTimeCompare([]byte(flow.State), []byte(state)) != 1 {
+		return nil, ErrOIDCInvalidState
+	}
+	oauthConfig, provider, err := s.oauthConfig(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := oauthConfig.Exchange(ctx, code, oauth2.VerifierOption(flow.Verifier))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCLoginRejected, err)
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, fmt.Errorf("%w: no ID token in response", ErrOIDCLoginRejected)
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: s.config.ClientID}).Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCLoginRejected, err)
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(flow.Nonce)) != 1 {
+		return nil, fmt.Errorf("%w: nonce mismatch", ErrOIDCLoginRejected)
+	}
+
+	var claims idTokenClaims
+	var allClaims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCLoginRejected, err)
+	}
+	if err := idToken.Claims(&allClaims); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrOIDCLoginRejected, err)
+	}
+
+	var user *entity.User
+	var created bool
+	if flow.LinkToken != "" {
+		user, err = s.linkSignedIn(flow.LinkToken, idToken.Issuer, idToken.Subject)
+	} else {
+		user, created, err = s.findOrCreateUser(idToken.Issuer, idToken.Subject, claims)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.syncRoles(user, created, stringsClaim(allClaims[s.config.RolesClaim])); err != nil {
+		return nil, err
+	}
+	return s.users.FindByID(user.ID.String())
+}
+
+// oauthConfig returns the OAuth2 client settings, discovering the provider's
+// endpoints on first use so that the server starts while the IdP is down
+func (s *oidcService) oauthConfig(ctx context.Context) (*oauth2.Config, *oidc.Provider, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.provider == nil {
+		provider, err := oidc.NewProvider(ctx, s.config.IssuerURL)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to discover OIDC provider: %w", err)
+		}
+		s.provider = provider
+	}
+	return &oauth2.Config{
+		ClientID:     s.config.ClientID,
+		ClientSecret: s.config.ClientSecret,
+		RedirectURL:  s.config.RedirectURL,
+		Endpoint:     s.provider.Endpoint(),
+		Scopes:       s.config.Scopes,
+	}, s.provider, nil
+}
+
+// findOrCreateUser resolves the provider account to a local user. Accounts
+// are matched by issuer and subject, then by verified email address. Only
+// accounts that can sign in through providers alone are linked by email.
+func (s *oidcService) findOrCreateUser(issuer string, subject string, claims idTokenClaims) (*entity.User, bool, error) {
+	identity, err := s.identities.FindBySubject(issuer, subject)
+	if err == nil {
+		user, err := s.users.FindByID(identity.UserID.String())
+		return user, false, err
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, err
+	}
+
+	email := normalizeEmail(claims.Email)
+	if email != "" && claims.EmailVerified {
+		user, err := s.users.FindByEmail(email)
+		if err == nil {
+			if user.PasswordHash != "" || user.MFAEnabled() {
+				return nil, false, ErrOIDCLinkRequired
+			}
+			return user, false, s.link(user, issuer, subject)
+		}
+		if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, false, err
+		}
+	}
+
+	username, err := s.availableUsername(claims, subject)
+	if err != nil {
+		return nil, false, err
+	}
+	// No password is set, so the account can only sign in through the provider
+	user := &entity.User{Username: username}
+	if email != "" && claims.EmailVerified {
+		now := time.Now()
+		user.Email = &email
+		user.EmailVerifiedAt = &now
+	}
+	if _, err := s.users.Save(user); err != nil {
+		return nil, false, err
+	}
+	return user, true, s.link(user, issuer, subject)
+}
+
+// linkSignedIn redeems a link token from BeginLink and links the provider
+// account to the user it was issued to
+func (s *oidcService) linkSignedIn(token string, issuer string, subject string) (*entity.User, error) {
+	claims, err := s.jwtService.ValidateActionToken(token, entity.PurposeOIDCLink)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	record, err := s.tokens.FindByID(claims.ID)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	if record.Purpose != entity.PurposeOIDCLink || record.UserID.String() != claims.Subject || time.Now().After(record.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+	consumed, err := s.tokens.MarkUsed(record.ID.String())
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, ErrInvalidToken
+	}
+
+	identity, err := s.identities.FindBySubject(issuer, subject)
+	if err == nil {
+		if identity.UserID != record.UserID {
+			return nil, ErrOIDCIdentityLinked
+		}
+		return s.users.FindByID(claims.Subject)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+	user, err := s.users.FindByID(claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	return user, s.link(user, issuer, subject)
+}
+
+func (s *oidcService) link(user *entity.User, issuer string, subject string) error {
+	return s.identities.Save(&entity.UserIdentity{UserID: user.ID, Issuer: issuer, Subject: subject})
+}
+
+// availableUsername derives a free local username from the provider claims
+func (s *oidcService) availableUsername(claims idTokenClaims, subject string) (string, error) {
+	base := claims.PreferredUsername
+	if base == "" {
+		base, _, _ = strings.Cut(claims.Email, "@")
+	}
+	if base == "" {
+		base = subject
+	}
+	if len(base) > 40 {
+		base = base[:40]
+	}
+	username := base
+	for i := 0; i < 5; i++ {
+		_, err := s.users.FindByUsername(username)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return username, nil
+		}
+		if err != nil {
+			return "", err
+		}
+		suffix := make([]byte, 3)
+		if _, err := rand.Read(suffix); err != nil {
+			return "", err
+		}
+		username = base + "-" + hex.EncodeToString(suffix)
+	}
+	return "", ErrUserExists
+}
+
+// syncRoles grants the roles mapped from the provider's groups. Without a
+// mapping only new users get the default role and local grants are kept.
+func (s *oidcService) syncRoles(user *entity.User, created bool, groups []string) error {
+	if len(s.config.RoleMapping) == 0 && !created {
+		return nil
+	}
+	names := []string{}
+	for _, group := range groups {
+		if role, ok := s.config.RoleMapping[group]; ok && !containsString(names, role) {
+			names = append(names, role)
+		}
+	}
+	if len(names) == 0 {
+		names = append(names, s.config.DefaultRole)
+	}
+	roles, err := s.roles.FindByNames(names)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUnknownRole
+	}
+	if err != nil {
+		return err
+	}
+	return s.users.SetRoles(user, roles)
+}
+
+// stringsClaim reads a claim that holds either a string or a list of strings
+func stringsClaim(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		return []string{v}
+	case []interface{}:
+		values := []string{}
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				values = append(values, s)
+			}
+		}
+		return values
+	}
+	return nil
+}
+
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
@@ -0,0 +1,201 @@
+package service_test
+
+import (
+	"context"
+	"net/url"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/oidctest"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+var _ = Describe("OIDCService", func() {
+	var (
+		provider    *oidctest.Provider
+		oidcService service.OIDCService
+		userService service.UserService
+	)
+
+	// complete runs the browser round trip of a flow against the mock provider
+	complete := func(flow *service.OIDCFlow) (*entity.User, error) {
+		callback, err := provider.Authorize(flow.AuthURL)
+		Expect(err).To(BeNil())
+		return oidcService.Complete(context.Background(), *flow, callback.Query().Get("state"), callback.Query().Get("code"))
+	}
+
+	login := func() (*entity.User, error) {
+		flow, err := oidcService.Begin()
+		Expect(err).To(BeNil())
+		return complete(flow)
+	}
+
+	BeforeEach(func() {
+		var err error
+		provider, err = oidctest.NewProvider("video-api", "video-api-secret")
+		Expect(err).To(BeNil())
+		DeferCleanup(provider.Close)
+
+		userRepository := repository.NewUserRepository(testDB)
+		roleRepository := repository.NewRoleRepository(testDB)
+		userService = service.NewUserService(userRepository, roleRepository)
+		jwtService, err := service.NewJWTService(service.JWTConfig{DevMode: true})
+		Expect(err).To(BeNil())
+		oidcService, err = service.NewOIDCService(service.OIDCConfig{
+			IssuerURL:    provider.URL,
+			ClientID:     provider.ClientID,
+			ClientSecret: provider.ClientSecret,
+			RedirectURL:  "http://localhost:5000/auth/oidc/callback",
+			RoleMapping:  map[string]string{"video-admins": entity.RoleAdmin},
+			DefaultRole:  entity.RoleViewer,
+		}, userRepository, roleRepository, repository.NewIdentityRepository(testDB), repository.NewActionTokenRepository(testDB), jwtService)
+		Expect(err).To(BeNil())
+	})
+
+	Describe("Begin", func() {
+		It("should request an authorization code with PKCE", func() {
+			flow, err := oidcService.Begin()
+			Expect(err).To(BeNil())
+
+			authURL, err := url.Parse(flow.AuthURL)
+			Expect(err).To(BeNil())
+			Expect(authURL.Query().Get("state")).To(Equal(flow.State))
+			Expect(authURL.Query().Get("nonce")).To(Equal(flow.Nonce))
+			Expect(authURL.Query().Get("code_challenge_method")).To(Equal("S256"))
+			Expect(authURL.Query().Get("code_challenge")).NotTo(BeEmpty())
+			Expect(authURL.Query().Get("scope")).To(ContainSubstring("openid"))
+		})
+
+		It("should fail when no provider is configured", func() {
+			disabled, err := service.NewOIDCService(service.OIDCConfig{}, nil, nil, nil, nil, nil)
+			Expect(err).To(BeNil())
+			_, err = disabled.Begin()
+			Expect(err).To(MatchError(service.ErrOIDCDisabled))
+		})
+	})
+
+	Describe("Complete", func() {
+		It("should create a local user with mapped roles on first login", func() {
+			provider.SetUser(oidctest.User{
+				Subject:           "staff-0001",
+				Email:             "Grace@Corp.example",
+				EmailVerified:     true,
+				PreferredUsername: "grace",
+				Claims:            map[string]interface{}{"groups": []string{"video-admins", "staff"}},
+			})
+
+			user, err := login()
+			Expect(err).To(BeNil())
+			Expect(user.Username).To(Equal("grace"))
+			Expect(*user.Email).To(Equal("grace@corp.example"))
+			Expect(user.EmailVerifiedAt).NotTo(BeNil())
+			Expect(user.RoleNames()).To(ConsistOf(entity.RoleAdmin))
+		})
+
+		It("should sign the same account in again and resync its roles", func() {
+			provider.SetUser(oidctest.User{Subject: "staff-0002", PreferredUsername: "heidi",
+				Claims: map[string]interface{}{"groups": []string{"video-admins"}}})
+			first, err := login()
+			Expect(err).To(BeNil())
+
+			provider.SetUser(oidctest.User{Subject: "staff-0002", PreferredUsername: "heidi"})
+			second, err := login()
+			Expect(err).To(BeNil())
+			Expect(second.ID).To(Equal(first.ID))
+			Expect(second.RoleNames()).To(ConsistOf(entity.RoleViewer))
+		})
+
+		It("should link a provider-only user with the same verified email", func() {
+			email := "grace@corp.example"
+			provider.SetUser(oidctest.User{Subject: "staff-0006", Email: email, EmailVerified: true, PreferredUsername: "grace"})
+			existing, err := login()
+			Expect(err).To(BeNil())
+
+			provider.SetUser(oidctest.User{Subject: "staff-0007", Email: email, EmailVerified: true, PreferredUsername: "grace"})
+			user, err := login()
+			Expect(err).To(BeNil())
+			Expect(user.ID).To(Equal(existing.ID))
+		})
+
+		It("should require a user with a password to link explicitly", func() {
+			existing, err := userService.Create("ivan-local", "ivan-password")
+			Expect(err).To(BeNil())
+			email := "ivan@corp.example"
+			existing.Email = &email
+			Expect(repository.NewUserRepository(testDB).Update(existing)).To(Succeed())
+
+			provider.SetUser(oidctest.User{Subject: "staff-0003", Email: email, EmailVerified: true, PreferredUsername: "ivan"})
+			_, err = login()
+			Expect(err).To(MatchError(service.ErrOIDCLinkRequired))
+
+			flow, err := oidcService.BeginLink(existing.ID.String())
+			Expect(err).To(BeNil())
+			Expect(flow.LinkToken).NotTo(BeEmpty())
+			user, err := complete(flow)
+			Expect(err).To(BeNil())
+			Expect(user.ID).To(Equal(existing.ID))
+
+			user, err = login()
+			Expect(err).To(BeNil())
+			Expect(user.ID).To(Equal(existing.ID))
+
+			_, err = complete(flow)
+			Expect(err).To(MatchError(service.ErrInvalidToken))
+		})
+
+		It("should not link a provider account that belongs to another user", func() {
+			provider.SetUser(oidctest.User{Subject: "staff-0008", PreferredUsername: "oscar"})
+			_, err := login()
+			Expect(err).To(BeNil())
+
+			other, err := userService.Create("oscar-local", "oscar-password")
+			Expect(err).To(BeNil())
+			flow, err := oidcService.BeginLink(other.ID.String())
+			Expect(err).To(BeNil())
+			_, err = complete(flow)
+			Expect(err).To(MatchError(service.ErrOIDCIdentityLinked))
+		})
+
+		It("should not link by an unverified email", func() {
+			existing, err := userService.Create("judy-local", "judy-password")
+			Expect(err).To(BeNil())
+			email := "judy@corp.example"
+			existing.Email = &email
+			Expect(repository.NewUserRepository(testDB).Update(existing)).To(Succeed())
+
+			provider.SetUser(oidctest.User{Subject: "staff-0004", Email: email, PreferredUsername: "judy-local"})
+			user, err := login()
+			Expect(err).To(BeNil())
+			Expect(user.ID).NotTo(Equal(existing.ID))
+			Expect(user.Username).To(HavePrefix("judy-local-"))
+			Expect(user.Email).To(BeNil())
+		})
+
+		It("should reject a callback with a different state", func() {
+			flow, err := oidcService.Begin()
+			Expect(err).To(BeNil())
+			callback, err := provider.Authorize(flow.AuthURL)
+			Expect(err).To(BeNil())
+
+			_, err = oidcService.Complete(context.Background(), *flow, "forged", callback.Query().Get("code"))
+			Expect(err).To(MatchError(service.ErrOIDCInvalidState))
+		})
+
+		It("should reject a code redeemed without the matching PKCE verifier", func() {
+			provider.SetUser(oidctest.User{Subject: "staff-0005", PreferredUsername: "mallory"})
+			flow, err := oidcService.Begin()
+			Expect(err).To(BeNil())
+			callback, err := provider.Authorize(flow.AuthURL)
+			Expect(err).To(BeNil())
+
+			other, err := oidcService.Begin()
+			Expect(err).To(BeNil())
+			flow.Verifier = other.Verifier
+			_, err = oidcService.Complete(context.Background(), *flow, callback.Query().Get("state"), callback.Query().Get("code"))
+			Expect(err).To(MatchError(service.ErrOIDCLoginRejected))
+		})
+	})
+})
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"gorm.io/gorm"
+)
+
+// playlistPositionGap is the distance between item positions after a
+// renumber. An item can be moved between the same two neighbours about
+// 16 times before they have to be renumbered.
+const playlistPositionGap int64 = 1 << 16
+
+var (
+	ErrPlaylistNotFound       = errors.New("playlist not found")
+	ErrPlaylistForbidden      = errors.New("you are not allowed to modify this playlist")
+	ErrPlaylistItemNotFound   = errors.New("playlist item not found")
+	ErrVideoAlreadyInPlaylist = errors.New("video is already in the playlist")
+)
+
+type PlaylistService interface {
+	Create(request dto.PlaylistCreateRequest, principal *auth.Principal) (*entity.Playlist, error)
+	// GetByID returns a playlist with its items. Private playlists of
+	// other users are reported as not found.
+	GetByID(id string, principal *auth.Principal) (*entity.Playlist, error)
+	// GetByOwner lists a user's playlists without their items; other users
+	// only see the public ones
+	GetByOwner(ownerID string, principal *auth.Principal) ([]entity.Playlist, error)
+	Update(id string, request dto.PlaylistUpdateRequest, principal *auth.Principal) (*entity.Playlist, error)
+	Delete(id string, principal *auth.Principal) error
+	// AddItem inserts a video at the zero-based position, or appends it
+	// if position is nil
+	AddItem(id string, videoID string, position *int, principal *auth.Principal) (*entity.Playlist, error)
+	// MoveItem moves an item to the zero-based position
+	MoveItem(id string, itemID string, position int, principal *auth.Principal) (*entity.Playlist, error)
+	RemoveItem(id string, itemID string, principal *auth.Principal) (*entity.Playlist, error)
+}
+
+type playlistService struct {
+	playlists repository.PlaylistRepository
+	videos    repository.VideoRepository
+}
+
+func NewPlaylistService(playlists repository.PlaylistRepository, videos repository.VideoRepository) PlaylistService {
+	return &playlistService{
+		playlists: playlists,
+		videos:    videos,
+	}
+}
+
+func (s *playlistService) Create(request dto.PlaylistCreateRequest, principal *auth.Principal) (*entity.Playlist, error) {
+	if principal == nil {
+		return nil, ErrPlaylistForbidden
+	}
+	ownerID, err := uuid.Parse(principal.UserID)
+	if err != nil {
+		return nil, ErrPlaylistForbidden
+	}
+	playlist := entity.Playlist{
+		OwnerID:     ownerID,
+		Title:       request.Title,
+		Description: request.Description,
+		Visibility:  request.Visibility,
+		Items:       []entity.PlaylistItem{},
+	}
+	if playlist.Visibility == "" {
+		playlist.Visibility = entity.VisibilityPrivate
+	}
+	if err := s.playlists.Save(&playlist); err != nil {
+		return nil, err
+	}
+	return &playlist, nil
+}
+
+func (s *playlistService) GetByID(id string, principal *auth.Principal) (*entity.Playlist, error) {
+	playlist, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if playlist.Visibility == entity.VisibilityPrivate && !canModifyPlaylist(playlist, principal) {
+		return nil, ErrPlaylistNotFound
+	}
+	return playlist, nil
+}
+
+func (s *playlistService) GetByOwner(ownerID string, principal *auth.Principal) ([]entity.Playlist, error) {
+	publicOnly := principal == nil || (principal.UserID != ownerID && !principal.HasPermission(auth.PermVideosAdmin))
+	return s.playlists.FindByOwner(ownerID, publicOnly)
+}
+
+func (s *playlistService) Update(id string, request dto.PlaylistUpdateRequest, principal *auth.Principal) (*entity.Playlist, error) {
+	playlist, err := s.authorize(id, principal)
+	if err != nil {
+		return nil, err
+	}
+	if request.Title != nil {
+		playlist.Title = *request.Title
+	}
+	if request.Description != nil {
+		playlist.Description = *request.Description
+	}
+	if request.Visibility != nil {
+		playlist.Visibility = *request.Visibility
+	}
+	if err := s.playlists.Update(playlist); err != nil {
+		return nil, err
+	}
+	return playlist, nil
+}
+
+func (s *playlistService) Delete(id string, principal *auth.Principal) error {
+	if _, err := s.authorize(id, principal); err != nil {
+		return err
+	}
+	return s.playlists.Delete(id)
+}
+
+func (s *playlistService) AddItem(id string, videoID string, position *int, principal *auth.Principal) (*entity.Playlist, error) {
+	playlist, err := s.authorize(id, principal)
+	if err != nil {
+		return nil, err
+	}
+	video, err := s.videos.FindByID(videoID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrVideoNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range playlist.Items {
+		if item.VideoID == video.ID {
+			return nil, ErrVideoAlreadyInPlaylist
+		}
+	}
+
+	index := len(playlist.Items)
+	if position != nil {
+		index = *position
+	}
+	item := entity.PlaylistItem{PlaylistID: playlist.ID, VideoID: video.ID}
+	if item.Position, err = s.position(playlist.Items, index); err != nil {
+		return nil, err
+	}
+	if err := s.playlists.AddItem(&item); err != nil {
+		return nil, err
+	}
+	return s.find(id)
+}
+
+func (s *playlistService) MoveItem(id string, itemID string, position int, principal *auth.Principal) (*entity.Playlist, error) {
+	playlist, err := s.authorize(id, principal)
+	if err != nil {
+		return nil, err
+	}
+	// Place the item among the others as if it had been taken out
+	others := make([]entity.PlaylistItem, 0, len(playlist.Items))
+	found := false
+	for _, item := range playlist.Items {
+		if item.ID.String() == itemID {
+			found = true
+			continue
+		}
+		others = append(others, item)
+	}
+	if !found {
+		return nil, ErrPlaylistItemNotFound
+	}
+	newPosition, err := s.position(others, position)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.playlists.MoveItem(itemID, newPosition); err != nil {
+		return nil, err
+	}
+	return s.find(id)
+}
+
+func (s *playlistService) RemoveItem(id string, itemID string, principal *auth.Principal) (*entity.Playlist, error) {
+	if _, err := s.authorize(id, principal); err != nil {
+		return nil, err
+	}
+	err := s.playlists.RemoveItem(id, itemID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPlaylistItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return s.find(id)
+}
+
+func (s *playlistService) find(id string) (*entity.Playlist, error) {
+	playlist, err := s.playlists.FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrPlaylistNotFound
+	}
+	return playlist, err
+}
+
+// authorize loads a playlist and checks that the principal may modify it.
+// Private playlists of other users are reported as not found.
+func (s *playlistService) authorize(id string, principal *auth.Principal) (*entity.Playlist, error) {
+	playlist, err := s.find(id)
+	if err != nil {
+		return nil, err
+	}
+	if canModifyPlaylist(playlist, principal) {
+		return playlist, nil
+	}
+	if playlist.Visibility == entity.VisibilityPrivate {
+		return nil, ErrPlaylistNotFound
+	}
+	return nil, ErrPlaylistForbidden
+}
+
+// position returns the position that puts a new item at index among items,
+// renumbering the items first if its neighbours left no room
+func (s *playlistService) position(items []entity.PlaylistItem, index int) (int64, error) {
+	index = min(max(index, 0), len(items))
+	if position, ok := positionAt(items, index); ok {
+		return position, nil
+	}
+	if err := s.playlists.Renumber(items, playlistPositionGap); err != nil {
+		return 0, err
+	}
+	for i := range items {
+		items[i].Position = int64(i+1) * playlistPositionGap
+	}
+	position, _ := positionAt(items, index)
+	return position, nil
+}
+
+// positionAt returns the position halfway between the neighbours of index,
+// or false if they are adjacent
+func positionAt(items []entity.PlaylistItem, index int) (int64, bool) {
+	switch {
+	case len(items) == 0:
+		return playlistPositionGap, true
+	case index == 0:
+		return items[0].Position - playlistPositionGap, true
+	case index == len(items):
+		return items[len(items)-1].Position + playlistPositionGap, true
+	}
+	before, after := items[index-1].Position, items[index].Position
+	if after-before < 2 {
+		return 0, false
+	}
+	return before + (after-before)/2, true
+}
+
+func canModifyPlaylist(playlist *entity.Playlist, principal *auth.Principal) bool {
+	if principal == nil {
+		return false
+	}
+	return playlist.OwnerID.String() == principal.UserID || principal.HasPermission(auth.PermVideosAdmin)
+}
@@ -0,0 +1,165 @@
+package service_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+var _ = Describe("PlaylistService", func() {
+	var (
+		playlistService service.PlaylistService
+		videoService    service.VideoService
+		playlist        *entity.Playlist
+		videos          []entity.Video
+	)
+
+	titles := func(p *entity.Playlist) []string {
+		found := []string{}
+		for _, item := range p.Items {
+			found = append(found, item.Video.Title)
+		}
+		return found
+	}
+
+	itemOf := func(p *entity.Playlist, title string) string {
+		for _, item := range p.Items {
+			if item.Video.Title == title {
+				return item.ID.String()
+			}
+		}
+		Fail("no item for " + title)
+		return ""
+	}
+
+	BeforeEach(func() {
+		videoRepository := repository.NewVideoRepository(testDB)
+		videoService = service.New(videoRepository, repository.NewAuthorRepository(testDB), repository.NewRevisionRepository(testDB))
+		playlistService = service.NewPlaylistService(repository.NewPlaylistRepository(testDB), videoRepository)
+
+		videos = nil
+		for _, title := range []string{"Playlist A", "Playlist B", "Playlist C", "Playlist D"} {
+			request := testVideo
+			request.Title = title
+			video, err := videoService.Save(request, videoAdmin)
+			Expect(err).To(BeNil())
+			videos = append(videos, video)
+		}
+		DeferCleanup(func() {
+			for _, video := range videos {
+				_ = videoService.Delete(video.ID.String(), service.AnyVersion, videoAdmin)
+			}
+		})
+
+		var err error
+		playlist, err = playlistService.Create(dto.PlaylistCreateRequest{Title: "Favourites"}, videoOwner)
+		Expect(err).To(BeNil())
+	})
+
+	add := func(index int, position *int) *entity.Playlist {
+		updated, err := playlistService.AddItem(playlist.ID.String(), videos[index].ID.String(), position, videoOwner)
+		Expect(err).To(BeNil())
+		return updated
+	}
+
+	It("should create private playlists by default", func() {
+		Expect(playlist.Visibility).To(Equal(entity.VisibilityPrivate))
+		Expect(playlist.OwnerID.String()).To(Equal(videoOwner.UserID))
+
+		_, err := playlistService.GetByID(playlist.ID.String(), otherMember)
+		Expect(err).To(MatchError(service.ErrPlaylistNotFound))
+		found, err := playlistService.GetByID(playlist.ID.String(), videoAdmin)
+		Expect(err).To(BeNil())
+		Expect(found.Title).To(Equal("Favourites"))
+	})
+
+	It("should only list public playlists to other users", func() {
+		public := entity.VisibilityPublic
+		_, err := playlistService.Create(dto.PlaylistCreateRequest{Title: "Shared", Visibility: public}, videoOwner)
+		Expect(err).To(BeNil())
+
+		mine, err := playlistService.GetByOwner(videoOwner.UserID, videoOwner)
+		Expect(err).To(BeNil())
+		theirs, err := playlistService.GetByOwner(videoOwner.UserID, otherMember)
+		Expect(err).To(BeNil())
+		Expect(len(theirs)).To(BeNumerically("<", len(mine)))
+		for _, p := range theirs {
+			Expect(p.Visibility).To(Equal(entity.VisibilityPublic))
+		}
+	})
+
+	It("should refuse changes from other users", func() {
+		unlisted := entity.VisibilityUnlisted
+		_, err := playlistService.Update(playlist.ID.String(), dto.PlaylistUpdateRequest{Visibility: &unlisted}, videoOwner)
+		Expect(err).To(BeNil())
+
+		found, err := playlistService.GetByID(playlist.ID.String(), otherMember)
+		Expect(err).To(BeNil())
+		Expect(found.Visibility).To(Equal(entity.VisibilityUnlisted))
+		_, err = playlistService.AddItem(playlist.ID.String(), videos[0].ID.String(), nil, otherMember)
+		Expect(err).To(MatchError(service.ErrPlaylistForbidden))
+		Expect(playlistService.Delete(playlist.ID.String(), otherMember)).To(MatchError(service.ErrPlaylistForbidden))
+	})
+
+	It("should append and insert items", func() {
+		add(0, nil)
+		add(1, nil)
+		first, middle := 0, 1
+		add(2, &first)
+		updated := add(3, &middle)
+		Expect(titles(updated)).To(Equal([]string{"Playlist C", "Playlist D", "Playlist A", "Playlist B"}))
+
+		_, err := playlistService.AddItem(playlist.ID.String(), videos[0].ID.String(), nil, videoOwner)
+		Expect(err).To(MatchError(service.ErrVideoAlreadyInPlaylist))
+		_, err = playlistService.AddItem(playlist.ID.String(), "00000000-0000-0000-0000-000000000000", nil, videoOwner)
+		Expect(err).To(MatchError(service.ErrVideoNotFound))
+	})
+
+	It("should move and remove items", func() {
+		add(0, nil)
+		add(1, nil)
+		updated := add(2, nil)
+
+		updated, err := playlistService.MoveItem(playlist.ID.String(), itemOf(updated, "Playlist A"), 2, videoOwner)
+		Expect(err).To(BeNil())
+		Expect(titles(updated)).To(Equal([]string{"Playlist B", "Playlist C", "Playlist A"}))
+
+		updated, err = playlistService.MoveItem(playlist.ID.String(), itemOf(updated, "Playlist C"), 0, videoOwner)
+		Expect(err).To(BeNil())
+		Expect(titles(updated)).To(Equal([]string{"Playlist C", "Playlist B", "Playlist A"}))
+
+		updated, err = playlistService.RemoveItem(playlist.ID.String(), itemOf(updated, "Playlist B"), videoOwner)
+		Expect(err).To(BeNil())
+		Expect(titles(updated)).To(Equal([]string{"Playlist C", "Playlist A"}))
+
+		_, err = playlistService.RemoveItem(playlist.ID.String(), "00000000-0000-0000-0000-000000000000", videoOwner)
+		Expect(err).To(MatchError(service.ErrPlaylistItemNotFound))
+	})
+
+	It("should keep the order when the gaps between items run out", func() {
+		add(0, nil)
+		add(1, nil)
+		updated := add(2, nil)
+		// Swapping the last two items halves the same gap every time
+		for i := 0; i < 40; i++ {
+			var err error
+			updated, err = playlistService.MoveItem(playlist.ID.String(), updated.Items[2].ID.String(), 1, videoOwner)
+			Expect(err).To(BeNil())
+		}
+		Expect(titles(updated)).To(Equal([]string{"Playlist A", "Playlist B", "Playlist C"}))
+	})
+
+	It("should hide items whose video was deleted", func() {
+		add(0, nil)
+		add(1, nil)
+		Expect(videoService.Delete(videos[0].ID.String(), service.AnyVersion, videoAdmin)).To(Succeed())
+
+		found, err := playlistService.GetByID(playlist.ID.String(), videoOwner)
+		Expect(err).To(BeNil())
+		Expect(titles(found)).To(Equal([]string{"Playlist B"}))
+	})
+})
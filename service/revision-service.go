@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"slices"
+
+	"github.com/google/uuid"
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"gorm.io/gorm"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+// RevisionChange is a metadata field that differs between two revisions
+type RevisionChange struct {
+	Field string
+	From  interface{}
+	To    interface{}
+}
+
+type RevisionService interface {
+	// List returns the revisions of a video, oldest first
+	List(videoID string) ([]entity.VideoRevision, error)
+	Get(videoID string, number int64) (*entity.VideoRevision, error)
+	// Diff compares the video as it was after revision from with the video
+	// as it was after revision to
+	Diff(videoID string, from, to int64) ([]RevisionChange, error)
+}
+
+type revisionService struct {
+	revisions repository.RevisionRepository
+	videos    repository.VideoRepository
+}
+
+func NewRevisionService(revisions repository.RevisionRepository, videos repository.VideoRepository) RevisionService {
+	return &revisionService{
+		revisions: revisions,
+		videos:    videos,
+	}
+}
+
+func (s *revisionService) List(videoID string) ([]entity.VideoRevision, error) {
+	if err := s.checkVideo(videoID); err != nil {
+		return nil, err
+	}
+	return s.revisions.FindByVideo(videoID)
+}
+
+func (s *revisionService) Get(videoID string, number int64) (*entity.VideoRevision, error) {
+	if err := s.checkVideo(videoID); err != nil {
+		return nil, err
+	}
+	return findRevision(s.revisions, videoID, number)
+}
+
+func (s *revisionService) Diff(videoID string, from, to int64) ([]RevisionChange, error) {
+	if err := s.checkVideo(videoID); err != nil {
+		return nil, err
+	}
+	fromRevision, err := findRevision(s.revisions, videoID, from)
+	if err != nil {
+		return nil, err
+	}
+	toRevision, err := findRevision(s.revisions, videoID, to)
+	if err != nil {
+		return nil, err
+	}
+	return diffSnapshots(fromRevision.After, toRevision.After), nil
+}
+
+// checkVideo fails with ErrVideoNotFound unless the video exists and is
+// not in the trash
+func (s *revisionService) checkVideo(videoID string) error {
+	_, err := s.videos.FindByID(videoID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrVideoNotFound
+	}
+	return err
+}
+
+func findRevision(revisions repository.RevisionRepository, videoID string, number int64) (*entity.VideoRevision, error) {
+	revision, err := revisions.FindOne(videoID, number)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrRevisionNotFound
+	}
+	return revision, err
+}
+
+// newRevision describes a change of video by principal. before is nil
+// when the video is created.
+func newRevision(action string, video *entity.Video, before *entity.VideoSnapshot, principal *auth.Principal) *entity.VideoRevision {
+	after := entity.NewVideoSnapshot(video)
+	revision := &entity.VideoRevision{
+		VideoID:       video.ID,
+		Action:        action,
+		ChangedFields: []string{},
+		Before:        before,
+		After:         after,
+	}
+	for _, change := range diffSnapshots(before, after) {
+		revision.ChangedFields = append(revision.ChangedFields, change.Field)
+	}
+	if principal != nil {
+		revision.ActorName = principal.Username
+		if actorID, err := uuid.Parse(principal.UserID); err == nil {
+			revision.ActorID = &actorID
+		}
+	}
+	return revision
+}
+
+// diffSnapshots lists the fields that differ between two snapshots by their
+// JSON names. Every field differs from a nil snapshot.
+func diffSnapshots(from, to *entity.VideoSnapshot) []RevisionChange {
+	if from == nil {
+		from = &entity.VideoSnapshot{}
+	}
+	changes := []RevisionChange{}
+	add := func(field string, before, after interface{}, changed bool) {
+		if changed {
+			changes = append(changes, RevisionChange{Field: field, From: before, To: after})
+		}
+	}
+	add("title", from.Title, to.Title, from.Title != to.Title)
+	add("description", from.Description, to.Description, from.Description != to.Description)
+	add("url", from.URL, to.URL, from.URL != to.URL)
+	add("author_id", from.AuthorID, to.AuthorID, from.AuthorID != to.AuthorID)
+	add("tags", from.Tags, to.Tags, !slices.Equal(from.Tags, to.Tags))
+	return changes
+}
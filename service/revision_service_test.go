@@ -0,0 +1,133 @@
+package service_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+var _ = Describe("RevisionService", func() {
+	var (
+		revisionService service.RevisionService
+		videoService    service.VideoService
+		video           entity.Video
+	)
+
+	BeforeEach(func() {
+		videoRepository := repository.NewVideoRepository(testDB)
+		revisionRepository := repository.NewRevisionRepository(testDB)
+		videoService = service.New(videoRepository, repository.NewAuthorRepository(testDB), revisionRepository)
+		revisionService = service.NewRevisionService(revisionRepository, videoRepository)
+
+		request := testVideo
+		request.Title = "Revised video"
+		request.Tags = []string{"revisionspec"}
+		var err error
+		video, err = videoService.Save(request, videoOwner)
+		Expect(err).To(BeNil())
+		DeferCleanup(func() {
+			_ = videoService.Delete(video.ID.String(), service.AnyVersion, videoAdmin)
+			_ = service.NewTrashService(service.TrashConfig{Retention: time.Hour, PurgeInterval: time.Hour},
+				repository.NewTrashRepository(testDB)).Purge(video.ID.String(), videoAdmin)
+		})
+	})
+
+	It("should record who changed which fields", func() {
+		_, err := videoService.Patch(video.ID.String(), service.MergePatch, []byte(`{"title": "Revised twice", "tags": ["revisionspec", "second"]}`), service.AnyVersion, videoOwner)
+		Expect(err).To(BeNil())
+		// Patches that change nothing are not recorded
+		_, err = videoService.Patch(video.ID.String(), service.MergePatch, []byte(`{"title": "Revised twice"}`), service.AnyVersion, videoOwner)
+		Expect(err).To(BeNil())
+		updated, err := videoService.GetByID(video.ID.String())
+		Expect(err).To(BeNil())
+		updated.Description = "Changed by the admin"
+		_, err = videoService.Update(*updated, videoAdmin)
+		Expect(err).To(BeNil())
+
+		revisions, err := revisionService.List(video.ID.String())
+		Expect(err).To(BeNil())
+		Expect(revisions).To(HaveLen(3))
+
+		Expect(revisions[0].Number).To(Equal(int64(1)))
+		Expect(revisions[0].Action).To(Equal(entity.RevisionCreate))
+		Expect(revisions[0].Before).To(BeNil())
+		Expect(revisions[0].After.Title).To(Equal("Revised video"))
+		Expect(revisions[0].ActorName).To(Equal(videoOwner.Username))
+
+		Expect(revisions[1].Number).To(Equal(int64(2)))
+		Expect(revisions[1].Action).To(Equal(entity.RevisionUpdate))
+		Expect(revisions[1].ChangedFields).To(Equal([]string{"title", "tags"}))
+		Expect(revisions[1].Before.Title).To(Equal("Revised video"))
+		Expect(revisions[1].After.Tags).To(Equal([]string{"revisionspec", "second"}))
+
+		Expect(revisions[2].ChangedFields).To(Equal([]string{"description"}))
+		Expect(revisions[2].ActorID.String()).To(Equal(videoAdmin.UserID))
+	})
+
+	It("should compare two revisions field by field", func() {
+		_, err := videoService.Patch(video.ID.String(), service.MergePatch, []byte(`{"title": "Revised twice"}`), service.AnyVersion, videoOwner)
+		Expect(err).To(BeNil())
+		_, err = videoService.Patch(video.ID.String(), service.MergePatch, []byte(`{"description": "Longer description"}`), service.AnyVersion, videoOwner)
+		Expect(err).To(BeNil())
+
+		changes, err := revisionService.Diff(video.ID.String(), 1, 3)
+		Expect(err).To(BeNil())
+		Expect(changes).To(Equal([]service.RevisionChange{
+			{Field: "title", From: "Revised video", To: "Revised twice"},
+			{Field: "description", From: testVideo.Description, To: "Longer description"},
+		}))
+		changes, err = revisionService.Diff(video.ID.String(), 3, 3)
+		Expect(err).To(BeNil())
+		Expect(changes).To(BeEmpty())
+
+		_, err = revisionService.Diff(video.ID.String(), 1, 9)
+		Expect(err).To(MatchError(service.ErrRevisionNotFound))
+	})
+
+	It("should roll a video back to a revision", func() {
+		_, err := videoService.Patch(video.ID.String(), service.MergePatch, []byte(`{"title": "Bad edit", "tags": []}`), service.AnyVersion, videoOwner)
+		Expect(err).To(BeNil())
+
+		_, err = videoService.RestoreRevision(video.ID.String(), 1, service.AnyVersion, otherMember)
+		Expect(err).To(MatchError(service.ErrForbidden))
+		_, err = videoService.RestoreRevision(video.ID.String(), 1, 1, videoOwner)
+		Expect(err).To(MatchError(service.ErrVersionMismatch))
+		_, err = videoService.RestoreRevision(video.ID.String(), 7, service.AnyVersion, videoOwner)
+		Expect(err).To(MatchError(service.ErrRevisionNotFound))
+
+		restored, err := videoService.RestoreRevision(video.ID.String(), 1, 2, videoOwner)
+		Expect(err).To(BeNil())
+		Expect(restored.Title).To(Equal("Revised video"))
+		Expect(entity.TagNames(restored.Tags)).To(Equal([]string{"revisionspec"}))
+		Expect(restored.Version).To(Equal(int64(3)))
+
+		revision, err := revisionService.Get(video.ID.String(), 3)
+		Expect(err).To(BeNil())
+		Expect(revision.Action).To(Equal(entity.RevisionRollback))
+		Expect(*revision.RestoredFrom).To(Equal(int64(1)))
+		Expect(revision.ChangedFields).To(Equal([]string{"title", "tags"}))
+	})
+
+	It("should record deletions and restores from the trash", func() {
+		trashService := service.NewTrashService(service.TrashConfig{Retention: time.Hour, PurgeInterval: time.Hour},
+			repository.NewTrashRepository(testDB))
+		Expect(videoService.Delete(video.ID.String(), service.AnyVersion, videoOwner)).To(Succeed())
+		_, err := revisionService.List(video.ID.String())
+		Expect(err).To(MatchError(service.ErrVideoNotFound))
+		_, err = trashService.Restore(video.ID.String(), videoOwner)
+		Expect(err).To(BeNil())
+
+		revisions, err := revisionService.List(video.ID.String())
+		Expect(err).To(BeNil())
+		Expect(revisions).To(HaveLen(3))
+		Expect(revisions[1].Action).To(Equal(entity.RevisionDelete))
+		Expect(revisions[2].Action).To(Equal(entity.RevisionRestore))
+		Expect(revisions[2].Number).To(Equal(int64(3)))
+		Expect(revisions[2].ChangedFields).To(BeEmpty())
+	})
+})
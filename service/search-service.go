@@ -0,0 +1,41 @@
+package service
+
+import (
+	"github.com/muzammil-cyber/golang-gin/repository"
+)
+
+// VideoSearchResults is one page of search hits
+type VideoSearchResults struct {
+	Hits []repository.VideoSearchHit
+	// Total is the number of hits on all pages
+	Total int64
+}
+
+type SearchService interface {
+	// SearchVideos returns the videos matching query, best matches first.
+	// It fails with repository.ErrEmptySearch if the query has no words.
+	SearchVideos(query string, limit int, offset int) (VideoSearchResults, error)
+}
+
+type searchService struct {
+	searcher repository.VideoSearcher
+}
+
+func NewSearchService(searcher repository.VideoSearcher) SearchService {
+	return &searchService{
+		searcher: searcher,
+	}
+}
+
+func (s *searchService) SearchVideos(query string, limit int, offset int) (VideoSearchResults, error) {
+	if limit <= 0 {
+		limit = repository.DefaultVideoPageSize
+	}
+	limit = min(limit, repository.MaxVideoPageSize)
+	offset = max(offset, 0)
+	hits, total, err := s.searcher.Search(query, limit, offset)
+	if err != nil {
+		return VideoSearchResults{}, err
+	}
+	return VideoSearchResults{Hits: hits, Total: total}, nil
+}
@@ -0,0 +1,144 @@
+package service_test
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/google/uuid"
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+// searchOwner creates the videos of the search specs, so that they do not
+// show up among the videos of other owners
+var searchOwner = &auth.Principal{
+	UserID:      "3c2b1a09-8f7e-4d6c-9b5a-4f3e2d1c0b9a",
+	Username:    "searcher",
+	Permissions: []string{auth.PermVideosRead, auth.PermVideosWrite, auth.PermVideosDelete},
+}
+
+var _ = Describe("SearchService", func() {
+	var (
+		searchService service.SearchService
+		videoService  service.VideoService
+		// tag is a word no other spec uses, so searches only see the
+		// videos of this spec
+		tag string
+	)
+
+	save := func(title string, description string) entity.Video {
+		video, err := videoService.Save(dto.VideoCreateRequest{
+			Title:       title,
+			Description: description,
+			URL:         "https://www.example.com/search",
+			Author:      &entity.Person{Name: "Search Author", Email: "search@example.com", Age: 30},
+		}, searchOwner)
+		Expect(err).To(BeNil())
+		return video
+	}
+
+	titles := func(results service.VideoSearchResults) []string {
+		found := make([]string, len(results.Hits))
+		for i, hit := range results.Hits {
+			found[i] = hit.Video.Title
+		}
+		return found
+	}
+
+	BeforeEach(func() {
+		videoService = service.New(repository.NewVideoRepository(testDB), repository.NewAuthorRepository(testDB), repository.NewRevisionRepository(testDB))
+		searchService = service.NewSearchService(repository.NewVideoSearcher(testDB))
+		tag = "t" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	})
+
+	It("ranks title matches above description matches", func() {
+		save("Cooking basics "+tag, "Pasta and sauces")
+		save("Gardening "+tag, "Growing herbs for cooking")
+
+		results, err := searchService.SearchVideos(tag+" cooking", 10, 0)
+		Expect(err).To(BeNil())
+		Expect(results.Total).To(Equal(int64(2)))
+		Expect(titles(results)).To(Equal([]string{"Cooking basics " + tag, "Gardening " + tag}))
+		Expect(results.Hits[0].Score).To(BeNumerically(">", results.Hits[1].Score))
+		Expect(results.Hits[0].TitleHighlight).To(ContainSubstring("<mark>Cooking</mark>"))
+		Expect(results.Hits[1].Snippet).To(ContainSubstring("<mark>cooking</mark>"))
+	})
+
+	It("escapes HTML outside the highlight markers", func() {
+		save("<script>alert(1)</script> cooking "+tag, `<img src=x onerror="alert(1)"> cooking`)
+
+		results, err := searchService.SearchVideos(tag+" cooking", 10, 0)
+		Expect(err).To(BeNil())
+		Expect(results.Hits).To(HaveLen(1))
+		Expect(results.Hits[0].TitleHighlight).To(HavePrefix("&lt;script&gt;alert(1)&lt;/script&gt; <mark>cooking</mark>"))
+		Expect(results.Hits[0].Snippet).To(Equal("&lt;img src=x onerror=&#34;alert(1)&#34;&gt; <mark>cooking</mark>"))
+	})
+
+	It("matches word prefixes", func() {
+		save("Golang generics "+tag, "Type parameters")
+		save("Gophers "+tag, "Mascots")
+
+		results, err := searchService.SearchVideos(tag+" gola*", 10, 0)
+		Expect(err).To(BeNil())
+		Expect(titles(results)).To(Equal([]string{"Golang generics " + tag}))
+
+		results, err = searchService.SearchVideos(tag+" gola", 10, 0)
+		Expect(err).To(BeNil())
+		Expect(results.Hits).To(BeEmpty())
+	})
+
+	It("matches quoted phrases in order", func() {
+		save("Getting started "+tag, "A first look")
+		save("Started getting "+tag, "A second look")
+
+		results, err := searchService.SearchVideos(tag+` "getting started"`, 10, 0)
+		Expect(err).To(BeNil())
+		Expect(titles(results)).To(Equal([]string{"Getting started " + tag}))
+	})
+
+	It("follows updates and deletes", func() {
+		video := save("Painting "+tag, "Watercolors")
+		video.Title = "Sculpting " + tag
+		_, err := videoService.Update(video, searchOwner)
+		Expect(err).To(BeNil())
+
+		results, err := searchService.SearchVideos(tag+" painting", 10, 0)
+		Expect(err).To(BeNil())
+		Expect(results.Hits).To(BeEmpty())
+		results, err = searchService.SearchVideos(tag+" sculpting", 10, 0)
+		Expect(err).To(BeNil())
+		Expect(titles(results)).To(Equal([]string{"Sculpting " + tag}))
+
+		Expect(videoService.Delete(video.ID.String(), service.AnyVersion, searchOwner)).To(Succeed())
+		results, err = searchService.SearchVideos(tag, 10, 0)
+		Expect(err).To(BeNil())
+		Expect(results.Hits).To(BeEmpty())
+	})
+
+	It("pages through the hits", func() {
+		for _, title := range []string{"One ", "Two ", "Three "} {
+			save(title+tag, "Counting")
+		}
+
+		results, err := searchService.SearchVideos(tag, 2, 2)
+		Expect(err).To(BeNil())
+		Expect(results.Total).To(Equal(int64(3)))
+		Expect(results.Hits).To(HaveLen(1))
+	})
+
+	It("treats query syntax as plain words", func() {
+		save("Syntax "+tag, "Operators")
+
+		results, err := searchService.SearchVideos(tag+` syntax OR ^NEAR(" -`, 10, 0)
+		Expect(err).To(BeNil())
+		Expect(results.Hits).To(BeEmpty())
+
+		_, err = searchService.SearchVideos(`"*" - ()`, 10, 0)
+		Expect(err).To(MatchError(repository.ErrEmptySearch))
+	})
+})
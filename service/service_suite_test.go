@@ -1,13 +1,58 @@
 package service_test
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/database"
+	"github.com/muzammil-cyber/golang-gin/database/factory"
+	"github.com/muzammil-cyber/golang-gin/database/sqlite"
+	"github.com/muzammil-cyber/golang-gin/migrations"
+	"github.com/muzammil-cyber/golang-gin/repository"
 )
 
+// testDB is the database the specs run against: the one named by
+// TEST_DATABASE_URL, which is emptied first, or else a fresh SQLite file.
+// The database of TEST_DATABASE_URL must have "test" in its name.
+var testDB database.Database
+
 func TestService(t *testing.T) {
 	RegisterFailHandler(Fail)
 	RunSpecs(t, "Service Suite")
 }
+
+var _ = BeforeSuite(func() {
+	var err error
+	if databaseURL := os.Getenv("TEST_DATABASE_URL"); databaseURL != "" {
+		testDB, err = factory.Open(databaseURL)
+		Expect(err).To(BeNil())
+		// The specs expect an empty database, so the test database is
+		// emptied on every run. Refuse to empty anything that does not
+		// look like a dedicated test database.
+		name := testDB.GetDB().Migrator().CurrentDatabase()
+		Expect(strings.ToLower(name)).To(ContainSubstring("test"),
+			"TEST_DATABASE_URL names the database %q, whose tables would all be dropped; use a dedicated database with \"test\" in its name", name)
+		tables, err := testDB.GetDB().Migrator().GetTables()
+		Expect(err).To(BeNil())
+		for _, table := range tables {
+			Expect(testDB.GetDB().Migrator().DropTable(table)).To(Succeed())
+		}
+	} else {
+		// Keep the suite away from the development database
+		dir, dirErr := os.MkdirTemp("", "service-suite")
+		Expect(dirErr).To(BeNil())
+		DeferCleanup(os.RemoveAll, dir)
+		testDB, err = sqlite.NewSQLiteDBAt(filepath.Join(dir, "sqlite.db"))
+	}
+	Expect(err).To(BeNil())
+	migrator, err := migrations.NewMigrator(testDB)
+	Expect(err).To(BeNil())
+	_, err = migrator.Up()
+	Expect(err).To(BeNil())
+	Expect(repository.SeedRoles(testDB)).To(Succeed())
+})
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+)
+
+// MaxVideoTags is the number of tags a video can carry
+const MaxVideoTags = 20
+
+var ErrInvalidTag = errors.New("invalid tag")
+
+// tagPattern accepts tags of up to 50 letters, digits, spaces, dashes and
+// underscores, starting with a letter or digit
+var tagPattern = regexp.MustCompile(`^[\p{L}\p{N}][\p{L}\p{N} _-]{0,49}$`)
+
+type TagService interface {
+	// GetAll returns the tags in use with the number of videos carrying
+	// each, most used first
+	GetAll() ([]repository.TagCount, error)
+}
+
+type tagService struct {
+	tags repository.TagRepository
+}
+
+func NewTagService(tags repository.TagRepository) TagService {
+	return &tagService{
+		tags: tags,
+	}
+}
+
+func (s *tagService) GetAll() ([]repository.TagCount, error) {
+	return s.tags.FindAllWithCounts()
+}
+
+// normalizeTags lowercases and trims tag names and drops duplicates. It
+// fails with ErrInvalidTag if a name is malformed.
+func normalizeTags(names []string) ([]string, error) {
+	normalized := make([]string, 0, len(names))
+	seen := make(map[string]bool, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.Join(strings.Fields(name), " "))
+		if !tagPattern.MatchString(name) {
+			return nil, fmt.Errorf("%w %q: use 1-50 letters, digits, spaces, dashes or underscores", ErrInvalidTag, name)
+		}
+		if !seen[name] {
+			seen[name] = true
+			normalized = append(normalized, name)
+		}
+	}
+	return normalized, nil
+}
+
+// videoTags converts tag names into the tags of a video
+func videoTags(names []string) ([]entity.Tag, error) {
+	normalized, err := normalizeTags(names)
+	if err != nil {
+		return nil, err
+	}
+	if len(normalized) > MaxVideoTags {
+		return nil, fmt.Errorf("%w: a video can have at most %d tags", ErrInvalidTag, MaxVideoTags)
+	}
+	tags := make([]entity.Tag, len(normalized))
+	for i, name := range normalized {
+		tags[i] = entity.Tag{Name: name}
+	}
+	return tags, nil
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+)
+
+var ErrRefreshTokenReused = errors.New("refresh token has already been used")
+
+// TokenService issues access/refresh token pairs and keeps track of
+// rotated and revoked tokens.
+type TokenService interface {
+	Issue(user *entity.User) (dto.LoginResponse, error)
+	Refresh(refreshToken string) (dto.LoginResponse, error)
+	Logout(userID string, accessJTI string, accessExpiresAt time.Time, refreshToken string) error
+	IsRevoked(jti string) bool
+}
+
+// TokenConfig describes the refresh tokens handed out at login
+type TokenConfig struct {
+	RefreshExpiry time.Duration
+}
+
+type tokenService struct {
+	jwtService    JWTService
+	users         repository.UserRepository
+	refreshTokens repository.RefreshTokenRepository
+	revokedTokens repository.RevokedTokenRepository
+	refreshExpiry time.Duration
+}
+
+func NewTokenService(config TokenConfig, jwtService JWTService, users repository.UserRepository, refreshTokens repository.RefreshTokenRepository, revokedTokens repository.RevokedTokenRepository) TokenService {
+	return &tokenService{
+		jwtService:    jwtService,
+		users:         users,
+		refreshTokens: refreshTokens,
+		revokedTokens: revokedTokens,
+		refreshExpiry: config.RefreshExpiry,
+	}
+}
+
+// Issue starts a new refresh token family for a fresh login
+func (s *tokenService) Issue(user *entity.User) (dto.LoginResponse, error) {
+	record, err := s.refreshTokens.Save(&entity.RefreshToken{
+		FamilyID:  uuid.New(),
+		UserID:    user.ID,
+		ExpiresAt: time.Now().Add(s.refreshExpiry),
+	})
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+	return s.pair(user, record)
+}
+
+// Refresh exchanges a refresh token for a new pair. A token can only be
+// exchanged once; presenting it again means it leaked, so every token of
+// its family is revoked.
+func (s *tokenService) Refresh(refreshToken string) (dto.LoginResponse, error) {
+	record, err := s.lookup(refreshToken)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+	if record.ReplacedByID != nil || record.RevokedAt != nil {
+		if err := s.refreshTokens.RevokeFamily(record.FamilyID.String()); err != nil {
+			return dto.LoginResponse{}, err
+		}
+		return dto.LoginResponse{}, ErrRefreshTokenReused
+	}
+	if time.Now().After(record.ExpiresAt) {
+		return dto.LoginResponse{}, ErrInvalidToken
+	}
+
+	user, err := s.users.FindByID(record.UserID.String())
+	if err != nil {
+		return dto.LoginResponse{}, ErrInvalidToken
+	}
+
+	replacement := &entity.RefreshToken{
+		FamilyID:  record.FamilyID,
+		UserID:    record.UserID,
+		ExpiresAt: time.Now().Add(s.refreshExpiry),
+	}
+	rotated, err := s.refreshTokens.Rotate(record.ID.String(), replacement)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+	if !rotated {
+		// A concurrent request redeemed the same token first
+		if err := s.refreshTokens.RevokeFamily(record.FamilyID.String()); err != nil {
+			return dto.LoginResponse{}, err
+		}
+		return dto.LoginResponse{}, ErrRefreshTokenReused
+	}
+	return s.pair(user, replacement)
+}
+
+// Logout revokes the access token until it expires and, if given, the
+// refresh token family it belongs to.
+func (s *tokenService) Logout(userID string, accessJTI string, accessExpiresAt time.Time, refreshToken string) error {
+	if refreshToken != "" {
+		record, err := s.lookup(refreshToken)
+		if err != nil {
+			return err
+		}
+		if record.UserID.String() != userID {
+			return ErrInvalidToken
+		}
+		if err := s.refreshTokens.RevokeFamily(record.FamilyID.String()); err != nil {
+			return err
+		}
+	}
+	if accessJTI == "" {
+		return nil
+	}
+	return s.revokedTokens.Revoke(accessJTI, accessExpiresAt)
+}
+
+func (s *tokenService) IsRevoked(jti string) bool {
+	revoked, err := s.revokedTokens.IsRevoked(jti)
+	if err != nil {
+		// Fail closed: an unreadable revocation list must not let tokens through
+		log.Printf("failed to check token revocation: %v", err)
+		return true
+	}
+	return revoked
+}
+
+func (s *tokenService) lookup(refreshToken string) (*entity.RefreshToken, error) {
+	claims, err := s.jwtService.ValidateActionToken(refreshToken, entity.PurposeRefresh)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	record, err := s.refreshTokens.FindByID(claims.ID)
+	if err != nil || record.UserID.String() != claims.Subject {
+		return nil, ErrInvalidToken
+	}
+	return record, nil
+}
+
+func (s *tokenService) pair(user *entity.User, record *entity.RefreshToken) (dto.LoginResponse, error) {
+	refreshToken, err := s.jwtService.GenerateActionToken(user.ID.String(), entity.PurposeRefresh, record.ID.String(), record.ExpiresAt)
+	if err != nil {
+		return dto.LoginResponse{}, err
+	}
+	accessToken := s.jwtService.GenerateToken(user)
+	if accessToken == "" {
+		return dto.LoginResponse{}, errors.New("failed to sign access token")
+	}
+	return dto.LoginResponse{
+		Token:        accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
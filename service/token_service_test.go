@@ -0,0 +1,112 @@
+package service_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+var _ = Describe("TokenService", func() {
+	var (
+		tokenService service.TokenService
+		jwtService   service.JWTService
+		user         *entity.User
+	)
+
+	BeforeEach(func() {
+		userRepository := repository.NewUserRepository(testDB)
+		userService := service.NewUserService(userRepository, repository.NewRoleRepository(testDB))
+		Expect(userService.EnsureUser("dave", "dave-password", entity.RoleViewer)).To(Succeed())
+		var err error
+		user, err = userService.GetByUsername("dave")
+		Expect(err).To(BeNil())
+
+		jwtService, err = service.NewJWTService(service.JWTConfig{DevMode: true})
+		Expect(err).To(BeNil())
+		tokenService = service.NewTokenService(service.TokenConfig{RefreshExpiry: 7 * 24 * time.Hour}, jwtService, userRepository, repository.NewRefreshTokenRepository(testDB), repository.NewRevokedTokenRepository(testDB))
+	})
+
+	Describe("Issue", func() {
+		It("should return an access token with a jti and a refresh token", func() {
+			tokens, err := tokenService.Issue(user)
+			Expect(err).To(BeNil())
+			Expect(tokens.RefreshToken).NotTo(BeEmpty())
+
+			token, err := jwtService.ValidateToken(tokens.Token)
+			Expect(err).To(BeNil())
+			claims := token.Claims.(*service.AccessClaims)
+			Expect(claims.ID).NotTo(BeEmpty())
+			Expect(claims.Subject).To(Equal(user.ID.String()))
+			Expect(claims.Roles).To(ConsistOf(entity.RoleViewer))
+			Expect(claims.Permissions).To(ConsistOf(auth.PermVideosRead))
+		})
+
+		It("should not accept a refresh token as an access token", func() {
+			tokens, err := tokenService.Issue(user)
+			Expect(err).To(BeNil())
+			_, err = jwtService.ValidateToken(tokens.RefreshToken)
+			Expect(err).NotTo(BeNil())
+		})
+	})
+
+	Describe("Refresh", func() {
+		It("should rotate the refresh token", func() {
+			first, err := tokenService.Issue(user)
+			Expect(err).To(BeNil())
+			second, err := tokenService.Refresh(first.RefreshToken)
+			Expect(err).To(BeNil())
+			Expect(second.RefreshToken).NotTo(Equal(first.RefreshToken))
+
+			_, err = tokenService.Refresh(second.RefreshToken)
+			Expect(err).To(BeNil())
+		})
+
+		It("should revoke the whole family when a token is reused", func() {
+			first, err := tokenService.Issue(user)
+			Expect(err).To(BeNil())
+			second, err := tokenService.Refresh(first.RefreshToken)
+			Expect(err).To(BeNil())
+
+			_, err = tokenService.Refresh(first.RefreshToken)
+			Expect(err).To(MatchError(service.ErrRefreshTokenReused))
+
+			_, err = tokenService.Refresh(second.RefreshToken)
+			Expect(err).To(MatchError(service.ErrRefreshTokenReused))
+		})
+
+		It("should reject garbage", func() {
+			_, err := tokenService.Refresh("not-a-token")
+			Expect(err).To(MatchError(service.ErrInvalidToken))
+		})
+	})
+
+	Describe("Logout", func() {
+		It("should revoke the access token and the refresh token family", func() {
+			tokens, err := tokenService.Issue(user)
+			Expect(err).To(BeNil())
+			token, err := jwtService.ValidateToken(tokens.Token)
+			Expect(err).To(BeNil())
+			jti := token.Claims.(*service.AccessClaims).ID
+
+			Expect(tokenService.IsRevoked(jti)).To(BeFalse())
+			Expect(tokenService.Logout(user.ID.String(), jti, time.Now().Add(time.Minute), tokens.RefreshToken)).To(Succeed())
+			Expect(tokenService.IsRevoked(jti)).To(BeTrue())
+
+			_, err = tokenService.Refresh(tokens.RefreshToken)
+			Expect(err).To(MatchError(service.ErrRefreshTokenReused))
+		})
+
+		It("should refuse to revoke another user's refresh token", func() {
+			tokens, err := tokenService.Issue(user)
+			Expect(err).To(BeNil())
+			err = tokenService.Logout("someone-else", "", time.Time{}, tokens.RefreshToken)
+			Expect(err).To(MatchError(service.ErrInvalidToken))
+		})
+	})
+})
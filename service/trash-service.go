@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"gorm.io/gorm"
+)
+
+// TrashConfig describes how long deleted videos are kept
+type TrashConfig struct {
+	// Retention is how long a deleted video stays in the trash
+	Retention time.Duration
+	// PurgeInterval is how often the retention job looks for videos to purge
+	PurgeInterval time.Duration
+}
+
+// TrashedVideo is a deleted video and the time it will be purged
+type TrashedVideo struct {
+	Video   entity.Video
+	PurgeAt time.Time
+}
+
+type TrashService interface {
+	// List returns the caller's deleted videos, or those of all users for
+	// holders of videos:admin
+	List(principal *auth.Principal) ([]TrashedVideo, error)
+	// Restore undeletes a video. Videos of other users are reported as not
+	// found unless the principal holds videos:admin.
+	Restore(id string, principal *auth.Principal) (*entity.Video, error)
+	// Purge deletes a video from the trash for good. Its revisions are
+	// kept, ending with a purge revision.
+	Purge(id string, principal *auth.Principal) error
+	// PurgeExpired purges the videos that have been in the trash for longer
+	// than the retention and returns how many there were
+	PurgeExpired() (int64, error)
+	// RunRetention purges expired videos now and after every purge interval
+	// until ctx is done
+	RunRetention(ctx context.Context)
+}
+
+type trashService struct {
+	config TrashConfig
+	trash  repository.TrashRepository
+}
+
+func NewTrashService(config TrashConfig, trash repository.TrashRepository) TrashService {
+	return &trashService{
+		config: config,
+		trash:  trash,
+	}
+}
+
+func (s *trashService) List(principal *auth.Principal) ([]TrashedVideo, error) {
+	if principal == nil {
+		return nil, ErrForbidden
+	}
+	ownerID := principal.UserID
+	if principal.HasPermission(auth.PermVideosAdmin) {
+		ownerID = ""
+	}
+	videos, err := s.trash.FindAll(ownerID)
+	if err != nil {
+		return nil, err
+	}
+	trashed := make([]TrashedVideo, len(videos))
+	for i, video := range videos {
+		trashed[i] = TrashedVideo{Video: video, PurgeAt: video.DeletedAt.Time.Add(s.config.Retention)}
+	}
+	return trashed, nil
+}
+
+func (s *trashService) Restore(id string, principal *auth.Principal) (*entity.Video, error) {
+	video, err := s.find(id, principal)
+	if err != nil {
+		return nil, err
+	}
+	revision := newRevision(entity.RevisionRestore, video, entity.NewVideoSnapshot(video), principal)
+	if err := s.trash.Restore(id, revision); errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrVideoNotFound
+	} else if err != nil {
+		return nil, err
+	}
+	video.DeletedAt = gorm.DeletedAt{}
+	video.Version = revision.Number
+	return video, nil
+}
+
+func (s *trashService) Purge(id string, principal *auth.Principal) error {
+	video, err := s.find(id, principal)
+	if err != nil {
+		return err
+	}
+	err = s.trash.Purge(id, purgeRevision(video, principal))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrVideoNotFound
+	}
+	return err
+}
+
+func (s *trashService) PurgeExpired() (int64, error) {
+	// The retention job purges videos on behalf of no one
+	return s.trash.PurgeDeletedBefore(time.Now().Add(-s.config.Retention), func(video *entity.Video) *entity.VideoRevision {
+		return purgeRevision(video, nil)
+	})
+}
+
+func (s *trashService) RunRetention(ctx context.Context) {
+	ticker := time.NewTicker(s.config.PurgeInterval)
+	defer ticker.Stop()
+	for {
+		purged, err := s.PurgeExpired()
+		if err != nil {
+			log.Printf("failed to purge the trash: %v", err)
+		} else if purged > 0 {
+			log.Printf("purged %d videos from the trash", purged)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// purgeRevision records the metadata a video had when it was purged
+func purgeRevision(video *entity.Video, principal *auth.Principal) *entity.VideoRevision {
+	return newRevision(entity.RevisionPurge, video, entity.NewVideoSnapshot(video), principal)
+}
+
+// find loads a deleted video the principal may restore or purge
+func (s *trashService) find(id string, principal *auth.Principal) (*entity.Video, error) {
+	video, err := s.trash.FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrVideoNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if principal.HasPermission(auth.PermVideosAdmin) {
+		return video, nil
+	}
+	if principal == nil || video.OwnerID == nil || video.OwnerID.String() != principal.UserID {
+		return nil, ErrVideoNotFound
+	}
+	return video, nil
+}
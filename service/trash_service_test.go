@@ -0,0 +1,141 @@
+package service_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+var _ = Describe("TrashService", func() {
+	var (
+		trashService    service.TrashService
+		videoService    service.VideoService
+		playlistService service.PlaylistService
+		video           entity.Video
+	)
+
+	trashedIDs := func(principal *auth.Principal) []string {
+		trashed, err := trashService.List(principal)
+		Expect(err).To(BeNil())
+		ids := []string{}
+		for _, item := range trashed {
+			ids = append(ids, item.Video.ID.String())
+		}
+		return ids
+	}
+
+	revisionActions := func() []string {
+		revisions, err := repository.NewRevisionRepository(testDB).FindByVideo(video.ID.String())
+		Expect(err).To(BeNil())
+		actions := []string{}
+		for _, revision := range revisions {
+			actions = append(actions, revision.Action)
+		}
+		return actions
+	}
+
+	BeforeEach(func() {
+		videoRepository := repository.NewVideoRepository(testDB)
+		videoService = service.New(videoRepository, repository.NewAuthorRepository(testDB), repository.NewRevisionRepository(testDB))
+		playlistService = service.NewPlaylistService(repository.NewPlaylistRepository(testDB), videoRepository)
+		trashService = service.NewTrashService(service.TrashConfig{
+			Retention:     time.Hour,
+			PurgeInterval: time.Hour,
+		}, repository.NewTrashRepository(testDB))
+
+		request := testVideo
+		request.Title = "Trashed video"
+		request.Tags = []string{"trashspec"}
+		var err error
+		video, err = videoService.Save(request, videoOwner)
+		Expect(err).To(BeNil())
+		Expect(videoService.Delete(video.ID.String(), service.AnyVersion, videoOwner)).To(Succeed())
+		DeferCleanup(func() {
+			_ = videoService.Delete(video.ID.String(), service.AnyVersion, videoAdmin)
+			_ = trashService.Purge(video.ID.String(), videoAdmin)
+		})
+	})
+
+	It("should list deleted videos to their owner and admins only", func() {
+		Expect(trashedIDs(videoOwner)).To(ContainElement(video.ID.String()))
+		Expect(trashedIDs(videoAdmin)).To(ContainElement(video.ID.String()))
+		Expect(trashedIDs(otherMember)).NotTo(ContainElement(video.ID.String()))
+
+		trashed, err := trashService.List(videoOwner)
+		Expect(err).To(BeNil())
+		for _, item := range trashed {
+			if item.Video.ID == video.ID {
+				Expect(item.PurgeAt).To(BeTemporally("~", item.Video.DeletedAt.Time.Add(time.Hour)))
+				Expect(entity.TagNames(item.Video.Tags)).To(Equal([]string{"trashspec"}))
+			}
+		}
+	})
+
+	It("should restore a video", func() {
+		_, err := trashService.Restore(video.ID.String(), otherMember)
+		Expect(err).To(MatchError(service.ErrVideoNotFound))
+
+		restored, err := trashService.Restore(video.ID.String(), videoOwner)
+		Expect(err).To(BeNil())
+		// Deleting and restoring both increase the version
+		Expect(restored.Version).To(Equal(video.Version + 2))
+		found, err := videoService.GetByID(video.ID.String())
+		Expect(err).To(BeNil())
+		Expect(found.Version).To(Equal(restored.Version))
+		Expect(entity.TagNames(found.Tags)).To(Equal([]string{"trashspec"}))
+		Expect(trashedIDs(videoOwner)).NotTo(ContainElement(video.ID.String()))
+
+		_, err = trashService.Restore(video.ID.String(), videoOwner)
+		Expect(err).To(MatchError(service.ErrVideoNotFound))
+	})
+
+	It("should purge a video with its playlist items", func() {
+		restored, err := trashService.Restore(video.ID.String(), videoOwner)
+		Expect(err).To(BeNil())
+		playlist, err := playlistService.Create(dto.PlaylistCreateRequest{Title: "Trash spec"}, videoOwner)
+		Expect(err).To(BeNil())
+		_, err = playlistService.AddItem(playlist.ID.String(), restored.ID.String(), nil, videoOwner)
+		Expect(err).To(BeNil())
+		Expect(videoService.Delete(video.ID.String(), service.AnyVersion, videoOwner)).To(Succeed())
+
+		Expect(trashService.Purge(video.ID.String(), otherMember)).To(MatchError(service.ErrVideoNotFound))
+		Expect(trashService.Purge(video.ID.String(), videoOwner)).To(Succeed())
+		Expect(trashedIDs(videoAdmin)).NotTo(ContainElement(video.ID.String()))
+		_, err = trashService.Restore(video.ID.String(), videoAdmin)
+		Expect(err).To(MatchError(service.ErrVideoNotFound))
+
+		found, err := playlistService.GetByID(playlist.ID.String(), videoOwner)
+		Expect(err).To(BeNil())
+		Expect(found.Items).To(BeEmpty())
+
+		Expect(revisionActions()).To(Equal([]string{entity.RevisionCreate, entity.RevisionDelete,
+			entity.RevisionRestore, entity.RevisionDelete, entity.RevisionPurge}))
+		purge, err := repository.NewRevisionRepository(testDB).FindOne(video.ID.String(), 5)
+		Expect(err).To(BeNil())
+		Expect(purge.ActorName).To(Equal(videoOwner.Username))
+		Expect(purge.After.Title).To(Equal("Trashed video"))
+	})
+
+	It("should purge videos deleted longer ago than the retention", func() {
+		Expect(trashService.PurgeExpired()).To(BeNumerically(">=", 0))
+		Expect(trashedIDs(videoOwner)).To(ContainElement(video.ID.String()))
+
+		expiring := service.NewTrashService(service.TrashConfig{
+			Retention:     time.Millisecond,
+			PurgeInterval: time.Hour,
+		}, repository.NewTrashRepository(testDB))
+		time.Sleep(10 * time.Millisecond)
+		purged, err := expiring.PurgeExpired()
+		Expect(err).To(BeNil())
+		Expect(purged).To(BeNumerically(">=", 1))
+		Expect(trashedIDs(videoOwner)).NotTo(ContainElement(video.ID.String()))
+		Expect(revisionActions()).To(Equal([]string{entity.RevisionCreate, entity.RevisionDelete, entity.RevisionPurge}))
+	})
+})
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/utils"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrUserExists   = errors.New("user already exists")
+	ErrUserNotFound = errors.New("user not found")
+	ErrUnknownRole  = errors.New("unknown role")
+)
+
+type UserService interface {
+	Create(username string, password string, roles ...string) (*entity.User, error)
+	EnsureUser(username string, password string, roles ...string) error
+	GetByID(id string) (*entity.User, error)
+	GetByUsername(username string) (*entity.User, error)
+	GetAll() ([]entity.User, error)
+	GetRoles() ([]entity.Role, error)
+	SetRoles(userID string, roles []string) (*entity.User, error)
+}
+
+type userService struct {
+	users repository.UserRepository
+	roles repository.RoleRepository
+}
+
+func NewUserService(users repository.UserRepository, roles repository.RoleRepository) UserService {
+	return &userService{
+		users: users,
+		roles: roles,
+	}
+}
+
+func (s *userService) Create(username string, password string, roles ...string) (*entity.User, error) {
+	if _, err := s.users.FindByUsername(username); err == nil {
+		return nil, ErrUserExists
+	} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	granted, err := s.findRoles(roles)
+	if err != nil {
+		return nil, err
+	}
+	hash, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+	return s.users.Save(&entity.User{
+		Username:     username,
+		PasswordHash: hash,
+		Roles:        granted,
+	})
+}
+
+// EnsureUser creates the account if it does not exist yet and makes sure it
+// holds the given roles. It is used to bootstrap the first account from the
+// environment on startup.
+func (s *userService) EnsureUser(username string, password string, roles ...string) error {
+	user, err := s.users.FindByUsername(username)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		_, err = s.Create(username, password, roles...)
+		return err
+	}
+	if err != nil {
+		return err
+	}
+
+	missing := false
+	for _, role := range roles {
+		if !containsString(user.RoleNames(), role) {
+			missing = true
+		}
+	}
+	if !missing {
+		return nil
+	}
+	_, err = s.SetRoles(user.ID.String(), append(user.RoleNames(), roles...))
+	return err
+}
+
+func (s *userService) GetByID(id string) (*entity.User, error) {
+	user, err := s.users.FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	return user, err
+}
+
+func (s *userService) GetByUsername(username string) (*entity.User, error) {
+	return s.users.FindByUsername(username)
+}
+
+func (s *userService) GetAll() ([]entity.User, error) {
+	return s.users.FindAll()
+}
+
+func (s *userService) GetRoles() ([]entity.Role, error) {
+	return s.roles.FindAll()
+}
+
+// SetRoles replaces the roles of a user. The change applies to access tokens
+// issued from then on, including tokens obtained through /auth/refresh.
+func (s *userService) SetRoles(userID string, roles []string) (*entity.User, error) {
+	user, err := s.users.FindByID(userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	granted, err := s.findRoles(roles)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.users.SetRoles(user, granted); err != nil {
+		return nil, err
+	}
+	return s.users.FindByID(userID)
+}
+
+func (s *userService) findRoles(names []string) ([]entity.Role, error) {
+	unique := []string{}
+	for _, name := range names {
+		if !containsString(unique, name) {
+			unique = append(unique, name)
+		}
+	}
+	roles, err := s.roles.FindByNames(unique)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrUnknownRole
+	}
+	return roles, err
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package service_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/entity"
+	"github.com/muzammil-cyber/golang-gin/repository"
+	"github.com/muzammil-cyber/golang-gin/service"
+)
+
+var _ = Describe("UserService", func() {
+	var (
+		userService service.UserService
+	)
+
+	BeforeEach(func() {
+		userService = service.NewUserService(repository.NewUserRepository(testDB), repository.NewRoleRepository(testDB))
+	})
+
+	Describe("Create", func() {
+		It("should store a hashed password", func() {
+			user, err := userService.Create("alice", "s3cret-pass")
+			Expect(err).To(BeNil())
+			Expect(user.ID).NotTo(BeZero())
+			Expect(user.PasswordHash).NotTo(BeEmpty())
+			Expect(user.PasswordHash).NotTo(Equal("s3cret-pass"))
+		})
+
+		It("should reject a duplicate username", func() {
+			_, err := userService.Create("alice", "another-pass")
+			Expect(err).To(MatchError(service.ErrUserExists))
+		})
+	})
+
+	Describe("EnsureUser", func() {
+		It("should be a no-op for an existing user", func() {
+			Expect(userService.EnsureUser("alice", "ignored")).To(Succeed())
+			user, err := userService.GetByUsername("alice")
+			Expect(err).To(BeNil())
+			Expect(user.Username).To(Equal("alice"))
+		})
+
+		It("should grant missing roles to an existing user", func() {
+			Expect(userService.EnsureUser("alice", "ignored", entity.RoleAdmin)).To(Succeed())
+			user, err := userService.GetByUsername("alice")
+			Expect(err).To(BeNil())
+			Expect(user.RoleNames()).To(ContainElement(entity.RoleAdmin))
+			Expect(user.Permissions()).To(ContainElement(auth.PermUsersAdmin))
+		})
+	})
+
+	Describe("SetRoles", func() {
+		It("should replace the roles of a user", func() {
+			user, err := userService.GetByUsername("alice")
+			Expect(err).To(BeNil())
+			updated, err := userService.SetRoles(user.ID.String(), []string{entity.RoleViewer})
+			Expect(err).To(BeNil())
+			Expect(updated.RoleNames()).To(ConsistOf(entity.RoleViewer))
+			Expect(updated.Permissions()).To(ConsistOf(auth.PermVideosRead))
+		})
+
+		It("should reject unknown roles", func() {
+			user, err := userService.GetByUsername("alice")
+			Expect(err).To(BeNil())
+			_, err = userService.SetRoles(user.ID.String(), []string{"superuser"})
+			Expect(err).To(MatchError(service.ErrUnknownRole))
+		})
+	})
+})
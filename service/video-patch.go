@@ -0,0 +1,163 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/go-playground/validator/v10"
+	"github.com/muzammil-cyber/golang-gin/auth"
+	"github.com/muzammil-cyber/golang-gin/dto"
+	"github.com/muzammil-cyber/golang-gin/entity"
+)
+
+// PatchFormat is the format of a PATCH request body
+type PatchFormat int
+
+const (
+	// MergePatch is an RFC 7396 JSON merge patch
+	MergePatch PatchFormat = iota
+	// JSONPatch is an RFC 6902 list of JSON Patch operations
+	JSONPatch
+)
+
+var (
+	ErrInvalidPatch = errors.New("invalid patch")
+	// ErrPatchTestFailed is returned when a JSON Patch test operation fails
+	ErrPatchTestFailed = errors.New("patch test operation failed")
+)
+
+// patchValidator checks patched documents against their binding rules
+var patchValidator = newPatchValidator()
+
+func newPatchValidator() *validator.Validate {
+	v := validator.New()
+	v.SetTagName("binding")
+	return v
+}
+
+func (s *videoService) Patch(id string, format PatchFormat, patch []byte, version int64, principal *auth.Principal) (entity.Video, error) {
+	existing, err := s.authorize(id, principal)
+	if err != nil {
+		return entity.Video{}, err
+	}
+	if err := checkVersion(existing, version); err != nil {
+		return entity.Video{}, err
+	}
+	document, err := applyVideoPatch(videoPatchDocument(existing), format, patch)
+	if err != nil {
+		return entity.Video{}, err
+	}
+	return s.saveDocument(existing, document, entity.RevisionUpdate, nil, principal)
+}
+
+// saveDocument saves the fields of document that differ from the existing
+// video and records the change as a revision with action. Nothing is saved
+// if no field differs.
+func (s *videoService) saveDocument(existing *entity.Video, document dto.VideoPatchDocument, action string, restoredFrom *int64, principal *auth.Principal) (entity.Video, error) {
+	if err := patchValidator.Struct(document); err != nil {
+		return entity.Video{}, err
+	}
+
+	video := *existing
+	var columns []string
+	if document.Title != video.Title {
+		video.Title = document.Title
+		columns = append(columns, "title")
+	}
+	if document.Description != video.Description {
+		video.Description = document.Description
+		columns = append(columns, "description")
+	}
+	if document.URL != video.URL {
+		video.URL = document.URL
+		columns = append(columns, "url")
+	}
+	if document.AuthorID != video.AuthorID.String() {
+		author, err := s.resolveAuthor(document.AuthorID, nil)
+		if err != nil {
+			return entity.Video{}, err
+		}
+		video.Author = *author
+		video.AuthorID = author.ID
+		columns = append(columns, "author_id")
+	}
+	tags, err := videoTags(document.Tags)
+	if err != nil {
+		return entity.Video{}, err
+	}
+	tagsChanged := !slices.Equal(sortedTagNames(tags), entity.TagNames(existing.Tags))
+	if tagsChanged {
+		video.Tags = tags
+	}
+
+	if len(columns) == 0 && !tagsChanged {
+		return video, nil
+	}
+	revision := newRevision(action, &video, entity.NewVideoSnapshot(existing), principal)
+	revision.RestoredFrom = restoredFrom
+	if err := s.videos.Patch(&video, columns, tagsChanged, revision); err != nil {
+		return entity.Video{}, versionError(err)
+	}
+	updated, err := s.videos.FindByID(existing.ID.String())
+	if err != nil {
+		return entity.Video{}, err
+	}
+	return *updated, nil
+}
+
+// videoPatchDocument returns the patchable fields of a video
+func videoPatchDocument(video *entity.Video) dto.VideoPatchDocument {
+	return dto.VideoPatchDocument{
+		Title:       video.Title,
+		Description: video.Description,
+		URL:         video.URL,
+		AuthorID:    video.AuthorID.String(),
+		Tags:        entity.TagNames(video.Tags),
+	}
+}
+
+// applyVideoPatch applies a patch to the document. Patches that leave
+// fields the document does not have are rejected.
+func applyVideoPatch(document dto.VideoPatchDocument, format PatchFormat, patch []byte) (dto.VideoPatchDocument, error) {
+	original, err := json.Marshal(document)
+	if err != nil {
+		return dto.VideoPatchDocument{}, err
+	}
+	var patched []byte
+	switch format {
+	case MergePatch:
+		patched, err = jsonpatch.MergePatch(original, patch)
+	case JSONPatch:
+		var operations jsonpatch.Patch
+		if operations, err = jsonpatch.DecodePatch(patch); err == nil {
+			patched, err = operations.Apply(original)
+		}
+	default:
+		err = fmt.Errorf("unknown patch format %d", format)
+	}
+	if errors.Is(err, jsonpatch.ErrTestFailed) {
+		return dto.VideoPatchDocument{}, fmt.Errorf("%w: %v", ErrPatchTestFailed, err)
+	}
+	if err != nil {
+		return dto.VideoPatchDocument{}, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	var result dto.VideoPatchDocument
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		return dto.VideoPatchDocument{}, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return result, nil
+}
+
+// sortedTagNames returns the names of tags in the order videos list them
+func sortedTagNames(tags []entity.Tag) []string {
+	names := entity.TagNames(tags)
+	slices.Sort(names)
+	return names
+}
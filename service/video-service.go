@@ -1,37 +1,84 @@
 package service
 
 import (
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/muzammil-cyber/golang-gin/auth"
 	"github.com/muzammil-cyber/golang-gin/dto"
 	"github.com/muzammil-cyber/golang-gin/entity"
 	"github.com/muzammil-cyber/golang-gin/repository"
+	"gorm.io/gorm"
+)
+
+// AnyVersion skips the version check of video updates and deletes
+const AnyVersion int64 = 0
+
+var (
+	ErrVideoNotFound = errors.New("video not found")
+	ErrForbidden     = errors.New("you are not allowed to modify this video")
+	// ErrVersionMismatch is returned when a video is not at the version an
+	// update or delete expects
+	ErrVersionMismatch = errors.New("the video has been changed since it was read")
 )
 
 type VideoService interface {
-	Save(dto.VideoCreateRequest) (entity.Video, error)
+	Save(dto.VideoCreateRequest, *auth.Principal) (entity.Video, error)
 	GetAll() ([]entity.Video, error)
+	List(query repository.VideoQuery) (repository.VideoPage, error)
 	GetByID(string) (*entity.Video, error)
-	Update(entity.Video) (entity.Video, error)
-	Delete(string) error
+	GetByOwner(ownerID string) ([]entity.Video, error)
+	GetByAuthor(authorID string) ([]entity.Video, error)
+	// Update replaces the video if it is at video.Version, or at any
+	// version if that is AnyVersion
+	Update(entity.Video, *auth.Principal) (entity.Video, error)
+	// Patch applies a merge patch or JSON Patch to the video's
+	// dto.VideoPatchDocument and saves the fields that changed. A patched
+	// document that fails validation yields validator.ValidationErrors.
+	Patch(id string, format PatchFormat, patch []byte, version int64, principal *auth.Principal) (entity.Video, error)
+	Delete(id string, version int64, principal *auth.Principal) error
+	// RestoreRevision sets the video's metadata back to how it was after
+	// the given revision, if the video is at version
+	RestoreRevision(id string, revision int64, version int64, principal *auth.Principal) (entity.Video, error)
 }
 
+// videoService records a revision for every change it makes to a video
 type videoService struct {
-	videos repository.VideoRepository
+	videos    repository.VideoRepository
+	authors   repository.AuthorRepository
+	revisions repository.RevisionRepository
 }
 
-func New(repo repository.VideoRepository) VideoService {
+func New(repo repository.VideoRepository, authors repository.AuthorRepository, revisions repository.RevisionRepository) VideoService {
 	return &videoService{
-		videos: repo,
+		videos:    repo,
+		authors:   authors,
+		revisions: revisions,
 	}
 }
 
-func (s *videoService) Save(video dto.VideoCreateRequest) (entity.Video, error) {
+func (s *videoService) Save(video dto.VideoCreateRequest, principal *auth.Principal) (entity.Video, error) {
+	tags, err := videoTags(video.Tags)
+	if err != nil {
+		return entity.Video{}, err
+	}
+	author, err := s.resolveAuthor(video.AuthorID, video.Author)
+	if err != nil {
+		return entity.Video{}, err
+	}
 	entityVideo := entity.Video{
 		Title:       video.Title,
 		Description: video.Description,
 		URL:         video.URL,
-		Author:      video.Author,
+		AuthorID:    author.ID,
+		Tags:        tags,
 	}
-	createdVideo, err := s.videos.Save(&entityVideo)
+	if principal != nil {
+		if ownerID, err := uuid.Parse(principal.UserID); err == nil {
+			entityVideo.OwnerID = &ownerID
+		}
+	}
+	createdVideo, err := s.videos.Save(&entityVideo, newRevision(entity.RevisionCreate, &entityVideo, nil, principal))
 	if err != nil {
 		return entity.Video{}, err
 	}
@@ -42,15 +89,153 @@ func (s *videoService) GetAll() ([]entity.Video, error) {
 	return s.videos.FindAll()
 }
 
-func (s *videoService) GetByID(id string) (*entity.Video, error) {
-	return s.videos.FindByID(id)
+func (s *videoService) List(query repository.VideoQuery) (repository.VideoPage, error) {
+	tags, err := normalizeTags(query.Tags)
+	if err != nil {
+		return repository.VideoPage{}, err
+	}
+	query.Tags = tags
+	return s.videos.FindPage(query)
 }
 
-func (s *videoService) Update(video entity.Video) (entity.Video, error) {
-	err := s.videos.Update(&video)
+func (s *videoService) GetByID(id string) (*entity.Video, error) {
+	video, err := s.videos.FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrVideoNotFound
+	}
 	return video, err
 }
 
-func (s *videoService) Delete(id string) error {
-	return s.videos.Delete(id)
+func (s *videoService) GetByOwner(ownerID string) ([]entity.Video, error) {
+	return s.videos.FindByOwner(ownerID)
+}
+
+func (s *videoService) Update(video entity.Video, principal *auth.Principal) (entity.Video, error) {
+	existing, err := s.authorize(video.ID.String(), principal)
+	if err != nil {
+		return entity.Video{}, err
+	}
+	if err := checkVersion(existing, video.Version); err != nil {
+		return entity.Video{}, err
+	}
+	video.Version = existing.Version
+	// Ownership and creation time cannot be changed through an update
+	video.OwnerID = existing.OwnerID
+	video.CreatedAt = existing.CreatedAt
+	// The author is kept unless the update names another one
+	if video.Author.ID == uuid.Nil && video.Author.Email == "" {
+		video.Author = existing.Author
+	}
+	author, err := s.resolveAuthor(video.Author.ID.String(), &video.Author)
+	if err != nil {
+		return entity.Video{}, err
+	}
+	video.Author = *author
+	video.AuthorID = author.ID
+	// Tags are kept unless the update sets them
+	if video.Tags == nil {
+		video.Tags = existing.Tags
+	} else {
+		video.Tags, err = videoTags(entity.TagNames(video.Tags))
+		if err != nil {
+			return entity.Video{}, err
+		}
+	}
+	revision := newRevision(entity.RevisionUpdate, &video, entity.NewVideoSnapshot(existing), principal)
+	if err := s.videos.Update(&video, revision); err != nil {
+		return entity.Video{}, versionError(err)
+	}
+	return video, nil
+}
+
+func (s *videoService) Delete(id string, version int64, principal *auth.Principal) error {
+	video, err := s.authorize(id, principal)
+	if err != nil {
+		return err
+	}
+	if err := checkVersion(video, version); err != nil {
+		return err
+	}
+	snapshot := entity.NewVideoSnapshot(video)
+	return versionError(s.videos.Delete(id, video.Version, newRevision(entity.RevisionDelete, video, snapshot, principal)))
+}
+
+func (s *videoService) RestoreRevision(id string, number int64, version int64, principal *auth.Principal) (entity.Video, error) {
+	existing, err := s.authorize(id, principal)
+	if err != nil {
+		return entity.Video{}, err
+	}
+	if err := checkVersion(existing, version); err != nil {
+		return entity.Video{}, err
+	}
+	revision, err := findRevision(s.revisions, id, number)
+	if err != nil {
+		return entity.Video{}, err
+	}
+	document := dto.VideoPatchDocument{
+		Title:       revision.After.Title,
+		Description: revision.After.Description,
+		URL:         revision.After.URL,
+		AuthorID:    revision.After.AuthorID.String(),
+		Tags:        revision.After.Tags,
+	}
+	return s.saveDocument(existing, document, entity.RevisionRollback, &number, principal)
+}
+
+func (s *videoService) GetByAuthor(authorID string) ([]entity.Video, error) {
+	return s.videos.FindByAuthor(authorID)
+}
+
+// resolveAuthor returns the existing author with authorID, or else creates
+// author or updates the author with the same email
+func (s *videoService) resolveAuthor(authorID string, author *entity.Person) (*entity.Person, error) {
+	if authorID != "" && authorID != uuid.Nil.String() {
+		existing, err := s.authors.FindByID(authorID)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, ErrAuthorNotFound
+		}
+		return existing, err
+	}
+	if author == nil || author.Email == "" {
+		return nil, ErrAuthorRequired
+	}
+	upserted := entity.Person{Name: author.Name, Age: author.Age, Email: normalizeEmail(author.Email)}
+	return s.authors.Upsert(&upserted)
+}
+
+// checkVersion fails with ErrVersionMismatch unless the video is at version
+// or version is AnyVersion
+func checkVersion(video *entity.Video, version int64) error {
+	if version != AnyVersion && video.Version != version {
+		return ErrVersionMismatch
+	}
+	return nil
+}
+
+// versionError reports a video changed by a concurrent write as
+// ErrVersionMismatch
+func versionError(err error) error {
+	if errors.Is(err, repository.ErrVersionConflict) {
+		return ErrVersionMismatch
+	}
+	return err
+}
+
+// authorize loads a video and checks that the principal may modify it:
+// either they created it or they hold the videos:admin permission.
+func (s *videoService) authorize(id string, principal *auth.Principal) (*entity.Video, error) {
+	video, err := s.videos.FindByID(id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, ErrVideoNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if principal.HasPermission(auth.PermVideosAdmin) {
+		return video, nil
+	}
+	if principal == nil || video.OwnerID == nil || video.OwnerID.String() != principal.UserID {
+		return nil, ErrForbidden
+	}
+	return video, nil
 }
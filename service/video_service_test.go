@@ -4,6 +4,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/muzammil-cyber/golang-gin/auth"
 	"github.com/muzammil-cyber/golang-gin/dto"
 	"github.com/muzammil-cyber/golang-gin/entity"
 	"github.com/muzammil-cyber/golang-gin/repository"
@@ -14,7 +15,7 @@ var testVideo = dto.VideoCreateRequest{
 	Title:       "Test Video",
 	Description: "This is a test video",
 	URL:         "https://www.example.com/test-video",
-	Author: entity.Person{
+	Author: &entity.Person{
 		Name:  "Test Author",
 		Email: "example@gmail.com",
 		Age:   23,
@@ -23,6 +24,24 @@ var testVideo = dto.VideoCreateRequest{
 
 var savedVideo entity.Video
 
+var (
+	videoOwner = &auth.Principal{
+		UserID:      "6f1c1f8e-3c1e-4c43-9d0b-5b0a3f6e2a11",
+		Username:    "owner",
+		Permissions: []string{auth.PermVideosRead, auth.PermVideosWrite, auth.PermVideosDelete},
+	}
+	otherMember = &auth.Principal{
+		UserID:      "0b7e2c55-8f0e-4f5e-a9f3-1d2c3b4a5e66",
+		Username:    "other",
+		Permissions: []string{auth.PermVideosRead, auth.PermVideosWrite, auth.PermVideosDelete},
+	}
+	videoAdmin = &auth.Principal{
+		UserID:      "9a8b7c6d-5e4f-4a3b-8c2d-1e0f9a8b7c6d",
+		Username:    "moderator",
+		Permissions: []string{auth.PermVideosRead, auth.PermVideosWrite, auth.PermVideosDelete, auth.PermVideosAdmin},
+	}
+)
+
 var _ = Describe("VideoService", func() {
 	var (
 		videoService    service.VideoService
@@ -30,14 +49,16 @@ var _ = Describe("VideoService", func() {
 	)
 
 	BeforeEach(func() {
-		videoRepository = repository.NewVideoRepository()
-		videoService = service.New(videoRepository)
+		videoRepository = repository.NewVideoRepository(testDB)
+		videoService = service.New(videoRepository, repository.NewAuthorRepository(testDB), repository.NewRevisionRepository(testDB))
 	})
 
 	Describe("Save", func() {
 		It("should save a video successfully", func() {
-			createdVideo, err := videoService.Save(testVideo)
+			createdVideo, err := videoService.Save(testVideo, videoOwner)
 			Expect(err).To(BeNil())
+			Expect(createdVideo.OwnerID).NotTo(BeNil())
+			Expect(createdVideo.OwnerID.String()).To(Equal(videoOwner.UserID))
 			Expect(createdVideo.ID).NotTo(BeEmpty())
 			Expect(createdVideo.Title).To(Equal(testVideo.Title))
 			Expect(createdVideo.Description).To(Equal(testVideo.Description))
@@ -64,23 +85,349 @@ var _ = Describe("VideoService", func() {
 			Expect(video.Title).To(Equal(savedVideo.Title))
 		})
 	})
+	Describe("GetByOwner", func() {
+		It("should only return the owner's videos", func() {
+			videos, err := videoService.GetByOwner(videoOwner.UserID)
+			Expect(err).To(BeNil())
+			Expect(videos).To(HaveLen(1))
+			Expect(videos[0].ID).To(Equal(savedVideo.ID))
+
+			videos, err = videoService.GetByOwner(otherMember.UserID)
+			Expect(err).To(BeNil())
+			Expect(videos).To(BeEmpty())
+		})
+	})
+
 	Describe("Update", func() {
 		It("should update the video's title", func() {
 			savedVideo.Title = "Updated Test Video"
-			updatedVideo, err := videoService.Update(savedVideo)
+			updatedVideo, err := videoService.Update(savedVideo, videoOwner)
 			Expect(err).To(BeNil())
 			Expect(updatedVideo.Title).To(Equal("Updated Test Video"))
+			// Later updates need the new version
+			savedVideo.Version = updatedVideo.Version
+		})
+
+		It("should refuse updates from another user", func() {
+			_, err := videoService.Update(savedVideo, otherMember)
+			Expect(err).To(MatchError(service.ErrForbidden))
+		})
+
+		It("should let an admin update any video and keep the owner", func() {
+			video := savedVideo
+			video.OwnerID = nil
+			updatedVideo, err := videoService.Update(video, videoAdmin)
+			Expect(err).To(BeNil())
+			Expect(updatedVideo.OwnerID.String()).To(Equal(videoOwner.UserID))
+		})
+	})
+
+	Describe("Versions", func() {
+		var video entity.Video
+
+		BeforeEach(func() {
+			request := testVideo
+			request.Title = "Versioned video"
+			var err error
+			video, err = videoService.Save(request, videoOwner)
+			Expect(err).To(BeNil())
+			Expect(video.Version).To(Equal(int64(1)))
+		})
+
+		It("should refuse updates based on an older version", func() {
+			first := video
+			first.Title = "First edit"
+			updated, err := videoService.Update(first, videoOwner)
+			Expect(err).To(BeNil())
+			Expect(updated.Version).To(Equal(int64(2)))
+
+			second := video
+			second.Title = "Second edit"
+			_, err = videoService.Update(second, videoOwner)
+			Expect(err).To(MatchError(service.ErrVersionMismatch))
+			_, err = videoService.Patch(video.ID.String(), service.MergePatch, []byte(`{"title": "Third edit"}`), video.Version, videoOwner)
+			Expect(err).To(MatchError(service.ErrVersionMismatch))
+			Expect(videoService.Delete(video.ID.String(), video.Version, videoOwner)).To(MatchError(service.ErrVersionMismatch))
+
+			found, err := videoService.GetByID(video.ID.String())
+			Expect(err).To(BeNil())
+			Expect(found.Title).To(Equal("First edit"))
+			Expect(videoService.Delete(video.ID.String(), found.Version, videoOwner)).To(Succeed())
+		})
+
+		It("should skip the check for AnyVersion", func() {
+			patched, err := videoService.Patch(video.ID.String(), service.MergePatch, []byte(`{"title": "Patched edit"}`), video.Version, videoOwner)
+			Expect(err).To(BeNil())
+			Expect(patched.Version).To(Equal(int64(2)))
+
+			stale := video
+			stale.Title = "Forced edit"
+			stale.Version = service.AnyVersion
+			updated, err := videoService.Update(stale, videoOwner)
+			Expect(err).To(BeNil())
+			Expect(updated.Version).To(Equal(int64(3)))
+			Expect(videoService.Delete(video.ID.String(), service.AnyVersion, videoOwner)).To(Succeed())
 		})
 	})
 
 	Describe("Delete", func() {
+		It("should refuse deletes from another user", func() {
+			err := videoService.Delete(savedVideo.ID.String(), service.AnyVersion, otherMember)
+			Expect(err).To(MatchError(service.ErrForbidden))
+		})
+
+		It("should report missing videos", func() {
+			err := videoService.Delete("00000000-0000-0000-0000-000000000000", service.AnyVersion, videoOwner)
+			Expect(err).To(MatchError(service.ErrVideoNotFound))
+		})
+
 		It("should delete the saved video", func() {
-			err := videoService.Delete(savedVideo.ID.String())
+			err := videoService.Delete(savedVideo.ID.String(), service.AnyVersion, videoOwner)
 			Expect(err).To(BeNil())
 
 			deletedVideo, err := videoService.GetByID(savedVideo.ID.String())
-			Expect(err).NotTo(BeNil())
+			Expect(err).To(MatchError(service.ErrVideoNotFound))
 			Expect(deletedVideo).To(BeNil())
 		})
 	})
+
+	Describe("List", func() {
+		var listed []entity.Video
+
+		BeforeEach(func() {
+			listed = nil
+			// Titles are saved out of order so that sorting is observable
+			for _, title := range []string{"Paging C", "Paging A", "Paging E", "Paging B", "Paging D"} {
+				request := testVideo
+				request.Title = title
+				video, err := videoService.Save(request, videoAdmin)
+				Expect(err).To(BeNil())
+				listed = append(listed, video)
+			}
+			DeferCleanup(func() {
+				for _, video := range listed {
+					Expect(videoService.Delete(video.ID.String(), service.AnyVersion, videoAdmin)).To(Succeed())
+				}
+			})
+		})
+
+		collect := func(query repository.VideoQuery) ([]string, int64) {
+			titles := []string{}
+			var total int64
+			for {
+				page, err := videoService.List(query)
+				Expect(err).To(BeNil())
+				Expect(len(page.Videos)).To(BeNumerically("<=", query.Limit))
+				total = page.Total
+				for _, video := range page.Videos {
+					titles = append(titles, video.Title)
+				}
+				if page.NextCursor == "" {
+					return titles, total
+				}
+				query.After = page.NextCursor
+			}
+		}
+
+		It("should walk every page in title order", func() {
+			titles, total := collect(repository.VideoQuery{Limit: 2, Sort: repository.VideoSortTitle, OwnerID: videoAdmin.UserID})
+			Expect(total).To(BeEquivalentTo(5))
+			Expect(titles).To(Equal([]string{"Paging A", "Paging B", "Paging C", "Paging D", "Paging E"}))
+
+			titles, _ = collect(repository.VideoQuery{Limit: 3, Sort: repository.VideoSortTitle, Desc: true, OwnerID: videoAdmin.UserID})
+			Expect(titles).To(Equal([]string{"Paging E", "Paging D", "Paging C", "Paging B", "Paging A"}))
+		})
+
+		It("should page backwards from the end", func() {
+			query := repository.VideoQuery{Limit: 2, Sort: repository.VideoSortTitle, FromEnd: true, OwnerID: videoAdmin.UserID}
+			page, err := videoService.List(query)
+			Expect(err).To(BeNil())
+			Expect(page.Videos).To(HaveLen(2))
+			Expect(page.Videos[0].Title).To(Equal("Paging D"))
+			Expect(page.Videos[1].Title).To(Equal("Paging E"))
+			Expect(page.HasPrevious).To(BeTrue())
+			Expect(page.HasNext).To(BeFalse())
+
+			query.Before = query.Cursor(page.Videos[0])
+			page, err = videoService.List(query)
+			Expect(err).To(BeNil())
+			Expect(page.Videos[0].Title).To(Equal("Paging B"))
+			Expect(page.Videos[1].Title).To(Equal("Paging C"))
+			Expect(page.HasNext).To(BeTrue())
+		})
+
+		It("should walk every page in creation order", func() {
+			titles, _ := collect(repository.VideoQuery{Limit: 2, OwnerID: videoAdmin.UserID})
+			Expect(titles).To(Equal([]string{"Paging C", "Paging A", "Paging E", "Paging B", "Paging D"}))
+		})
+
+		It("should filter by title and creation time", func() {
+			page, err := videoService.List(repository.VideoQuery{TitleContains: "paging b", OwnerID: videoAdmin.UserID})
+			Expect(err).To(BeNil())
+			Expect(page.Total).To(BeEquivalentTo(1))
+			Expect(page.Videos[0].Title).To(Equal("Paging B"))
+
+			after := listed[2].CreatedAt
+			page, err = videoService.List(repository.VideoQuery{CreatedAfter: &after, OwnerID: videoAdmin.UserID})
+			Expect(err).To(BeNil())
+			Expect(page.Total).To(BeEquivalentTo(2))
+		})
+
+		It("should reject a cursor from a different sort order", func() {
+			page, err := videoService.List(repository.VideoQuery{Limit: 1, OwnerID: videoAdmin.UserID})
+			Expect(err).To(BeNil())
+			Expect(page.NextCursor).NotTo(BeEmpty())
+
+			_, err = videoService.List(repository.VideoQuery{After: page.NextCursor, Sort: repository.VideoSortTitle})
+			Expect(err).To(MatchError(repository.ErrInvalidCursor))
+			_, err = videoService.List(repository.VideoQuery{After: "not-a-cursor"})
+			Expect(err).To(MatchError(repository.ErrInvalidCursor))
+		})
+	})
+
+	Describe("Tags", func() {
+		save := func(title string, tags ...string) entity.Video {
+			request := testVideo
+			request.Title = title
+			request.Tags = tags
+			video, err := videoService.Save(request, videoAdmin)
+			Expect(err).To(BeNil())
+			DeferCleanup(func() {
+				Expect(videoService.Delete(video.ID.String(), service.AnyVersion, videoAdmin)).To(Succeed())
+			})
+			return video
+		}
+
+		titles := func(query repository.VideoQuery) []string {
+			page, err := videoService.List(query)
+			Expect(err).To(BeNil())
+			found := []string{}
+			for _, video := range page.Videos {
+				found = append(found, video.Title)
+			}
+			return found
+		}
+
+		It("should normalize and deduplicate tags", func() {
+			video := save("Tagged video", " Tagspec  Go ", "tagspec go", "TAGSPEC-web")
+			Expect(entity.TagNames(video.Tags)).To(Equal([]string{"tagspec go", "tagspec-web"}))
+		})
+
+		It("should reject malformed tags", func() {
+			request := testVideo
+			request.Tags = []string{"#hashtag"}
+			_, err := videoService.Save(request, videoAdmin)
+			Expect(err).To(MatchError(service.ErrInvalidTag))
+		})
+
+		It("should keep tags on updates without tags and replace them otherwise", func() {
+			video := save("Retagged video", "tagspec-old")
+			video.Tags = nil
+			video.Title = "Retagged video 2"
+			_, err := videoService.Update(video, videoAdmin)
+			Expect(err).To(BeNil())
+			reloaded, err := videoService.GetByID(video.ID.String())
+			Expect(err).To(BeNil())
+			Expect(entity.TagNames(reloaded.Tags)).To(Equal([]string{"tagspec-old"}))
+
+			reloaded.Tags = []entity.Tag{{Name: "tagspec-new"}}
+			_, err = videoService.Update(*reloaded, videoAdmin)
+			Expect(err).To(BeNil())
+			reloaded, err = videoService.GetByID(video.ID.String())
+			Expect(err).To(BeNil())
+			Expect(entity.TagNames(reloaded.Tags)).To(Equal([]string{"tagspec-new"}))
+		})
+
+		It("should filter by any or all of the tags", func() {
+			save("Tag filter A", "tagspec-a")
+			save("Tag filter B", "tagspec-b")
+			save("Tag filter AB", "tagspec-a", "tagspec-b")
+
+			query := repository.VideoQuery{Sort: repository.VideoSortTitle, Tags: []string{"tagspec-a", "TagSpec-B"}}
+			Expect(titles(query)).To(Equal([]string{"Tag filter A", "Tag filter AB", "Tag filter B"}))
+			query.MatchAllTags = true
+			Expect(titles(query)).To(Equal([]string{"Tag filter AB"}))
+		})
+
+		It("should count the videos carrying each tag", func() {
+			save("Tag count 1", "tagspec-count")
+			request := testVideo
+			request.Tags = []string{"tagspec-count"}
+			deleted, err := videoService.Save(request, videoAdmin)
+			Expect(err).To(BeNil())
+			Expect(videoService.Delete(deleted.ID.String(), service.AnyVersion, videoAdmin)).To(Succeed())
+			save("Tag count 3", "tagspec-count")
+
+			counts, err := service.NewTagService(repository.NewTagRepository(testDB)).GetAll()
+			Expect(err).To(BeNil())
+			Expect(counts).To(ContainElement(repository.TagCount{Name: "tagspec-count", Count: 2}))
+		})
+	})
+
+	Describe("Patch", func() {
+		var video entity.Video
+
+		BeforeEach(func() {
+			request := testVideo
+			request.Title = "Patched video"
+			request.Tags = []string{"patchspec-a", "patchspec-b"}
+			var err error
+			video, err = videoService.Save(request, videoOwner)
+			Expect(err).To(BeNil())
+			DeferCleanup(func() {
+				Expect(videoService.Delete(video.ID.String(), service.AnyVersion, videoAdmin)).To(Succeed())
+			})
+		})
+
+		patch := func(format service.PatchFormat, body string) (entity.Video, error) {
+			return videoService.Patch(video.ID.String(), format, []byte(body), service.AnyVersion, videoOwner)
+		}
+
+		It("should only change the fields in a merge patch", func() {
+			patched, err := patch(service.MergePatch, `{"title": "Merged title", "description": null}`)
+			Expect(err).To(BeNil())
+			Expect(patched.Title).To(Equal("Merged title"))
+			Expect(patched.Description).To(BeEmpty())
+			Expect(patched.URL).To(Equal(video.URL))
+			Expect(patched.Author.Email).To(Equal(video.Author.Email))
+			Expect(entity.TagNames(patched.Tags)).To(Equal([]string{"patchspec-a", "patchspec-b"}))
+			Expect(patched.UpdatedAt).To(BeTemporally(">", video.UpdatedAt))
+		})
+
+		It("should apply JSON Patch operations", func() {
+			patched, err := patch(service.JSONPatch, `[
+				{"op": "test", "path": "/title", "value": "Patched video"},
+				{"op": "add", "path": "/tags/-", "value": "PatchSpec-C"},
+				{"op": "remove", "path": "/tags/0"},
+				{"op": "replace", "path": "/url", "value": "https://www.example.com/patched"}
+			]`)
+			Expect(err).To(BeNil())
+			Expect(patched.URL).To(Equal("https://www.example.com/patched"))
+			Expect(entity.TagNames(patched.Tags)).To(Equal([]string{"patchspec-b", "patchspec-c"}))
+
+			_, err = patch(service.JSONPatch, `[{"op": "test", "path": "/title", "value": "Another title"}]`)
+			Expect(err).To(MatchError(service.ErrPatchTestFailed))
+		})
+
+		It("should validate the patched video", func() {
+			_, err := patch(service.MergePatch, `{"title": "No"}`)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("min"))
+			_, err = patch(service.MergePatch, `{"author": {"name": "Someone"}}`)
+			Expect(err).To(MatchError(service.ErrInvalidPatch))
+			_, err = patch(service.JSONPatch, `{"op": "remove"}`)
+			Expect(err).To(MatchError(service.ErrInvalidPatch))
+			_, err = patch(service.MergePatch, `{"author_id": "00000000-0000-0000-0000-000000000001"}`)
+			Expect(err).To(MatchError(service.ErrAuthorNotFound))
+
+			found, err := videoService.GetByID(video.ID.String())
+			Expect(err).To(BeNil())
+			Expect(found.Title).To(Equal("Patched video"))
+		})
+
+		It("should refuse patches from another user", func() {
+			_, err := videoService.Patch(video.ID.String(), service.MergePatch, []byte(`{"title": "Taken over"}`), service.AnyVersion, otherMember)
+			Expect(err).To(MatchError(service.ErrForbidden))
+		})
+	})
 })